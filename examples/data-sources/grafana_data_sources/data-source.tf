@@ -0,0 +1,24 @@
+resource "grafana_data_source" "prometheus" {
+  type = "prometheus"
+  name = "prometheus-list"
+  url  = "http://localhost:9090"
+}
+
+resource "grafana_data_source" "loki" {
+  type = "loki"
+  name = "loki-list"
+  url  = "http://localhost:3100"
+}
+
+data "grafana_data_sources" "all" {
+  depends_on = [grafana_data_source.prometheus, grafana_data_source.loki]
+}
+
+data "grafana_data_sources" "prometheus" {
+  depends_on = [grafana_data_source.prometheus, grafana_data_source.loki]
+  type       = "prometheus"
+}
+
+output "prometheus_uids" {
+  value = [for d in data.grafana_data_sources.prometheus.datasources : d.uid]
+}
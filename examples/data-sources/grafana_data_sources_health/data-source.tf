@@ -0,0 +1,10 @@
+resource "grafana_data_source" "prometheus" {
+  type = "prometheus"
+  name = "prometheus-health"
+  url  = "http://localhost:9090"
+}
+
+data "grafana_data_sources_health" "prometheus" {
+  depends_on = [grafana_data_source.prometheus]
+  types      = ["prometheus"]
+}
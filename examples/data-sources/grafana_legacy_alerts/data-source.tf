@@ -0,0 +1,38 @@
+resource "grafana_data_source" "test" {
+  type = "prometheus"
+  name = "legacy-alerts-prometheus"
+  url  = "http://localhost:9090"
+}
+
+resource "grafana_dashboard" "test" {
+  config_json = jsonencode({
+    uid   = "legacy-alerts-dashboard"
+    title = "Dashboard with legacy alerts"
+    panels = [{
+      id         = 1
+      type       = "graph"
+      title      = "Errors"
+      datasource = { type = "prometheus", uid = grafana_data_source.test.uid }
+      targets = [{
+        refId = "A"
+        expr  = "sum(rate(errors_total[5m]))"
+      }]
+      alert = {
+        name      = "Too many errors"
+        frequency = "1m"
+        for       = "5m"
+        conditions = [{
+          type      = "query"
+          query     = { params = ["A", "5m", "now"] }
+          reducer   = { type = "avg", params = [] }
+          evaluator = { type = "gt", params = [10] }
+          operator  = { type = "and" }
+        }]
+      }
+    }]
+  })
+}
+
+data "grafana_legacy_alerts" "test" {
+  dashboard_uid = grafana_dashboard.test.uid
+}
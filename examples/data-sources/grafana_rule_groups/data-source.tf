@@ -0,0 +1,64 @@
+resource "grafana_folder" "alerts" {
+  title = "Rule groups data source"
+  uid   = "rule-groups-data-source"
+}
+
+resource "grafana_rule_group" "availability" {
+  name             = "availability"
+  folder_uid       = grafana_folder.alerts.uid
+  interval_seconds = 60
+
+  dynamic "rule" {
+    for_each = ["API down", "Website down"]
+    content {
+      name      = rule.value
+      condition = "A"
+      data {
+        ref_id         = "A"
+        datasource_uid = "__expr__"
+        relative_time_range {
+          from = 0
+          to   = 0
+        }
+        model = jsonencode({
+          expression = "0 > 1"
+          refId      = "A"
+          type       = "math"
+        })
+      }
+    }
+  }
+}
+
+resource "grafana_rule_group" "latency" {
+  name             = "latency"
+  folder_uid       = grafana_folder.alerts.uid
+  interval_seconds = 60
+
+  rule {
+    name      = "API slow"
+    condition = "A"
+    data {
+      ref_id         = "A"
+      datasource_uid = "__expr__"
+      relative_time_range {
+        from = 0
+        to   = 0
+      }
+      model = jsonencode({
+        expression = "0 > 1"
+        refId      = "A"
+        type       = "math"
+      })
+    }
+  }
+}
+
+data "grafana_rule_groups" "alerts" {
+  folder_uid = grafana_folder.alerts.uid
+
+  depends_on = [
+    grafana_rule_group.availability,
+    grafana_rule_group.latency,
+  ]
+}
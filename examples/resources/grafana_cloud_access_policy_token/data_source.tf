@@ -0,0 +1,35 @@
+data "grafana_cloud_stack" "stack" {
+  slug = "<your stack slug>"
+}
+
+// A policy that can only read the metrics of the stack
+resource "grafana_cloud_access_policy" "metrics_read" {
+  region = data.grafana_cloud_stack.stack.region_slug
+  name   = "metrics-read-datasource"
+
+  scopes = ["metrics:read"]
+
+  realm {
+    type       = "stack"
+    identifier = data.grafana_cloud_stack.stack.id
+  }
+}
+
+resource "grafana_cloud_access_policy_token" "metrics_read" {
+  region           = data.grafana_cloud_stack.stack.region_slug
+  access_policy_id = grafana_cloud_access_policy.metrics_read.policy_id
+  name             = "metrics-read-datasource"
+}
+
+// The token authenticates the data source, with the ID of the metrics instance as username
+resource "grafana_data_source" "metrics" {
+  type                = "prometheus"
+  name                = "metrics-read-only"
+  url                 = data.grafana_cloud_stack.stack.prometheus_url
+  basic_auth_enabled  = true
+  basic_auth_username = data.grafana_cloud_stack.stack.prometheus_user_id
+
+  secure_json_data_encoded = jsonencode({
+    basicAuthPassword = grafana_cloud_access_policy_token.metrics_read.token
+  })
+}
@@ -8,6 +8,11 @@ resource "grafana_user" "user" {
   login    = "user.name"
 }
 
+resource "grafana_service_account" "sa" {
+  name = "deployer"
+  role = "Viewer"
+}
+
 resource "grafana_dashboard" "metrics" {
   config_json = jsonencode({
     "title" : "My Dashboard",
@@ -29,4 +34,8 @@ resource "grafana_dashboard_permission" "collectionPermission" {
     user_id    = grafana_user.user.id
     permission = "Admin"
   }
+  permissions {
+    service_account_id = grafana_service_account.sa.id
+    permission         = "Edit"
+  }
 }
@@ -0,0 +1,15 @@
+resource "grafana_dashboard_snapshot" "test" {
+  name    = "Production Overview (incident 1234)"
+  expires = 86400
+  config_json = jsonencode({
+    title = "Production Overview"
+    panels = [{
+      id    = 1
+      type  = "stat"
+      title = "Errors"
+      snapshotData = [{
+        fields = [{ name = "Value", values = [42] }]
+      }]
+    }]
+  })
+}
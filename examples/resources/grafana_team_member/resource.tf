@@ -0,0 +1,16 @@
+resource "grafana_team" "my_team" {
+  name = "My Team"
+  # The members are managed with grafana_team_member
+  ignore_members = true
+}
+
+resource "grafana_user" "viewer" {
+  email    = "viewer@example.com"
+  login    = "viewer"
+  password = "my-password"
+}
+
+resource "grafana_team_member" "viewer" {
+  team_id = grafana_team.my_team.id
+  user_id = grafana_user.viewer.user_id
+}
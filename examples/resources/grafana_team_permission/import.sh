@@ -0,0 +1,2 @@
+terraform import grafana_team_permission.name "{{ teamID }}"
+terraform import grafana_team_permission.name "{{ orgID }}:{{ teamID }}"
@@ -0,0 +1,20 @@
+resource "grafana_user" "user" {
+  email    = "team-admin@example.com"
+  login    = "team-admin"
+  password = "my-password"
+}
+
+# Team admins are members of the team
+resource "grafana_team" "team" {
+  name    = "Team Name"
+  members = [grafana_user.user.email]
+}
+
+resource "grafana_team_permission" "team" {
+  team_id = grafana_team.team.id
+
+  permissions {
+    user_id    = grafana_user.user.id
+    permission = "Admin"
+  }
+}
@@ -14,6 +14,7 @@ import (
 	SMAPI "github.com/grafana/synthetic-monitoring-api-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/time/rate"
 )
 
 type Client struct {
@@ -28,6 +29,21 @@ type Client struct {
 	OnCallClient    *onCallAPI.Client
 	SLOClient       *slo.APIClient
 
+	// RateLimiter is shared by all the clients above, it's nil if the provider's `api_rate_limit` isn't set
+	RateLimiter *rate.Limiter
+
+	// DashboardDatasources caches the data sources and plugins found while validating the data source references of dashboards.
+	// A plan usually contains many dashboards referencing the same few data sources, so each one is only looked up once.
+	DashboardDatasources sync.Map
+
+	// DashboardAlertRules caches the alert rules of each organization, listed to find the rules linked to dashboards.
+	// Listing them on every dashboard read would cost a full listing of the rules per dashboard.
+	DashboardAlertRules sync.Map
+
+	// PlannedContactPoints records the contact points planned in this run, keyed by org ID and name.
+	// They are created before the resources referencing them, so plan-time checks of these references accept them.
+	PlannedContactPoints sync.Map
+
 	alertingMutex sync.Mutex
 }
 
@@ -0,0 +1,31 @@
+package common
+
+import "net/http"
+
+// HeadersRoundTripper sets the given headers on every request sent through next, e.g. the `http_headers` of the provider.
+// Headers already set on a request, by the client or for a specific resource, take precedence.
+func HeadersRoundTripper(next http.RoundTripper, headers map[string]string) http.RoundTripper {
+	if len(headers) == 0 {
+		return next
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &headersRoundTripper{next: next, headers: headers}
+}
+
+type headersRoundTripper struct {
+	next    http.RoundTripper
+	headers map[string]string
+}
+
+func (t *headersRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrippers must not modify the request they're given
+	req = req.Clone(req.Context())
+	for k, v := range t.headers {
+		if req.Header.Get(k) == "" {
+			req.Header.Set(k, v)
+		}
+	}
+	return t.next.RoundTrip(req)
+}
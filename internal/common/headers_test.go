@@ -0,0 +1,54 @@
+package common_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+)
+
+func TestHeadersRoundTripper(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: common.HeadersRoundTripper(http.DefaultTransport, map[string]string{
+		"X-Scope-OrgID":              "tenant-1",
+		"Grafana-Terraform-Provider": "true",
+	})}
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Headers set for a specific request override the provider's
+	req.Header.Set("X-Scope-OrgID", "tenant-2")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if got := received.Get("X-Scope-OrgID"); got != "tenant-2" {
+		t.Errorf("expected the request's X-Scope-OrgID header to be kept, got %q", got)
+	}
+	if got := received.Get("Grafana-Terraform-Provider"); got != "true" {
+		t.Errorf("expected the Grafana-Terraform-Provider header to be set, got %q", got)
+	}
+	if got := req.Header.Get("Grafana-Terraform-Provider"); got != "" {
+		t.Errorf("expected the original request not to be modified, got header %q", got)
+	}
+
+	resp, err = client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if got := received.Get("X-Scope-OrgID"); got != "tenant-1" {
+		t.Errorf("expected the provider's X-Scope-OrgID header to be set, got %q", got)
+	}
+}
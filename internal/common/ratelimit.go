@@ -0,0 +1,97 @@
+package common
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"golang.org/x/time/rate"
+)
+
+// NewRateLimiter returns a limiter allowing the given number of requests per second, shared by all the clients of the provider.
+// It returns nil (no limit) if requestsPerSecond isn't positive.
+func NewRateLimiter(requestsPerSecond float64) *rate.Limiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(requestsPerSecond), 1)
+}
+
+// RateLimitRoundTripper makes every request sent through next wait for the limiter. A nil limiter doesn't limit requests.
+func RateLimitRoundTripper(next http.RoundTripper, limiter *rate.Limiter) http.RoundTripper {
+	if limiter == nil {
+		return next
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &rateLimitedRoundTripper{next: next, limiter: limiter}
+}
+
+type rateLimitedRoundTripper struct {
+	next    http.RoundTripper
+	limiter *rate.Limiter
+}
+
+func (t *rateLimitedRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(req)
+}
+
+// RateLimitClientTransport makes every operation submitted through next wait for the limiter. A nil limiter doesn't limit operations.
+func RateLimitClientTransport(next runtime.ClientTransport, limiter *rate.Limiter) runtime.ClientTransport {
+	if limiter == nil {
+		return next
+	}
+	if limited, ok := next.(*rateLimitedClientTransport); ok {
+		next = limited.next
+	}
+	return &rateLimitedClientTransport{next: next, limiter: limiter}
+}
+
+type rateLimitedClientTransport struct {
+	next    runtime.ClientTransport
+	limiter *rate.Limiter
+}
+
+func (t *rateLimitedClientTransport) Submit(op *runtime.ClientOperation) (interface{}, error) {
+	ctx := op.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := t.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return t.next.Submit(op)
+}
+
+// CloneGrafanaAPI clones a Grafana API client, keeping its rate limit.
+func CloneGrafanaAPI(client *goapi.GrafanaHTTPAPI) *goapi.GrafanaHTTPAPI {
+	return cloneGrafanaAPI(client, nil)
+}
+
+// GrafanaAPIWithOrgID returns a copy of a Grafana API client, scoped to the given org and keeping its rate limit.
+func GrafanaAPIWithOrgID(client *goapi.GrafanaHTTPAPI, orgID int64) *goapi.GrafanaHTTPAPI {
+	return cloneGrafanaAPI(client, func(c *goapi.GrafanaHTTPAPI) *goapi.GrafanaHTTPAPI {
+		return c.WithOrgID(orgID)
+	})
+}
+
+// The OpenAPI client rebuilds its transport when cloned or scoped to an org, so the rate limit has to be applied again.
+func cloneGrafanaAPI(client *goapi.GrafanaHTTPAPI, modify func(*goapi.GrafanaHTTPAPI) *goapi.GrafanaHTTPAPI) *goapi.GrafanaHTTPAPI {
+	limited, ok := client.Transport.(*rateLimitedClientTransport)
+	client = client.Clone()
+	if ok {
+		client.SetTransport(limited.next)
+	}
+	if modify != nil {
+		client = modify(client)
+	}
+	if ok {
+		client.SetTransport(RateLimitClientTransport(client.Transport, limited.limiter))
+	}
+	return client
+}
@@ -0,0 +1,78 @@
+package common_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+)
+
+func TestRateLimitRoundTripper(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	for _, tc := range []struct {
+		name       string
+		limit      float64
+		minElapsed time.Duration
+		maxElapsed time.Duration
+	}{
+		{name: "unlimited", limit: 0, maxElapsed: 200 * time.Millisecond},
+		// The first request is sent right away, the 4 others wait 100ms each
+		{name: "10 per second", limit: 10, minElapsed: 400 * time.Millisecond, maxElapsed: 2 * time.Second},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &http.Client{Transport: common.RateLimitRoundTripper(http.DefaultTransport, common.NewRateLimiter(tc.limit))}
+
+			start := time.Now()
+			for i := 0; i < 5; i++ {
+				resp, err := client.Get(server.URL)
+				if err != nil {
+					t.Fatal(err)
+				}
+				resp.Body.Close()
+			}
+			elapsed := time.Since(start)
+
+			if elapsed < tc.minElapsed || elapsed > tc.maxElapsed {
+				t.Errorf("expected 5 requests to take between %s and %s, took %s", tc.minElapsed, tc.maxElapsed, elapsed)
+			}
+		})
+	}
+}
+
+type countingClientTransport struct {
+	calls int
+}
+
+func (t *countingClientTransport) Submit(*runtime.ClientOperation) (interface{}, error) {
+	t.calls++
+	return nil, nil
+}
+
+func TestRateLimitClientTransport(t *testing.T) {
+	next := &countingClientTransport{}
+	limiter := common.NewRateLimiter(10)
+	// Wrapping twice with the same limiter must not wait twice for each operation
+	transport := common.RateLimitClientTransport(common.RateLimitClientTransport(next, limiter), limiter)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if _, err := transport.Submit(&runtime.ClientOperation{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	elapsed := time.Since(start)
+
+	if next.calls != 5 {
+		t.Errorf("expected 5 submitted operations, got %d", next.calls)
+	}
+	if elapsed < 400*time.Millisecond || elapsed > 2*time.Second {
+		t.Errorf("expected 5 operations to take between 400ms and 2s, took %s", elapsed)
+	}
+}
@@ -0,0 +1,129 @@
+package common
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+const (
+	defaultRetryWaitMin = 1 * time.Second
+	defaultRetryWaitMax = 30 * time.Second
+)
+
+// retriedStatusCodes are the status codes of transient errors, returned e.g. by load balancers when Grafana is overloaded.
+var retriedStatusCodes = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// idempotentMethods are the methods of the requests that can be retried without side effects.
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
+
+// NewRetryClient returns an HTTP client that retries idempotent requests failing with transient errors, up to `retries` times.
+// If wait is set, it's the minimum time to wait between attempts, otherwise it starts at one second. The wait doubles with each attempt.
+// If statusCodes are set, they replace the retried status codes. `x` is a digit wildcard, e.g. `5xx`.
+func NewRetryClient(retries int, wait time.Duration, statusCodes ...string) *retryablehttp.Client {
+	client := retryablehttp.NewClient()
+	client.RetryMax = retries
+	client.RetryWaitMin = defaultRetryWaitMin
+	client.RetryWaitMax = defaultRetryWaitMax
+	if wait > 0 {
+		client.RetryWaitMin = wait
+		client.RetryWaitMax = max(wait, defaultRetryWaitMax)
+	}
+	client.CheckRetry = RetryPolicy
+	if len(statusCodes) > 0 {
+		client.CheckRetry = StatusCodesRetryPolicy(statusCodes)
+	}
+	client.Backoff = RetryBackoff
+	client.Logger = nil
+	return client
+}
+
+// RetryPolicy retries idempotent requests that failed with a connection error or a transient status code (429, 502, 503, 504).
+func RetryPolicy(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	return retryPolicy(ctx, resp, err, func(code int) bool { return retriedStatusCodes[code] })
+}
+
+// StatusCodesRetryPolicy is RetryPolicy, retrying the given status codes instead. `x` is a digit wildcard, e.g. `5xx`.
+func StatusCodesRetryPolicy(statusCodes []string) retryablehttp.CheckRetry {
+	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		return retryPolicy(ctx, resp, err, func(code int) bool {
+			return slices.ContainsFunc(statusCodes, func(pattern string) bool { return matchStatusCode(pattern, code) })
+		})
+	}
+}
+
+func retryPolicy(ctx context.Context, resp *http.Response, err error, retried func(code int) bool) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+	if resp != nil && resp.Request != nil && !idempotentMethods[resp.Request.Method] {
+		return false, nil
+	}
+	if err != nil {
+		// Connection errors don't have a response, let retryablehttp decide whether they're transient
+		return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+	}
+	return retried(resp.StatusCode), nil
+}
+
+// matchStatusCode checks whether code matches pattern, a status code where `x` matches any digit.
+func matchStatusCode(pattern string, code int) bool {
+	codeStr := strconv.Itoa(code)
+	if len(pattern) != len(codeStr) {
+		return false
+	}
+	for i := range pattern {
+		if pattern[i] != 'x' && pattern[i] != codeStr[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// RetryBackoff waits exponentially longer between attempts, with jitter so that parallel requests don't all retry at once.
+// The `Retry-After` header of 429 responses is honored, up to the maximum wait.
+func RetryBackoff(minWait, maxWait time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		if wait, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return min(wait, maxWait)
+		}
+	}
+
+	wait := float64(minWait) * math.Pow(2, float64(attemptNum))
+	if wait > float64(maxWait) {
+		wait = float64(maxWait)
+	}
+	// Wait between half and all of the computed time
+	return time.Duration(wait/2 + rand.Float64()*wait/2) //nolint:gosec
+}
+
+// retryAfter parses a `Retry-After` header, either a number of seconds or an HTTP date.
+func retryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
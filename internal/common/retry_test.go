@@ -0,0 +1,100 @@
+package common_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+)
+
+func TestRetryClient(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		method        string
+		retries       int
+		expectedCode  int
+		expectedErr   bool
+		expectedCalls int
+	}{
+		{name: "transient errors are retried", method: http.MethodGet, retries: 3, expectedCode: http.StatusOK, expectedCalls: 3},
+		{name: "retries are capped", method: http.MethodDelete, retries: 1, expectedErr: true, expectedCalls: 2},
+		{name: "non-idempotent requests aren't retried", method: http.MethodPost, retries: 3, expectedCode: http.StatusServiceUnavailable, expectedCalls: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if calls <= 2 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			client := common.NewRetryClient(tc.retries, time.Millisecond).StandardClient()
+			req, err := http.NewRequest(tc.method, server.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := client.Do(req)
+			if calls != tc.expectedCalls {
+				t.Errorf("expected %d calls, got %d", tc.expectedCalls, calls)
+			}
+			if tc.expectedErr {
+				if err == nil {
+					t.Fatal("expected an error once the retries are exhausted")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedCode {
+				t.Errorf("expected status %d, got %d", tc.expectedCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestStatusCodesRetryPolicy(t *testing.T) {
+	policy := common.StatusCodesRetryPolicy([]string{"5xx", "429"})
+	for code, expected := range map[int]bool{
+		http.StatusInternalServerError: true,
+		http.StatusServiceUnavailable:  true,
+		http.StatusTooManyRequests:     true,
+		http.StatusNotFound:            false,
+		http.StatusOK:                  false,
+	} {
+		resp := &http.Response{StatusCode: code, Request: &http.Request{Method: http.MethodGet}}
+		retry, err := policy(context.Background(), resp, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if retry != expected {
+			t.Errorf("status %d: expected retry to be %t, got %t", code, expected, retry)
+		}
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"5"}}}
+	if wait := common.RetryBackoff(time.Second, time.Minute, 0, resp); wait != 5*time.Second {
+		t.Errorf("expected the Retry-After header to be honored, got %s", wait)
+	}
+	if wait := common.RetryBackoff(time.Second, 2*time.Second, 0, resp); wait != 2*time.Second {
+		t.Errorf("expected the Retry-After header to be capped, got %s", wait)
+	}
+
+	for attempt := 0; attempt < 5; attempt++ {
+		expected := time.Second << attempt
+		if wait := common.RetryBackoff(time.Second, time.Minute, attempt, nil); wait < expected/2 || wait > expected {
+			t.Errorf("attempt %d: expected a wait between %s and %s, got %s", attempt, expected/2, expected, wait)
+		}
+	}
+}
@@ -144,6 +144,77 @@ func TestResourceAccessPolicyToken_NoExpiration(t *testing.T) {
 	})
 }
 
+// A token scoped to the needs of a data source authenticates it
+func TestResourceAccessPolicyToken_DataSource(t *testing.T) {
+	testutils.CheckCloudAPITestsEnabled(t)
+
+	var stack gcom.FormattedApiInstance
+	var policy gcom.AuthAccessPolicy
+	var policyToken gcom.AuthToken
+	prefix := "tfdstoken"
+	slug := GetRandomStackName(prefix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccDeleteExistingStacks(t, prefix)
+		},
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccStackCheckDestroy(&stack),
+			testAccCloudAccessPolicyCheckDestroy("eu", &policy),
+			testAccCloudAccessPolicyTokenCheckDestroy("eu", &policyToken),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGrafanaServiceAccountFromCloud(slug, slug, false, "Admin") + fmt.Sprintf(`
+				provider "grafana" {
+					alias = "stack"
+					auth  = grafana_cloud_stack_service_account_token.management_token.key
+					url   = grafana_cloud_stack.test.url
+				}
+
+				resource "grafana_cloud_access_policy" "metrics_read" {
+					region = grafana_cloud_stack.test.region_slug
+					name   = "%[1]s-metrics-read"
+					scopes = ["metrics:read"]
+
+					realm {
+						type       = "stack"
+						identifier = grafana_cloud_stack.test.id
+					}
+				}
+
+				resource "grafana_cloud_access_policy_token" "metrics_read" {
+					region           = grafana_cloud_stack.test.region_slug
+					access_policy_id = grafana_cloud_access_policy.metrics_read.policy_id
+					name             = "%[1]s-metrics-read"
+				}
+
+				resource "grafana_data_source" "metrics" {
+					provider            = grafana.stack
+					type                = "prometheus"
+					name                = "metrics-read-only"
+					url                 = grafana_cloud_stack.test.prometheus_url
+					basic_auth_enabled  = true
+					basic_auth_username = grafana_cloud_stack.test.prometheus_user_id
+
+					secure_json_data_encoded = jsonencode({
+						basicAuthPassword = grafana_cloud_access_policy_token.metrics_read.token
+					})
+				}`, slug),
+				Check: resource.ComposeTestCheckFunc(
+					testAccStackCheckExists("grafana_cloud_stack.test", &stack),
+					testAccCloudAccessPolicyCheckExists("grafana_cloud_access_policy.metrics_read", &policy),
+					testAccCloudAccessPolicyTokenCheckExists("grafana_cloud_access_policy_token.metrics_read", &policyToken),
+					resource.TestCheckResourceAttr("grafana_cloud_access_policy.metrics_read", "realm.0.type", "stack"),
+					resource.TestCheckResourceAttrPair("grafana_data_source.metrics", "url", "grafana_cloud_stack.test", "prometheus_url"),
+					resource.TestCheckResourceAttrPair("grafana_data_source.metrics", "basic_auth_username", "grafana_cloud_stack.test", "prometheus_user_id"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCloudAccessPolicyCheckExists(rn string, a *gcom.AuthAccessPolicy) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
@@ -154,16 +154,18 @@ Required access policy scopes:
 			"alertmanager_status":  common.ComputedStringWithDescription("Status of the Alertmanager instance configured for this stack."),
 
 			// Logs (Loki)
-			"logs_user_id": common.ComputedInt(),
-			"logs_name":    common.ComputedString(),
-			"logs_url":     common.ComputedString(),
-			"logs_status":  common.ComputedString(),
+			"logs_user_id":  common.ComputedIntWithDescription("User ID of the Logs instance configured for this stack. It's the tenant ID (and basic auth username) used to push and query logs."),
+			"logs_name":     common.ComputedString(),
+			"logs_url":      common.ComputedString(),
+			"logs_push_url": common.ComputedStringWithDescription("Use this URL to push logs to Grafana Cloud, e.g. from Grafana Agent or Promtail. The username is `logs_user_id`."),
+			"logs_status":   common.ComputedString(),
 
 			// Traces (Tempo)
-			"traces_user_id": common.ComputedInt(),
-			"traces_name":    common.ComputedString(),
-			"traces_url":     common.ComputedStringWithDescription("Base URL of the Traces instance configured for this stack. To use this in the Tempo data source in Grafana, append `/tempo` to the URL."),
-			"traces_status":  common.ComputedString(),
+			"traces_user_id":       common.ComputedIntWithDescription("User ID of the Traces instance configured for this stack. It's the tenant ID (and basic auth username) used to push and query traces."),
+			"traces_name":          common.ComputedString(),
+			"traces_url":           common.ComputedStringWithDescription("Base URL of the Traces instance configured for this stack. To use this in the Tempo data source in Grafana, append `/tempo` to the URL."),
+			"traces_push_endpoint": common.ComputedStringWithDescription("Use this `host:port` endpoint to push traces to Grafana Cloud with OTLP over gRPC, e.g. from Grafana Agent. The username is `traces_user_id`."),
+			"traces_status":        common.ComputedString(),
 
 			// Profiles (Pyroscope)
 			"profiles_user_id": common.ComputedInt(),
@@ -178,8 +180,11 @@ Required access policy scopes:
 			"graphite_status":  common.ComputedString(),
 
 			// Connections
-			"influx_url": common.ComputedStringWithDescription("Base URL of the InfluxDB instance configured for this stack. The username is the same as the metrics' (`prometheus_user_id` attribute of this resource). See https://grafana.com/docs/grafana-cloud/send-data/metrics/metrics-influxdb/push-from-telegraf/ for docs on how to use this."),
-			"otlp_url":   common.ComputedStringWithDescription("Base URL of the OTLP instance configured for this stack. The username is the stack's ID (`id` attribute of this resource). See https://grafana.com/docs/grafana-cloud/send-data/otlp/send-data-otlp/ for docs on how to use this."),
+			"influx_url":       common.ComputedStringWithDescription("Base URL of the InfluxDB instance configured for this stack. The username is the same as the metrics' (`prometheus_user_id` attribute of this resource). See https://grafana.com/docs/grafana-cloud/send-data/metrics/metrics-influxdb/push-from-telegraf/ for docs on how to use this."),
+			"otlp_url":         common.ComputedStringWithDescription("Base URL of the OTLP instance configured for this stack. The username is the stack's ID (`id` attribute of this resource). See https://grafana.com/docs/grafana-cloud/send-data/otlp/send-data-otlp/ for docs on how to use this."),
+			"otlp_metrics_url": common.ComputedStringWithDescription("Use this URL to push metrics with OTLP over HTTP, e.g. as the `OTEL_EXPORTER_OTLP_METRICS_ENDPOINT` of OpenTelemetry SDKs. It's `otlp_url` followed by `/v1/metrics`, the username is the stack's ID."),
+			"otlp_logs_url":    common.ComputedStringWithDescription("Use this URL to push logs with OTLP over HTTP, e.g. as the `OTEL_EXPORTER_OTLP_LOGS_ENDPOINT` of OpenTelemetry SDKs. It's `otlp_url` followed by `/v1/logs`, the username is the stack's ID."),
+			"otlp_traces_url":  common.ComputedStringWithDescription("Use this URL to push traces with OTLP over HTTP, e.g. as the `OTEL_EXPORTER_OTLP_TRACES_ENDPOINT` of OpenTelemetry SDKs. It's `otlp_url` followed by `/v1/traces`, the username is the stack's ID."),
 		},
 		CustomizeDiff: customdiff.All(
 			customdiff.ComputedIf("url", func(_ context.Context, diff *schema.ResourceDiff, meta interface{}) bool {
@@ -395,6 +400,11 @@ func flattenStack(d *schema.ResourceData, stack *gcom.FormattedApiInstance, conn
 	d.Set("logs_user_id", stack.HlInstanceId)
 	d.Set("logs_url", stack.HlInstanceUrl)
 	d.Set("logs_name", stack.HlInstanceName)
+	logsPushURL, err := appendPath(stack.HlInstanceUrl, "/loki/api/v1/push")
+	if err != nil {
+		return err
+	}
+	d.Set("logs_push_url", logsPushURL)
 	d.Set("logs_status", stack.HlInstanceStatus)
 
 	d.Set("alertmanager_user_id", stack.AmInstanceId)
@@ -405,6 +415,11 @@ func flattenStack(d *schema.ResourceData, stack *gcom.FormattedApiInstance, conn
 	d.Set("traces_user_id", stack.HtInstanceId)
 	d.Set("traces_name", stack.HtInstanceName)
 	d.Set("traces_url", stack.HtInstanceUrl)
+	tracesPushEndpoint, err := grpcEndpoint(stack.HtInstanceUrl)
+	if err != nil {
+		return err
+	}
+	d.Set("traces_push_endpoint", tracesPushEndpoint)
 	d.Set("traces_status", stack.HtInstanceStatus)
 
 	d.Set("profiles_user_id", stack.HpInstanceId)
@@ -419,6 +434,13 @@ func flattenStack(d *schema.ResourceData, stack *gcom.FormattedApiInstance, conn
 
 	if otlpURL := connections.OtlpHttpUrl; otlpURL.IsSet() {
 		d.Set("otlp_url", otlpURL.Get())
+		for _, signal := range []string{"metrics", "logs", "traces"} {
+			signalURL, err := otlpSignalURL(connections.GetOtlpHttpUrl(), signal)
+			if err != nil {
+				return err
+			}
+			d.Set("otlp_"+signal+"_url", signalURL)
+		}
 	}
 
 	if influxURL := connections.InfluxUrl; influxURL.IsSet() {
@@ -428,6 +450,30 @@ func flattenStack(d *schema.ResourceData, stack *gcom.FormattedApiInstance, conn
 	return nil
 }
 
+// otlpSignalURL returns the URL that OTLP exporters push a signal (metrics, logs or traces) to over HTTP, below the base OTLP URL.
+func otlpSignalURL(baseURL, signal string) (string, error) {
+	if baseURL == "" {
+		return "", nil
+	}
+	return url.JoinPath(baseURL, "v1", signal)
+}
+
+// grpcEndpoint returns the host:port gRPC endpoint of a base URL. Grafana Cloud serves gRPC on the same host, on the HTTPS port.
+func grpcEndpoint(baseURL string) (string, error) {
+	if baseURL == "" {
+		return "", nil
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+	port := u.Port()
+	if port == "" {
+		port = "443"
+	}
+	return u.Hostname() + ":" + port, nil
+}
+
 // Append path to baseurl
 func appendPath(baseURL, path string) (string, error) {
 	bu, err := url.Parse(baseURL)
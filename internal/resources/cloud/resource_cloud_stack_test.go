@@ -44,13 +44,18 @@ func TestResourceStack_Basic(t *testing.T) {
 		resource.TestCheckResourceAttrSet("grafana_cloud_stack.test", "prometheus_user_id"),
 		resource.TestCheckResourceAttrSet("grafana_cloud_stack.test", "alertmanager_user_id"),
 		resource.TestCheckResourceAttrSet("grafana_cloud_stack.test", "logs_user_id"),
+		resource.TestMatchResourceAttr("grafana_cloud_stack.test", "logs_push_url", regexp.MustCompile(`^https://logs-prod-[a-z0-9-]+\.grafana\.net/loki/api/v1/push$`)),
 		resource.TestCheckResourceAttrSet("grafana_cloud_stack.test", "traces_user_id"),
+		resource.TestMatchResourceAttr("grafana_cloud_stack.test", "traces_push_endpoint", regexp.MustCompile(`^tempo-[a-z0-9-]+\.grafana\.net:443$`)),
 		resource.TestCheckResourceAttrSet("grafana_cloud_stack.test", "graphite_user_id"),
 		resource.TestCheckResourceAttrSet("grafana_cloud_stack.test", "profiles_user_id"),
 		resource.TestCheckResourceAttrSet("grafana_cloud_stack.test", "profiles_name"),
 		resource.TestCheckResourceAttrSet("grafana_cloud_stack.test", "profiles_url"),
 		resource.TestCheckResourceAttrSet("grafana_cloud_stack.test", "profiles_status"),
 		resource.TestCheckResourceAttrSet("grafana_cloud_stack.test", "otlp_url"),
+		resource.TestMatchResourceAttr("grafana_cloud_stack.test", "otlp_metrics_url", regexp.MustCompile(`/otlp/v1/metrics$`)),
+		resource.TestMatchResourceAttr("grafana_cloud_stack.test", "otlp_logs_url", regexp.MustCompile(`/otlp/v1/logs$`)),
+		resource.TestMatchResourceAttr("grafana_cloud_stack.test", "otlp_traces_url", regexp.MustCompile(`/otlp/v1/traces$`)),
 		resource.TestCheckResourceAttrSet("grafana_cloud_stack.test", "influx_url"),
 	)
 
@@ -239,6 +239,30 @@ var (
 			return payloadOrError(resp, err)
 		},
 	)
+	teamPermissionsCheckExists = newCheckExistsHelper(
+		teamCheckExists.getIDFunc, // We use the team as the reference
+		func(client *goapi.GrafanaHTTPAPI, id string) (*models.TeamDTO, error) {
+			team, err := teamCheckExists.getResourceFunc(client, id)
+			if err != nil {
+				return nil, err
+			}
+			resp, err := client.AccessControl.GetResourcePermissions(id, "teams")
+			if err != nil {
+				return nil, err
+			}
+			// Only managed permissions should be checked, team members are `Member` permissions
+			var managedPermissions []*models.ResourcePermissionDTO
+			for _, p := range resp.Payload {
+				if p.IsManaged && p.Permission != "Member" {
+					managedPermissions = append(managedPermissions, p)
+				}
+			}
+			if len(managedPermissions) == 0 {
+				return nil, &runtime.APIError{Code: 404, Response: "no managed permissions found"}
+			}
+			return team, nil
+		},
+	)
 	userCheckExists = newCheckExistsHelper(
 		func(u *models.UserProfileDTO) string { return strconv.FormatInt(u.ID, 10) },
 		func(client *goapi.GrafanaHTTPAPI, id string) (*models.UserProfileDTO, error) {
@@ -30,7 +30,7 @@ func (ld *ListerData) OrgIDs(client *goapi.GrafanaHTTPAPI) ([]int64, error) {
 
 	var err error
 	ld.orgsInit.Do(func() {
-		client = client.Clone().WithOrgID(0)
+		client = common.GrafanaAPIWithOrgID(client, 0)
 
 		var page int64 = 0
 		for {
@@ -48,7 +48,7 @@ func (r *basePluginFrameworkResource) clientFromExistingOrgResource(idFormat *co
 		return nil, 0, nil, fmt.Errorf("client not configured")
 	}
 
-	client := r.client.Clone()
+	client := common.CloneGrafanaAPI(r.client)
 	split, err := idFormat.Split(id)
 	if err != nil {
 		return nil, 0, nil, err
@@ -59,7 +59,7 @@ func (r *basePluginFrameworkResource) clientFromExistingOrgResource(idFormat *co
 	} else {
 		orgID = split[0].(int64)
 		split = split[1:]
-		client = client.WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 	}
 	return client, orgID, split, nil
 }
@@ -71,12 +71,12 @@ func (r *basePluginFrameworkResource) clientFromNewOrgResource(orgIDStr string)
 		return nil, 0, fmt.Errorf("client not configured")
 	}
 
-	client := r.client.Clone()
+	client := common.CloneGrafanaAPI(r.client)
 	orgID, _ := strconv.ParseInt(orgIDStr, 10, 64)
 	if orgID == 0 {
 		orgID = client.OrgID()
 	} else if orgID > 0 {
-		client = client.WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 	}
 	return client, orgID, nil
 }
@@ -0,0 +1,137 @@
+package grafana
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+)
+
+// getRawJSON calls a Grafana API endpoint that isn't part of the OpenAPI client, and decodes its JSON response into result.
+// Responses with a status code other than 200 (or one of acceptedCodes) are returned as API errors.
+func getRawJSON(ctx context.Context, client *goapi.GrafanaHTTPAPI, opID, path string, query map[string]string, result interface{}, acceptedCodes ...int) error {
+	return doRawJSON(ctx, client, opID, http.MethodGet, path, query, nil, result, acceptedCodes...)
+}
+
+// doRawJSON is like getRawJSON, for any method. If body isn't nil, it's sent as the JSON request body.
+// If result is nil, the response body is ignored.
+func doRawJSON(ctx context.Context, client *goapi.GrafanaHTTPAPI, opID, method, path string, query map[string]string, body, result interface{}, acceptedCodes ...int) error {
+	return doRawJSONWithHeaders(ctx, client, opID, method, path, nil, query, body, result, acceptedCodes...)
+}
+
+// doRawJSONWithHeaders is like doRawJSON, and also sets the given request headers.
+func doRawJSONWithHeaders(ctx context.Context, client *goapi.GrafanaHTTPAPI, opID, method, path string, headers, query map[string]string, body, result interface{}, acceptedCodes ...int) error {
+	_, err := client.Transport.Submit(&runtime.ClientOperation{
+		ID:                 opID,
+		Method:             method,
+		PathPattern:        path,
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http", "https"},
+		Params: runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, _ strfmt.Registry) error {
+			for k, v := range headers {
+				if err := r.SetHeaderParam(k, v); err != nil {
+					return err
+				}
+			}
+			for k, v := range query {
+				if err := r.SetQueryParam(k, v); err != nil {
+					return err
+				}
+			}
+			if body != nil {
+				return r.SetBodyParam(body)
+			}
+			return nil
+		}),
+		Reader: runtime.ClientResponseReaderFunc(func(response runtime.ClientResponse, _ runtime.Consumer) (interface{}, error) {
+			if response.Code() != http.StatusOK && !slices.Contains(acceptedCodes, response.Code()) {
+				return nil, runtime.NewAPIError(opID, rawErrorPayload(response), response.Code())
+			}
+			if result == nil {
+				return nil, nil
+			}
+			if err := json.NewDecoder(response.Body()).Decode(result); err != nil {
+				return nil, fmt.Errorf("error decoding %s response: %w", opID, err)
+			}
+			return result, nil
+		}),
+		Context: ctx,
+	})
+	return err
+}
+
+// rawErrorPayload returns the body of an error response, which usually has Grafana's error message, decoded if it's JSON.
+// It falls back to the status message if the response has no body.
+func rawErrorPayload(response runtime.ClientResponse) interface{} {
+	body, err := io.ReadAll(response.Body())
+	if err != nil || len(bytes.TrimSpace(body)) == 0 {
+		return response.Message()
+	}
+	var payload interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return string(bytes.TrimSpace(body))
+	}
+	return payload
+}
+
+// withRequestBodyFields returns an option of the OpenAPI client that passes the JSON request body of an operation through modify,
+// to send fields that are missing from the client's models.
+func withRequestBodyFields(modify func(body map[string]interface{})) func(*runtime.ClientOperation) {
+	return func(op *runtime.ClientOperation) {
+		params := op.Params
+		op.Params = runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, reg strfmt.Registry) error {
+			if err := params.WriteToRequest(r, reg); err != nil {
+				return err
+			}
+			encoded, err := json.Marshal(r.GetBodyParam())
+			if err != nil {
+				return err
+			}
+			body := map[string]interface{}{}
+			if err := json.Unmarshal(encoded, &body); err != nil {
+				return err
+			}
+			modify(body)
+			return r.SetBodyParam(body)
+		})
+	}
+}
+
+// withResponseBody returns an option of the OpenAPI client that also decodes the JSON body of successful responses into result,
+// to read fields that are missing from the client's models.
+func withResponseBody(result interface{}) func(*runtime.ClientOperation) {
+	return func(op *runtime.ClientOperation) {
+		reader := op.Reader
+		op.Reader = runtime.ClientResponseReaderFunc(func(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+			if response.Code() < 200 || response.Code() >= 300 {
+				return reader.ReadResponse(response, consumer)
+			}
+			body, err := io.ReadAll(response.Body())
+			if err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(body, result); err != nil {
+				return nil, fmt.Errorf("error decoding %s response: %w", op.ID, err)
+			}
+			return reader.ReadResponse(bufferedResponse{ClientResponse: response, body: body}, consumer)
+		})
+	}
+}
+
+// bufferedResponse is a response whose body was already read.
+type bufferedResponse struct {
+	runtime.ClientResponse
+	body []byte
+}
+
+func (r bufferedResponse) Body() io.ReadCloser {
+	return io.NopCloser(bytes.NewReader(r.body))
+}
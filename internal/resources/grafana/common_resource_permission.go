@@ -29,6 +29,7 @@ const (
 	datasourcesPermissionsType     = "datasources"
 	foldersPermissionsType         = "folders"
 	serviceAccountsPermissionsType = "serviceaccounts"
+	teamsPermissionsType           = "teams"
 )
 
 type resourcePermissionItemBaseModel struct {
@@ -128,6 +129,11 @@ func (r *resourcePermissionBase) readItem(id string, checkExistsFunc func(client
 	}
 
 	for _, permission := range permissionsResp.Payload {
+		// Permissions inherited from parent folders or granted through custom and fixed roles can't be managed by this resource.
+		// Reading them would show the permission of another grantee or resource, e.g. the one of a nested folder's parent.
+		if !permission.IsManaged || permission.IsInherited {
+			continue
+		}
 		data := &resourcePermissionItemBaseModel{
 			ResourceID: types.StringValue(itemID),
 			ID:         types.StringValue(id),
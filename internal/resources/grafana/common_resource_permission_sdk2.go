@@ -4,7 +4,9 @@ package grafana
 
 import (
 	"context"
+	"fmt"
 	"strconv"
+	"strings"
 
 	goapi "github.com/grafana/grafana-openapi-client-go/client"
 	"github.com/grafana/grafana-openapi-client-go/client/access_control"
@@ -18,6 +20,11 @@ import (
 type resourcePermissionsHelper struct {
 	resourceType  string
 	roleAttribute string // Not all resources have the same name for this attribute
+	// Whether permissions can be granted with the `service_account_id` attribute, rather than with the user ID of the service account.
+	serviceAccountAttribute bool
+	// A permission level that isn't managed by the resource: it's neither read nor removed, and removed permissions are downgraded to it.
+	// Ex: team memberships are `Member` permissions of the team, they're managed by `grafana_team` and `grafana_team_member`.
+	unmanagedPermission string
 
 	// Given the resource data, check the resource exists and return the correct ID for permissions.
 	// Ex: We support ID and UID for dashboards but the permissions are managed by UID.
@@ -53,6 +60,23 @@ func (h *resourcePermissionsHelper) addCommonSchemaAttributes(s map[string]*sche
 			Description:  "Permission to associate with item. Options: `Query`, `Edit` or `Admin` (`Admin` can only be used with Grafana v10.3.0+).",
 		}
 	}
+	if h.resourceType == teamsPermissionsType {
+		permissionSchema["permission"] = &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice([]string{"Admin"}, false),
+			Description:  "Permission to associate with item. Only `Admin` is supported, team members are managed with `grafana_team` or `grafana_team_member`.",
+		}
+	}
+	if h.serviceAccountAttribute {
+		permissionSchema["service_account_id"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "0",
+			Description: "ID of the service account to manage permissions for.",
+		}
+		permissionSchema["user_id"].Description = "ID of the user to manage permissions for."
+	}
 	if h.roleAttribute != "" {
 		permissionSchema[h.roleAttribute] = &schema.Schema{
 			Type:         schema.TypeString,
@@ -80,7 +104,11 @@ func (h *resourcePermissionsHelper) addCommonSchemaAttributes(s map[string]*sche
 				if h.roleAttribute != "" {
 					role = m[h.roleAttribute].(string)
 				}
-				return schema.HashString(role + teamID + userID + m["permission"].(string))
+				serviceAccountID := ""
+				if h.serviceAccountAttribute {
+					_, serviceAccountID = SplitOrgResourceID(m["service_account_id"].(string))
+				}
+				return schema.HashString(role + teamID + userID + serviceAccountID + m["permission"].(string))
 			},
 			Elem: &schema.Resource{
 				Schema: permissionSchema,
@@ -122,6 +150,10 @@ func (h *resourcePermissionsHelper) updatePermissions(ctx context.Context, d *sc
 		if userID > 0 {
 			permissionItem.UserID = userID
 		}
+		// Service accounts are granted permissions like users
+		if serviceAccountID := permissionServiceAccountID(permission); serviceAccountID > 0 {
+			permissionItem.UserID = serviceAccountID
+		}
 		permissionItem.Permission = permission["permission"].(string)
 		permissionList = append(permissionList, &permissionItem)
 	}
@@ -149,11 +181,22 @@ func (h *resourcePermissionsHelper) readPermissions(ctx context.Context, d *sche
 		return err
 	}
 
+	// Grafana returns the permissions of service accounts as those of users, tell them apart with the configured service accounts
+	serviceAccountIDs := map[int64]string{}
+	if h.serviceAccountAttribute {
+		for _, permission := range d.Get("permissions").(*schema.Set).List() {
+			permission := permission.(map[string]interface{})
+			if serviceAccountID := permissionServiceAccountID(permission); serviceAccountID > 0 {
+				serviceAccountIDs[serviceAccountID] = permission["service_account_id"].(string)
+			}
+		}
+	}
+
 	resourcePermissions := resp.Payload
 	var permissionItems []interface{}
 	for _, permission := range resourcePermissions {
 		// Only managed permissions can be provisioned through this resource, so we disregard the permissions obtained through custom and fixed roles here
-		if !permission.IsManaged || permission.IsInherited {
+		if !permission.IsManaged || permission.IsInherited || h.isUnmanagedPermission(permission.Permission) {
 			continue
 		}
 		permissionItem := make(map[string]interface{})
@@ -162,6 +205,13 @@ func (h *resourcePermissionsHelper) readPermissions(ctx context.Context, d *sche
 		}
 		permissionItem["team_id"] = strconv.FormatInt(permission.TeamID, 10)
 		permissionItem["user_id"] = strconv.FormatInt(permission.UserID, 10)
+		if h.serviceAccountAttribute {
+			permissionItem["service_account_id"] = "0"
+			if serviceAccountID, ok := serviceAccountIDs[permission.UserID]; ok {
+				permissionItem["service_account_id"] = serviceAccountID
+				permissionItem["user_id"] = "0"
+			}
+		}
 		permissionItem["permission"] = permission.Permission
 
 		permissionItems = append(permissionItems, permissionItem)
@@ -174,6 +224,32 @@ func (h *resourcePermissionsHelper) readPermissions(ctx context.Context, d *sche
 	return nil
 }
 
+// validatePermissions is the CustomizeDiff function of the resources that can grant permissions to different kinds of grantees.
+// Each permission item must be granted to exactly one user, team, service account or role.
+func (h *resourcePermissionsHelper) validatePermissions(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	attributes := []string{"user_id", "team_id"}
+	if h.serviceAccountAttribute {
+		attributes = append(attributes, "service_account_id")
+	}
+	if h.roleAttribute != "" {
+		attributes = append(attributes, h.roleAttribute)
+	}
+
+	for _, permission := range d.Get("permissions").(*schema.Set).List() {
+		permission := permission.(map[string]interface{})
+		grantees := 0
+		for _, attr := range attributes {
+			if v, _ := permission[attr].(string); v != "" && v != "0" {
+				grantees++
+			}
+		}
+		if grantees != 1 {
+			return fmt.Errorf("each permission item must set exactly one of %s, found %d in a %q permission item", strings.Join(attributes, ", "), grantees, permission["permission"])
+		}
+	}
+	return nil
+}
+
 func (h *resourcePermissionsHelper) deletePermissions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// since permissions are tied to the resource, we can't really delete the permissions.
 	// we will simply remove all permissions, leaving a resource that only an admin can access.
@@ -184,6 +260,20 @@ func (h *resourcePermissionsHelper) deletePermissions(ctx context.Context, d *sc
 	return diags
 }
 
+func (h *resourcePermissionsHelper) isUnmanagedPermission(permission string) bool {
+	return h.unmanagedPermission != "" && permission == h.unmanagedPermission
+}
+
+func permissionServiceAccountID(permission map[string]interface{}) int64 {
+	v, ok := permission["service_account_id"].(string)
+	if !ok {
+		return 0
+	}
+	_, idStr := SplitOrgResourceID(v)
+	id, _ := strconv.ParseInt(idStr, 10, 64)
+	return id
+}
+
 func (h *resourcePermissionsHelper) updateResourcePermissions(client *goapi.GrafanaHTTPAPI, uid string, permissions []*models.SetResourcePermissionCommand) error {
 	areEqual := func(a *models.ResourcePermissionDTO, b *models.SetResourcePermissionCommand) bool {
 		return a.Permission == b.Permission && a.TeamID == b.TeamID && a.UserID == b.UserID && a.BuiltInRole == b.BuiltInRole
@@ -198,7 +288,7 @@ func (h *resourcePermissionsHelper) updateResourcePermissions(client *goapi.Graf
 deleteLoop:
 	for _, current := range listResp.Payload {
 		// Only managed and non-inherited permissions can be provisioned through this resource, so we disregard the permissions obtained through custom and fixed roles here
-		if !current.IsManaged || current.IsInherited {
+		if !current.IsManaged || current.IsInherited || h.isUnmanagedPermission(current.Permission) {
 			continue
 		}
 		for _, new := range permissions {
@@ -211,7 +301,7 @@ deleteLoop:
 			TeamID:      current.TeamID,
 			UserID:      current.UserID,
 			BuiltInRole: current.BuiltInRole,
-			Permission:  "",
+			Permission:  h.unmanagedPermission,
 		}
 
 		permissionList = append(permissionList, &permToRemove)
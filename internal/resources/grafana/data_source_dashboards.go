@@ -14,7 +14,8 @@ import (
 func datasourceDashboards() *common.DataSource {
 	schema := &schema.Resource{
 		Description: `
-Datasource for retrieving all dashboards. Specify list of folder IDs to search in for dashboards.
+Datasource for retrieving all dashboards. Specify list of folder UIDs to search in for dashboards, and/or tags that the dashboards must have.
+When both are set, only the dashboards matching both filters are returned.
 
 * [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/)
 * [Folder/Dashboard Search HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/folder_dashboard_search/)
@@ -26,19 +27,19 @@ Datasource for retrieving all dashboards. Specify list of folder IDs to search i
 			"folder_uids": {
 				Type:        schema.TypeList,
 				Optional:    true,
-				Description: "UIDs of Grafana folders containing dashboards. Specify to filter for dashboards by folder (eg. `[\"General\"]` for General folder), or leave blank to get all dashboards in all folders.",
+				Description: "UIDs of Grafana folders containing dashboards. Specify to filter for dashboards by folder (eg. `[\"General\"]` for General folder), or leave blank to get all dashboards in all folders. Dashboards in any of the folders are returned.",
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
 			"limit": {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Default:     5000,
-				Description: "Maximum number of dashboard search results to return.",
+				Description: "Maximum number of dashboard search results to return. The search API of Grafana can't return more than 5000 results.",
 			},
 			"tags": {
 				Type:        schema.TypeList,
 				Optional:    true,
-				Description: "List of string Grafana dashboard tags to search for, eg. `[\"prod\"]`. Used only as search input, i.e., attribute value will remain unchanged.",
+				Description: "List of string Grafana dashboard tags to search for, eg. `[\"prod\"]`. Used only as search input, i.e., attribute value will remain unchanged. Only the dashboards with all the tags are returned.",
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
 			"dashboards": {
@@ -58,6 +59,15 @@ Datasource for retrieving all dashboards. Specify list of folder IDs to search i
 							Type:     schema.TypeString,
 							Computed: true,
 						},
+						"folder_uid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tags": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
 					},
 				},
 			},
@@ -100,6 +110,8 @@ func dataSourceReadDashboards(ctx context.Context, d *schema.ResourceData, meta
 			"title":        result.Title,
 			"uid":          result.UID,
 			"folder_title": result.FolderTitle,
+			"folder_uid":   result.FolderUID,
+			"tags":         result.Tags,
 		}
 	}
 
@@ -1,9 +1,11 @@
 package grafana_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -51,3 +53,96 @@ func TestAccDataSourceDashboardsAllAndByFolderUID(t *testing.T) {
 		},
 	})
 }
+
+func TestAccDataSourceDashboardsFilters(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=10.0.0")
+
+	name := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDashboardsFilters(name),
+				Check: resource.ComposeTestCheckFunc(
+					// Both dashboards with the tag, in both folders
+					resource.TestCheckResourceAttr("data.grafana_dashboards.tag", "dashboards.#", "2"),
+					resource.TestCheckResourceAttr("data.grafana_dashboards.tag", "dashboards.0.uid", name+"-1"),
+					resource.TestCheckResourceAttr("data.grafana_dashboards.tag", "dashboards.0.folder_uid", name+"-a"),
+					resource.TestCheckResourceAttr("data.grafana_dashboards.tag", "dashboards.0.tags.#", "2"),
+					resource.TestCheckResourceAttr("data.grafana_dashboards.tag", "dashboards.1.uid", name+"-3"),
+					resource.TestCheckResourceAttr("data.grafana_dashboards.tag", "dashboards.1.folder_uid", name+"-b"),
+
+					// Only the dashboard with the tag in the folder
+					resource.TestCheckResourceAttr("data.grafana_dashboards.folder_and_tag", "dashboards.#", "1"),
+					resource.TestCheckResourceAttr("data.grafana_dashboards.folder_and_tag", "dashboards.0.uid", name+"-1"),
+					resource.TestCheckResourceAttr("data.grafana_dashboards.folder_and_tag", "dashboards.0.title", name+" 1"),
+					resource.TestCheckResourceAttr("data.grafana_dashboards.folder_and_tag", "dashboards.0.folder_uid", name+"-a"),
+					resource.TestCheckTypeSetElemAttr("data.grafana_dashboards.folder_and_tag", "dashboards.0.tags.*", name+"-audit"),
+					resource.TestCheckTypeSetElemAttr("data.grafana_dashboards.folder_and_tag", "dashboards.0.tags.*", "prod"),
+
+					// Only the dashboard with both tags
+					resource.TestCheckResourceAttr("data.grafana_dashboards.tags", "dashboards.#", "1"),
+					resource.TestCheckResourceAttr("data.grafana_dashboards.tags", "dashboards.0.uid", name+"-1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceDashboardsFilters(name string) string {
+	return fmt.Sprintf(`
+resource "grafana_folder" "a" {
+  uid   = "%[1]s-a"
+  title = "%[1]s a"
+}
+
+resource "grafana_folder" "b" {
+  uid   = "%[1]s-b"
+  title = "%[1]s b"
+}
+
+resource "grafana_dashboard" "one" {
+  folder = grafana_folder.a.uid
+  config_json = jsonencode({
+    uid   = "%[1]s-1"
+    title = "%[1]s 1"
+    tags  = ["%[1]s-audit", "prod"]
+  })
+}
+
+resource "grafana_dashboard" "two" {
+  folder = grafana_folder.a.uid
+  config_json = jsonencode({
+    uid   = "%[1]s-2"
+    title = "%[1]s 2"
+    tags  = ["prod"]
+  })
+}
+
+resource "grafana_dashboard" "three" {
+  folder = grafana_folder.b.uid
+  config_json = jsonencode({
+    uid   = "%[1]s-3"
+    title = "%[1]s 3"
+    tags  = ["%[1]s-audit"]
+  })
+}
+
+data "grafana_dashboards" "tag" {
+  tags       = ["%[1]s-audit"]
+  depends_on = [grafana_dashboard.one, grafana_dashboard.two, grafana_dashboard.three]
+}
+
+data "grafana_dashboards" "folder_and_tag" {
+  folder_uids = [grafana_folder.a.uid]
+  tags        = ["%[1]s-audit"]
+  depends_on  = [grafana_dashboard.one, grafana_dashboard.two, grafana_dashboard.three]
+}
+
+data "grafana_dashboards" "tags" {
+  tags       = ["%[1]s-audit", "prod"]
+  depends_on = [grafana_dashboard.one, grafana_dashboard.two, grafana_dashboard.three]
+}
+`, name)
+}
@@ -27,8 +27,11 @@ func datasourceDatasource() *common.DataSource {
 				Computed:     true,
 				AtLeastOneOf: []string{"name", "uid"},
 			},
+			"json_data":                nil,
 			"secure_json_data_encoded": nil,
 			"http_headers":             nil,
+			"is_default_force":         nil,
+			"tenant_id":                nil,
 		}),
 	}
 	return common.NewLegacySDKDataSource(common.CategoryGrafanaOSS, "grafana_data_source", schema)
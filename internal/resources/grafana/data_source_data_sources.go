@@ -0,0 +1,96 @@
+package grafana
+
+import (
+	"context"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceDatasources() *common.DataSource {
+	schema := &schema.Resource{
+		Description: `
+Lists all data sources, or the data sources of the given type.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/datasources/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/data_source/#get-all-data-sources)
+`,
+		ReadContext: dataSourceReadDatasources,
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDAttribute(),
+			"type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the data sources of this type, e.g. `prometheus`. Leave blank to list all data sources.",
+			},
+			"datasources": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The data sources.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"uid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_default": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+	return common.NewLegacySDKDataSource(common.CategoryGrafanaOSS, "grafana_data_sources", schema)
+}
+
+func dataSourceReadDatasources(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, orgID := OAPIClientFromNewOrgResource(meta, d)
+
+	dsType := d.Get("type").(string)
+	id := "datasources"
+	if dsType != "" {
+		id += "-" + dsType
+	}
+	d.SetId(MakeOrgResourceID(orgID, id))
+
+	// The list endpoint can't be filtered, the type is filtered here
+	resp, err := client.Datasources.GetDataSources()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	dataSources := []map[string]interface{}{}
+	for _, ds := range resp.GetPayload() {
+		if dsType != "" && ds.Type != dsType {
+			continue
+		}
+		dataSources = append(dataSources, map[string]interface{}{
+			"uid":        ds.UID,
+			"name":       ds.Name,
+			"type":       ds.Type,
+			"url":        ds.URL,
+			"is_default": ds.IsDefault,
+		})
+	}
+
+	if err := d.Set("datasources", dataSources); err != nil {
+		return diag.Errorf("error setting datasources attribute: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package grafana
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/grafana/grafana-openapi-client-go/client/datasources"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceDatasourcesHealth() *common.DataSource {
+	schema := &schema.Resource{
+		Description: `
+Checks the health of all data sources, or of the data sources of the given types.
+A failing health check doesn't fail the read, it's reported in the data source's ` + "`status`" + ` and ` + "`message`" + `.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/datasources/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/data_source/#check-data-source-health)
+`,
+		ReadContext: dataSourceReadDatasourcesHealth,
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDAttribute(),
+			"types": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Only check the data sources of these types, e.g. `[\"prometheus\", \"loki\"]`. Leave blank to check all data sources.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"data_sources": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"uid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "`OK`, `ERROR` or `UNKNOWN`, if the health check itself failed or isn't supported by the data source's plugin.",
+						},
+						"message": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+	return common.NewLegacySDKDataSource(common.CategoryGrafanaOSS, "grafana_data_sources_health", schema)
+}
+
+type datasourceHealth struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+func dataSourceReadDatasourcesHealth(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, orgID := OAPIClientFromNewOrgResource(meta, d)
+
+	types := common.ListToStringSlice(d.Get("types").([]interface{}))
+	id := sha256.New()
+	id.Write([]byte(fmt.Sprintf("%v", types)))
+	d.SetId(MakeOrgResourceID(orgID, fmt.Sprintf("%x", id.Sum(nil))))
+
+	resp, err := client.Datasources.GetDataSources()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var dataSources []map[string]interface{}
+	for _, ds := range resp.GetPayload() {
+		if len(types) > 0 && !slices.Contains(types, ds.Type) {
+			continue
+		}
+
+		// The OpenAPI model of healthy responses has no status
+		var health datasourceHealth
+		_, err := client.Datasources.CheckDatasourceHealthWithUID(ds.UID, withResponseBody(&health))
+		// Unhealthy data sources are reported with a 400 and a regular health payload
+		var badRequest *datasources.CheckDatasourceHealthWithUIDBadRequest
+		switch {
+		case err == nil:
+		case errors.As(err, &badRequest) && badRequest.Payload != nil && badRequest.Payload.Status != "":
+			health = datasourceHealth{Status: badRequest.Payload.Status}
+			if badRequest.Payload.Message != nil {
+				health.Message = *badRequest.Payload.Message
+			}
+		default:
+			health = datasourceHealth{Status: "UNKNOWN", Message: err.Error()}
+		}
+
+		dataSources = append(dataSources, map[string]interface{}{
+			"uid":     ds.UID,
+			"name":    ds.Name,
+			"type":    ds.Type,
+			"status":  health.Status,
+			"message": health.Message,
+		})
+	}
+
+	if err := d.Set("data_sources", dataSources); err != nil {
+		return diag.Errorf("error setting data_sources attribute: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package grafana_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceDatasourcesHealth(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=9.0.0")
+
+	// Do not use parallel tests here because it tests a listing datasource on the default org
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "data-sources/grafana_data_sources_health/data-source.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchTypeSetElemNestedAttrs("data.grafana_data_sources_health.prometheus", "data_sources.*", map[string]*regexp.Regexp{
+						"name":   regexp.MustCompile("^prometheus-health$"),
+						"type":   regexp.MustCompile("^prometheus$"),
+						"status": regexp.MustCompile("^(OK|ERROR|UNKNOWN)$"),
+					}),
+				),
+			},
+		},
+	})
+}
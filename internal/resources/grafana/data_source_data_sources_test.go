@@ -0,0 +1,55 @@
+package grafana_test
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDatasourceDatasources(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	// Do not use parallel tests here because it tests a listing datasource on the default org
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "data-sources/grafana_data_sources/data-source.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.grafana_data_sources.all", "datasources.*", map[string]string{
+						"name": "prometheus-list",
+						"type": "prometheus",
+						"url":  "http://localhost:9090",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.grafana_data_sources.all", "datasources.*", map[string]string{
+						"name": "loki-list",
+						"type": "loki",
+						"url":  "http://localhost:3100",
+					}),
+					resource.TestMatchTypeSetElemNestedAttrs("data.grafana_data_sources.all", "datasources.*", map[string]*regexp.Regexp{
+						"name":       regexp.MustCompile("^loki-list$"),
+						"uid":        regexp.MustCompile("^.+$"),
+						"is_default": regexp.MustCompile("^(true|false)$"),
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.grafana_data_sources.prometheus", "datasources.*", map[string]string{
+						"name": "prometheus-list",
+					}),
+					func(s *terraform.State) error {
+						ds := s.RootModule().Resources["data.grafana_data_sources.prometheus"]
+						for k, v := range ds.Primary.Attributes {
+							if strings.HasSuffix(k, ".type") && v != "prometheus" {
+								return fmt.Errorf("expected only prometheus data sources, got %s = %s", k, v)
+							}
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,444 @@
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceLegacyAlerts() *common.DataSource {
+	schema := &schema.Resource{
+		Description: `
+Lists the legacy (dashboard panel) alerts of a dashboard, and converts them to a unified alerting rule group. This is meant to help migrating them to ` + "`grafana_rule_group`" + ` resources.
+
+Legacy alerting was removed in Grafana 11. This data source only works with earlier versions.
+
+* [Alerting HTTP API (legacy)](https://grafana.com/docs/grafana/v10.4/developers/http_api/alerting/)
+`,
+		ReadContext: dataSourceReadLegacyAlerts,
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDAttribute(),
+			"dashboard_uid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The UID of the dashboard to list legacy alerts for.",
+			},
+			"alerts": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"panel_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"rule_group_json": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The legacy alerts of the dashboard converted to a rule group, in the format of the alerting provisioning API. " +
+					"Like in Grafana's own migration, the conditions of each alert become a classic condition expression evaluated on the panel's queries. " +
+					"The group is named after the dashboard, in its folder, and is evaluated at the shortest frequency of the alerts. Alerts without conditions are skipped, and this is empty if no alert can be converted.",
+			},
+		},
+	}
+	return common.NewLegacySDKDataSource(common.CategoryAlerting, "grafana_legacy_alerts", schema)
+}
+
+func dataSourceReadLegacyAlerts(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, orgID := OAPIClientFromNewOrgResource(meta, d)
+
+	uid := d.Get("dashboard_uid").(string)
+	resp, err := client.Dashboards.GetDashboardByUID(uid)
+	if err != nil {
+		return diag.Errorf("error getting dashboard %s: %s", uid, err)
+	}
+	model, ok := resp.GetPayload().Dashboard.(map[string]interface{})
+	if !ok {
+		return diag.Errorf("unexpected model for dashboard %s", uid)
+	}
+	dashboardID, ok := model["id"].(float64)
+	if !ok {
+		return diag.Errorf("dashboard %s has no ID", uid)
+	}
+
+	group, skipped, err := LegacyAlertRuleGroup(model, resp.GetPayload().Meta.FolderUID, orgID, func(ref interface{}) (string, error) {
+		return legacyAlertDatasourceUID(client, ref)
+	})
+	if err != nil {
+		return diag.Errorf("error converting the legacy alerts of dashboard %s: %s", uid, err)
+	}
+	groupJSON := []byte{}
+	if group != nil {
+		if groupJSON, err = json.Marshal(group); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	var diags diag.Diagnostics
+	for _, name := range skipped {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("legacy alert %q has no conditions, it isn't part of the rule group", name),
+		})
+	}
+
+	alerts, err := getLegacyAlerts(ctx, client, int64(dashboardID))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tfAlerts := make([]map[string]interface{}, len(alerts))
+	for i, alert := range alerts {
+		tfAlerts[i] = map[string]interface{}{
+			"id":       alert.ID,
+			"panel_id": alert.PanelID,
+			"name":     alert.Name,
+			"state":    alert.State,
+			"url":      alert.URL,
+		}
+	}
+
+	d.SetId(MakeOrgResourceID(orgID, uid))
+	if err := d.Set("alerts", tfAlerts); err != nil {
+		return diag.Errorf("error setting alerts attribute: %s", err)
+	}
+	d.Set("rule_group_json", string(groupJSON))
+
+	return diags
+}
+
+type legacyAlert struct {
+	ID      int64  `json:"id"`
+	PanelID int64  `json:"panelId"`
+	Name    string `json:"name"`
+	State   string `json:"state"`
+	URL     string `json:"url"`
+}
+
+// getLegacyAlerts calls the legacy alerting API, which isn't part of the OpenAPI client.
+func getLegacyAlerts(ctx context.Context, client *goapi.GrafanaHTTPAPI, dashboardID int64) ([]legacyAlert, error) {
+	var alerts []legacyAlert
+	query := map[string]string{"dashboardId": strconv.FormatInt(dashboardID, 10)}
+	if err := getRawJSON(ctx, client, "getLegacyAlerts", "/alerts", query, &alerts); err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
+
+// legacyAlertDatasourceUID returns the UID of a data source referenced by a legacy dashboard: by UID, by name, or the default data source if not set.
+func legacyAlertDatasourceUID(client *goapi.GrafanaHTTPAPI, ref interface{}) (string, error) {
+	switch ref := ref.(type) {
+	case map[string]interface{}:
+		if uid, ok := ref["uid"].(string); ok && uid != "" {
+			return uid, nil
+		}
+	case string:
+		resp, err := client.Datasources.GetDataSourceByName(ref)
+		if err != nil {
+			return "", fmt.Errorf("error getting data source %q: %w", ref, err)
+		}
+		return resp.GetPayload().UID, nil
+	}
+
+	resp, err := client.Datasources.GetDataSources()
+	if err != nil {
+		return "", fmt.Errorf("error getting data sources: %w", err)
+	}
+	for _, ds := range resp.GetPayload() {
+		if ds.IsDefault {
+			return ds.UID, nil
+		}
+	}
+	return "", errors.New("the default data source is used, but there is none")
+}
+
+// LegacyAlertRuleGroup converts the legacy alerts of a dashboard model to a rule group, in the format of the alerting provisioning API.
+// The group is nil if no alert can be converted. It also returns the names of the alerts that were skipped because they have no conditions.
+func LegacyAlertRuleGroup(dashboard map[string]interface{}, folderUID string, orgID int64, datasourceUID func(ref interface{}) (string, error)) (map[string]interface{}, []string, error) {
+	groupName, _ := dashboard["title"].(string)
+	dashboardUID, _ := dashboard["uid"].(string)
+
+	var rules []interface{}
+	var skipped []string
+	var interval time.Duration
+	for _, panel := range legacyDashboardPanels(dashboard) {
+		alert, ok := panel["alert"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := alert["name"].(string)
+		conditions, _ := alert["conditions"].([]interface{})
+		if len(conditions) == 0 {
+			skipped = append(skipped, name)
+			continue
+		}
+
+		frequency, err := legacyAlertDuration(alert["frequency"], time.Minute)
+		if err != nil {
+			return nil, nil, fmt.Errorf("alert %q: invalid frequency: %w", name, err)
+		}
+		if interval == 0 || frequency < interval {
+			interval = frequency
+		}
+		forDuration, err := legacyAlertDuration(alert["for"], 0)
+		if err != nil {
+			return nil, nil, fmt.Errorf("alert %q: invalid `for` duration: %w", name, err)
+		}
+
+		data, condition, err := legacyAlertQueries(panel, conditions, datasourceUID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("alert %q: %w", name, err)
+		}
+
+		panelID, _ := panel["id"].(float64)
+		annotations := map[string]string{
+			"__dashboardUid__": dashboardUID,
+			"__panelId__":      strconv.FormatInt(int64(panelID), 10),
+		}
+		if message, _ := alert["message"].(string); message != "" {
+			annotations["message"] = message
+		}
+		labels := map[string]string{}
+		if tags, ok := alert["alertRuleTags"].(map[string]interface{}); ok {
+			for k, v := range tags {
+				labels[k] = fmt.Sprint(v)
+			}
+		}
+
+		noDataState, _ := alert["noDataState"].(string)
+		execErrState, _ := alert["executionErrorState"].(string)
+		rules = append(rules, map[string]interface{}{
+			"title":        name,
+			"orgID":        orgID,
+			"folderUID":    folderUID,
+			"ruleGroup":    groupName,
+			"condition":    condition,
+			"data":         data,
+			"for":          forDuration.String(),
+			"noDataState":  legacyAlertNoDataState(noDataState),
+			"execErrState": legacyAlertExecErrState(execErrState),
+			"annotations":  annotations,
+			"labels":       labels,
+		})
+	}
+
+	if len(rules) == 0 {
+		return nil, skipped, nil
+	}
+	return map[string]interface{}{
+		"title":     groupName,
+		"folderUid": folderUID,
+		"interval":  int64(interval / time.Second),
+		"rules":     rules,
+	}, skipped, nil
+}
+
+// legacyDashboardPanels returns the panels of a dashboard, including the panels of collapsed rows and of the rows of the old dashboard schema.
+func legacyDashboardPanels(dashboard map[string]interface{}) []map[string]interface{} {
+	var panels []map[string]interface{}
+	var add func(list interface{})
+	add = func(list interface{}) {
+		items, _ := list.([]interface{})
+		for _, item := range items {
+			panel, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			panels = append(panels, panel)
+			add(panel["panels"])
+		}
+	}
+	add(dashboard["panels"])
+	rows, _ := dashboard["rows"].([]interface{})
+	for _, row := range rows {
+		if row, ok := row.(map[string]interface{}); ok {
+			add(row["panels"])
+		}
+	}
+	return panels
+}
+
+// legacyAlertQueries returns the queries of a legacy alert and the classic condition expression that evaluates them, whose ref ID is also returned.
+// Each condition of the alert queries a panel target over its own time range, so a query is added for each distinct target and time range.
+func legacyAlertQueries(panel map[string]interface{}, conditions []interface{}, datasourceUID func(ref interface{}) (string, error)) ([]interface{}, string, error) {
+	targets := map[string]map[string]interface{}{}
+	rawTargets, _ := panel["targets"].([]interface{})
+	for _, t := range rawTargets {
+		if target, ok := t.(map[string]interface{}); ok {
+			refID, _ := target["refId"].(string)
+			targets[refID] = target
+		}
+	}
+
+	var data []interface{}
+	refIDs := map[string]string{}
+	used := map[string]bool{}
+	newConditions := make([]interface{}, 0, len(conditions))
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			return nil, "", fmt.Errorf("invalid condition %v", c)
+		}
+		query, _ := condition["query"].(map[string]interface{})
+		params, _ := query["params"].([]interface{})
+		if len(params) != 3 {
+			return nil, "", fmt.Errorf("invalid condition query parameters %v, expected a ref ID and a time range", params)
+		}
+		refID, from, to := fmt.Sprint(params[0]), fmt.Sprint(params[1]), fmt.Sprint(params[2])
+
+		key := strings.Join([]string{refID, from, to}, "/")
+		newRefID, ok := refIDs[key]
+		if !ok {
+			target, ok := targets[refID]
+			if !ok {
+				return nil, "", fmt.Errorf("the panel has no query %q", refID)
+			}
+			fromSeconds, toSeconds, err := legacyAlertTimeRange(from, to)
+			if err != nil {
+				return nil, "", fmt.Errorf("query %q: %w", refID, err)
+			}
+			datasource := target["datasource"]
+			if datasource == nil {
+				datasource = panel["datasource"]
+			}
+			uid, err := datasourceUID(datasource)
+			if err != nil {
+				return nil, "", fmt.Errorf("query %q: %w", refID, err)
+			}
+
+			newRefID = unusedLegacyAlertRefID(used, refID)
+			refIDs[key] = newRefID
+			model := make(map[string]interface{}, len(target))
+			for k, v := range target {
+				model[k] = v
+			}
+			model["refId"] = newRefID
+			data = append(data, map[string]interface{}{
+				"refId":             newRefID,
+				"datasourceUid":     uid,
+				"relativeTimeRange": map[string]interface{}{"from": fromSeconds, "to": toSeconds},
+				"model":             model,
+			})
+		}
+
+		newCondition := make(map[string]interface{}, len(condition))
+		for k, v := range condition {
+			newCondition[k] = v
+		}
+		newCondition["query"] = map[string]interface{}{"params": []interface{}{newRefID}}
+		newConditions = append(newConditions, newCondition)
+	}
+
+	conditionRefID := unusedLegacyAlertRefID(used, "condition")
+	data = append(data, map[string]interface{}{
+		"refId":             conditionRefID,
+		"datasourceUid":     "__expr__",
+		"relativeTimeRange": map[string]interface{}{"from": 0, "to": 0},
+		"model": map[string]interface{}{
+			"refId":      conditionRefID,
+			"type":       "classic_conditions",
+			"datasource": map[string]interface{}{"type": "__expr__", "uid": "__expr__"},
+			"conditions": newConditions,
+		},
+	})
+	return data, conditionRefID, nil
+}
+
+// legacyAlertTimeRange returns the seconds in the past of the time range of a condition query, e.g. from `5m` to `now` or `now-1m`.
+func legacyAlertTimeRange(from, to string) (int64, int64, error) {
+	parse := func(value string) (int64, error) {
+		if value == "now" {
+			return 0, nil
+		}
+		d, err := strfmt.ParseDuration(strings.TrimPrefix(value, "now-"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid relative time %q: %w", value, err)
+		}
+		return int64(d / time.Second), nil
+	}
+	fromSeconds, err := parse(from)
+	if err != nil {
+		return 0, 0, err
+	}
+	toSeconds, err := parse(to)
+	if err != nil {
+		return 0, 0, err
+	}
+	if fromSeconds <= toSeconds {
+		return 0, 0, fmt.Errorf("the time range from %s to %s is empty", from, to)
+	}
+	return fromSeconds, toSeconds, nil
+}
+
+func unusedLegacyAlertRefID(used map[string]bool, refID string) string {
+	candidate := refID
+	for i := 1; used[candidate]; i++ {
+		candidate = refID + strconv.Itoa(i)
+	}
+	used[candidate] = true
+	return candidate
+}
+
+func legacyAlertDuration(value interface{}, defaultValue time.Duration) (time.Duration, error) {
+	s, _ := value.(string)
+	if s == "" {
+		return defaultValue, nil
+	}
+	d, err := strfmt.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	return d, nil
+}
+
+// legacyAlertNoDataState maps the no data option of a legacy alert, like Grafana's migration. Keeping the last state isn't supported anymore.
+func legacyAlertNoDataState(option string) string {
+	switch option {
+	case "ok":
+		return "OK"
+	case "alerting":
+		return "Alerting"
+	default:
+		return "NoData"
+	}
+}
+
+// legacyAlertExecErrState maps the execution error option of a legacy alert, like Grafana's migration. Keeping the last state isn't supported anymore.
+func legacyAlertExecErrState(option string) string {
+	switch option {
+	case "alerting":
+		return "Alerting"
+	case "ok":
+		return "OK"
+	default:
+		return "Error"
+	}
+}
@@ -0,0 +1,146 @@
+package grafana_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/resources/grafana"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccDatasourceLegacyAlerts(t *testing.T) {
+	// Legacy alerting was removed in Grafana 11
+	testutils.CheckOSSTestsEnabled(t, "<11.0.0")
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "data-sources/grafana_legacy_alerts/data-source.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.grafana_legacy_alerts.test", "dashboard_uid", "legacy-alerts-dashboard"),
+					resource.TestCheckResourceAttrSet("data.grafana_legacy_alerts.test", "alerts.#"),
+					resource.TestCheckResourceAttrWith("data.grafana_legacy_alerts.test", "rule_group_json", func(value string) error {
+						var group struct {
+							Title    string `json:"title"`
+							Interval int    `json:"interval"`
+							Rules    []struct {
+								Title     string `json:"title"`
+								Condition string `json:"condition"`
+							} `json:"rules"`
+						}
+						if err := json.Unmarshal([]byte(value), &group); err != nil {
+							return err
+						}
+						if group.Title != "Dashboard with legacy alerts" || group.Interval != 60 {
+							return fmt.Errorf("unexpected rule group %q with interval %d", group.Title, group.Interval)
+						}
+						if len(group.Rules) != 1 || group.Rules[0].Title != "Too many errors" || group.Rules[0].Condition != "condition" {
+							return fmt.Errorf("unexpected rules: %+v", group.Rules)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestLegacyAlertRuleGroup(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	var dashboard map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"uid": "dash",
+		"title": "Dashboard",
+		"panels": [
+			{"id": 1, "type": "graph", "title": "No alert"},
+			{
+				"id": 2,
+				"type": "row",
+				"collapsed": true,
+				"panels": [{
+					"id": 3,
+					"type": "graph",
+					"datasource": {"type": "prometheus", "uid": "prom"},
+					"targets": [{"refId": "A", "expr": "up"}],
+					"alert": {
+						"name": "Down",
+						"message": "Something is down",
+						"frequency": "5m",
+						"for": "10m",
+						"noDataState": "alerting",
+						"executionErrorState": "keep_state",
+						"alertRuleTags": {"team": "infra"},
+						"conditions": [
+							{"type": "query", "query": {"params": ["A", "5m", "now"]}, "reducer": {"type": "avg"}, "evaluator": {"type": "lt", "params": [1]}, "operator": {"type": "and"}},
+							{"type": "query", "query": {"params": ["A", "1h", "now-5m"]}, "reducer": {"type": "max"}, "evaluator": {"type": "lt", "params": [1]}, "operator": {"type": "or"}}
+						]
+					}
+				}]
+			},
+			{"id": 4, "type": "graph", "alert": {"name": "Empty", "frequency": "1m", "conditions": []}}
+		]
+	}`), &dashboard))
+
+	group, skipped, err := grafana.LegacyAlertRuleGroup(dashboard, "folder", 1, func(ref interface{}) (string, error) {
+		return ref.(map[string]interface{})["uid"].(string), nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Empty"}, skipped)
+
+	actual, err := json.Marshal(group)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"title": "Dashboard",
+		"folderUid": "folder",
+		"interval": 300,
+		"rules": [{
+			"title": "Down",
+			"orgID": 1,
+			"folderUID": "folder",
+			"ruleGroup": "Dashboard",
+			"condition": "condition",
+			"for": "10m0s",
+			"noDataState": "Alerting",
+			"execErrState": "Error",
+			"annotations": {"__dashboardUid__": "dash", "__panelId__": "3", "message": "Something is down"},
+			"labels": {"team": "infra"},
+			"data": [
+				{"refId": "A", "datasourceUid": "prom", "relativeTimeRange": {"from": 300, "to": 0}, "model": {"refId": "A", "expr": "up"}},
+				{"refId": "A1", "datasourceUid": "prom", "relativeTimeRange": {"from": 3600, "to": 300}, "model": {"refId": "A1", "expr": "up"}},
+				{
+					"refId": "condition",
+					"datasourceUid": "__expr__",
+					"relativeTimeRange": {"from": 0, "to": 0},
+					"model": {
+						"refId": "condition",
+						"type": "classic_conditions",
+						"datasource": {"type": "__expr__", "uid": "__expr__"},
+						"conditions": [
+							{"type": "query", "query": {"params": ["A"]}, "reducer": {"type": "avg"}, "evaluator": {"type": "lt", "params": [1]}, "operator": {"type": "and"}},
+							{"type": "query", "query": {"params": ["A1"]}, "reducer": {"type": "max"}, "evaluator": {"type": "lt", "params": [1]}, "operator": {"type": "or"}}
+						]
+					}
+				}
+			]
+		}]
+	}`, string(actual))
+}
+
+func TestLegacyAlertRuleGroupWithoutAlerts(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	dashboard := map[string]interface{}{
+		"uid":    "dash",
+		"title":  "Dashboard",
+		"panels": []interface{}{map[string]interface{}{"id": 1.0, "type": "graph"}},
+	}
+	group, skipped, err := grafana.LegacyAlertRuleGroup(dashboard, "folder", 1, nil)
+	require.NoError(t, err)
+	require.Nil(t, group)
+	require.Empty(t, skipped)
+}
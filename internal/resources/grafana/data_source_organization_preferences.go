@@ -33,6 +33,11 @@ func dataSourceOrganizationPreferencesRead(ctx context.Context, d *schema.Resour
 	prefs := resp.Payload
 	d.Set("theme", prefs.Theme)
 	d.Set("home_dashboard_uid", prefs.HomeDashboardUID)
+	title, err := homeDashboardTitle(client, prefs.HomeDashboardUID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("home_dashboard_title", title)
 	d.Set("timezone", prefs.Timezone)
 	d.Set("week_start", prefs.WeekStart)
 
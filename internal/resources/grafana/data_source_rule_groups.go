@@ -0,0 +1,106 @@
+package grafana
+
+import (
+	"context"
+	"sort"
+
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceRuleGroups() *common.DataSource {
+	schema := &schema.Resource{
+		Description: `
+Lists the alert rule groups of a folder. This is meant to help adopting existing rule groups, e.g. with ` + "`import`" + ` blocks of ` + "`grafana_rule_group`" + ` resources.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/alerting/alerting-rules/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/alerting_provisioning/#alert-rules)
+
+This data source requires Grafana 9.1.0 or later.
+`,
+		ReadContext: dataSourceReadRuleGroups,
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDAttribute(),
+			"folder_uid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The UID of the folder to list rule groups for.",
+			},
+			"rule_groups": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The rule groups of the folder, sorted by name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the rule group, as used to import `grafana_rule_group` resources.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the rule group.",
+						},
+						"rules_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of rules in the rule group.",
+						},
+					},
+				},
+			},
+		},
+	}
+	return common.NewLegacySDKDataSource(common.CategoryAlerting, "grafana_rule_groups", schema)
+}
+
+func dataSourceReadRuleGroups(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, orgID := OAPIClientFromNewOrgResource(meta, d)
+	folderUID := d.Get("folder_uid").(string)
+
+	if _, err := client.Folders.GetFolderByUID(folderUID); err != nil {
+		return diag.Errorf("error getting folder %s: %s", folderUID, err)
+	}
+
+	// The provisioning API returns all of the rules at once, there are no pages to go through
+	resp, err := client.Provisioning.GetAlertRules()
+	if err != nil {
+		return diag.Errorf("error listing alert rules: %s", err)
+	}
+
+	d.SetId(MakeOrgResourceID(orgID, folderUID))
+	if err := d.Set("rule_groups", folderRuleGroups(orgID, folderUID, resp.Payload)); err != nil {
+		return diag.Errorf("error setting rule_groups attribute: %s", err)
+	}
+	return nil
+}
+
+// folderRuleGroups returns the rule groups of the given folder, with their number of rules, sorted by name.
+func folderRuleGroups(orgID int64, folderUID string, rules []*models.ProvisionedAlertRule) []interface{} {
+	counts := map[string]int{}
+	for _, rule := range rules {
+		if rule == nil || rule.FolderUID == nil || *rule.FolderUID != folderUID || rule.RuleGroup == nil {
+			continue
+		}
+		counts[*rule.RuleGroup]++
+	}
+
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	groups := make([]interface{}, len(names))
+	for i, name := range names {
+		groups[i] = map[string]interface{}{
+			"id":          resourceRuleGroupID.Make(orgID, folderUID, name),
+			"name":        name,
+			"rules_count": counts[name],
+		}
+	}
+	return groups
+}
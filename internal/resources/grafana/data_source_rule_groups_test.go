@@ -0,0 +1,30 @@
+package grafana_test
+
+import (
+	"testing"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceRuleGroups(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=9.1.0")
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "data-sources/grafana_rule_groups/data-source.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.grafana_rule_groups.alerts", "rule_groups.#", "2"),
+					resource.TestCheckResourceAttr("data.grafana_rule_groups.alerts", "rule_groups.0.name", "availability"),
+					resource.TestCheckResourceAttr("data.grafana_rule_groups.alerts", "rule_groups.0.rules_count", "2"),
+					resource.TestCheckResourceAttrPair("data.grafana_rule_groups.alerts", "rule_groups.0.id", "grafana_rule_group.availability", "id"),
+					resource.TestCheckResourceAttr("data.grafana_rule_groups.alerts", "rule_groups.1.name", "latency"),
+					resource.TestCheckResourceAttr("data.grafana_rule_groups.alerts", "rule_groups.1.rules_count", "1"),
+					resource.TestCheckResourceAttrPair("data.grafana_rule_groups.alerts", "rule_groups.1.id", "grafana_rule_group.latency", "id"),
+				),
+			},
+		},
+	})
+}
@@ -10,6 +10,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// dataSourceUserLookupFields are the attributes a user can be looked up by, exactly one of them must be set.
+var dataSourceUserLookupFields = []string{"user_id", "email", "login"}
+
 func datasourceUser() *common.DataSource {
 	schema := &schema.Resource{
 		Description: `
@@ -18,26 +21,31 @@ func datasourceUser() *common.DataSource {
 
 This data source uses Grafana's admin APIs for reading users which
 does not currently work with API Tokens. You must use basic auth.
+
+Exactly one of ` + "`user_id`, `email` or `login`" + ` must be set to look up the user.
 `,
 		ReadContext: dataSourceUserRead,
 		Schema: map[string]*schema.Schema{
 			"user_id": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Default:     -1,
-				Description: "The numerical ID of the Grafana user.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: dataSourceUserLookupFields,
+				Description:  "The numerical ID of the Grafana user.",
 			},
 			"email": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "The email address of the Grafana user.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: dataSourceUserLookupFields,
+				Description:  "The email address of the Grafana user.",
 			},
 			"login": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "The username for the Grafana user.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: dataSourceUserLookupFields,
+				Description:  "The username for the Grafana user.",
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -61,20 +69,24 @@ func dataSourceUserRead(ctx context.Context, d *schema.ResourceData, meta interf
 	}
 
 	var resp interface{ GetPayload() *models.UserProfileDTO }
+	var lookup string
 
-	emailOrLogin := d.Get("email").(string)
-	if emailOrLogin == "" {
-		emailOrLogin = d.Get("login").(string)
-	}
-
-	if id := d.Get("user_id").(int); id >= 0 {
-		resp, err = client.Users.GetUserByID(int64(id))
-	} else if emailOrLogin != "" {
-		resp, err = client.Users.GetUserByLoginOrEmail(emailOrLogin)
+	if id, ok := d.GetOk("user_id"); ok {
+		lookup = fmt.Sprintf("ID %d", id.(int))
+		resp, err = client.Users.GetUserByID(int64(id.(int)))
+	} else if email, ok := d.GetOk("email"); ok {
+		lookup = fmt.Sprintf("email %q", email.(string))
+		resp, err = client.Users.GetUserByLoginOrEmail(email.(string))
+	} else if login, ok := d.GetOk("login"); ok {
+		lookup = fmt.Sprintf("login %q", login.(string))
+		resp, err = client.Users.GetUserByLoginOrEmail(login.(string))
 	} else {
 		err = fmt.Errorf("must specify one of user_id, email, or login")
 	}
 
+	if common.IsNotFoundError(err) {
+		return diag.Errorf("user with %s not found", lookup)
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -1,6 +1,7 @@
 package grafana_test
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/grafana/grafana-openapi-client-go/models"
@@ -47,3 +48,33 @@ func TestAccDatasourceUser_basic(t *testing.T) {
 		},
 	})
 }
+
+func TestAccDatasourceUser_lookupErrors(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "grafana_user" "test" {
+					email = "does-not-exist@example.com"
+				}`,
+				ExpectError: regexp.MustCompile(`user with email "does-not-exist@example.com" not found`),
+			},
+			{
+				Config: `
+				data "grafana_user" "test" {
+					email = "test.datasource@example.com"
+					login = "test-datasource"
+				}`,
+				ExpectError: regexp.MustCompile(`only one of .email,login,user_id. can be specified`),
+			},
+			{
+				Config: `
+				data "grafana_user" "test" {}`,
+				ExpectError: regexp.MustCompile(`one of .email,login,user_id. must be specified`),
+			},
+		},
+	})
+}
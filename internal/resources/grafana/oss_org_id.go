@@ -70,11 +70,11 @@ func SplitServiceAccountID(id string) (int64, string) {
 // Those IDs are in the <orgID>:<resourceID> format
 func OAPIClientFromExistingOrgResource(meta interface{}, id string) (*goapi.GrafanaHTTPAPI, int64, string) {
 	orgID, restOfID := SplitOrgResourceID(id)
-	client := meta.(*common.Client).GrafanaAPI.Clone()
+	client := common.CloneGrafanaAPI(meta.(*common.Client).GrafanaAPI)
 	if orgID == 0 {
 		orgID = client.OrgID()
 	} else if orgID > 0 {
-		client = client.WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 	}
 	return client, orgID, restOfID
 }
@@ -83,18 +83,35 @@ func OAPIClientFromExistingOrgResource(meta interface{}, id string) (*goapi.Graf
 // This client is meant to be used in `Create` functions when the ID hasn't already been baked into the resource ID
 func OAPIClientFromNewOrgResource(meta interface{}, d *schema.ResourceData) (*goapi.GrafanaHTTPAPI, int64) {
 	orgID := parseOrgID(d)
-	client := meta.(*common.Client).GrafanaAPI.Clone()
+	client := common.CloneGrafanaAPI(meta.(*common.Client).GrafanaAPI)
 	if orgID == 0 {
 		orgID = client.OrgID()
 	} else if orgID > 0 {
-		client = client.WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
+	}
+	return client, orgID
+}
+
+// oapiClientFromResourceDiff is like OAPIClientFromNewOrgResource, for `CustomizeDiff` functions.
+// It returns a nil client if the provider isn't configured to call the Grafana API.
+func oapiClientFromResourceDiff(meta interface{}, d *schema.ResourceDiff) (*goapi.GrafanaHTTPAPI, int64) {
+	metaClient, ok := meta.(*common.Client)
+	if !ok || metaClient.GrafanaAPI == nil {
+		return nil, 0
+	}
+	orgID, _ := strconv.ParseInt(d.Get("org_id").(string), 10, 64)
+	client := common.CloneGrafanaAPI(metaClient.GrafanaAPI)
+	if orgID == 0 {
+		orgID = client.OrgID()
+	} else if orgID > 0 {
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 	}
 	return client, orgID
 }
 
 func OAPIGlobalClient(meta interface{}) (*goapi.GrafanaHTTPAPI, error) {
 	metaClient := meta.(*common.Client)
-	client := meta.(*common.Client).GrafanaAPI.Clone().WithOrgID(0)
+	client := common.GrafanaAPIWithOrgID(meta.(*common.Client).GrafanaAPI, 0)
 	if metaClient.GrafanaAPIConfig.APIKey != "" {
 		return client, fmt.Errorf("global scope resources cannot be managed with an API key. Use basic auth instead")
 	}
@@ -53,11 +53,16 @@ Manages Grafana Alerting contact points.
 * [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/alerting_provisioning/#contact-points)
 
 This resource requires Grafana 9.1.0 or later.
+
+Contact points can be imported by name, or by the UID of one of their notifiers. Secure settings are redacted by Grafana, so they aren't imported.
+
+Notifiers can't be disabled individually, Grafana has no such setting for the integrations of a contact point. To stop using a notifier, remove its block, which deletes it from Grafana.
 `,
 		CreateContext: common.WithAlertingMutex[schema.CreateContextFunc](updateContactPoint),
 		ReadContext:   readContactPoint,
 		UpdateContext: common.WithAlertingMutex[schema.UpdateContextFunc](updateContactPoint),
 		DeleteContext: common.WithAlertingMutex[schema.DeleteContextFunc](deleteContactPoint),
+		CustomizeDiff: recordPlannedContactPoint,
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -106,6 +111,32 @@ This resource requires Grafana 9.1.0 or later.
 	)
 }
 
+// recordPlannedContactPoint records the name of the contact point when planning,
+// so that the resources referencing it in the same run pass their plan-time checks before it is created.
+func recordPlannedContactPoint(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	metaClient, ok := meta.(*common.Client)
+	if !ok || !d.NewValueKnown("name") || !d.NewValueKnown("org_id") {
+		return nil
+	}
+	_, orgID := oapiClientFromResourceDiff(meta, d)
+	metaClient.PlannedContactPoints.Store(plannedContactPointKey(orgID, d.Get("name").(string)), true)
+	return nil
+}
+
+// contactPointPlanned returns whether a contact point with this name is planned in this run.
+func contactPointPlanned(meta interface{}, orgID int64, name string) bool {
+	metaClient, ok := meta.(*common.Client)
+	if !ok {
+		return false
+	}
+	_, planned := metaClient.PlannedContactPoints.Load(plannedContactPointKey(orgID, name))
+	return planned
+}
+
+func plannedContactPointKey(orgID int64, name string) string {
+	return strconv.FormatInt(orgID, 10) + common.ResourceIDSeparator + name
+}
+
 // TODO: Fix contact points lister. Terraform doesn't read any of the sensitive fields (or their container)
 // It outputs an empty `email {}` block for example, which is not valid.
 // func listContactPoints(ctx context.Context, client *goapi.GrafanaHTTPAPI, data *ListerData) ([]string, error) {
@@ -153,10 +184,14 @@ func readContactPoint(ctx context.Context, data *schema.ResourceData, meta inter
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	var points []*models.EmbeddedContactPoint
-	for _, p := range resp.Payload {
-		if p.Name == name {
-			points = append(points, p)
+	points := contactPointsByName(resp.Payload, name)
+	if len(points) == 0 && data.Get("name").(string) == "" {
+		// On import, the ID can also be the UID of one of the contact point's notifiers
+		for _, p := range resp.Payload {
+			if p.UID == name {
+				points = contactPointsByName(resp.Payload, p.Name)
+				break
+			}
 		}
 	}
 	if len(points) == 0 {
@@ -172,6 +207,16 @@ func readContactPoint(ctx context.Context, data *schema.ResourceData, meta inter
 	return nil
 }
 
+func contactPointsByName(all []*models.EmbeddedContactPoint, name string) []*models.EmbeddedContactPoint {
+	var points []*models.EmbeddedContactPoint
+	for _, p := range all {
+		if p.Name == name {
+			points = append(points, p)
+		}
+	}
+	return points
+}
+
 func updateContactPoint(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, orgID := OAPIClientFromNewOrgResource(meta, data)
 
@@ -341,6 +386,7 @@ func packContactPoints(ps []*models.EmbeddedContactPoint, data *schema.ResourceD
 				if err != nil {
 					return err
 				}
+				dropRedactedSecureFields(packed.(map[string]interface{}), n.meta().secureFields)
 				pointsPerNotifier[n] = append(pointsPerNotifier[n], packed)
 				continue
 			}
@@ -355,6 +401,26 @@ func packContactPoints(ps []*models.EmbeddedContactPoint, data *schema.ResourceD
 	return nil
 }
 
+// redactedSecureValue is the placeholder that Grafana returns instead of the secure settings of notifiers.
+const redactedSecureValue = "[REDACTED]"
+
+// dropRedactedSecureFields removes the secure settings that couldn't be taken from the state (e.g. on import).
+// Storing the placeholder would show a misleading diff, and could send it back to Grafana as the setting's value.
+func dropRedactedSecureFields(packed map[string]interface{}, secureFields []string) {
+	for _, tfKey := range secureFields {
+		if packed[tfKey] == redactedSecureValue {
+			delete(packed, tfKey)
+		}
+	}
+	if settings, ok := packed["settings"].(map[string]interface{}); ok {
+		for k, v := range settings {
+			if v == redactedSecureValue {
+				delete(settings, k)
+			}
+		}
+	}
+}
+
 func unpackCommonNotifierFields(raw map[string]interface{}) (string, bool, map[string]interface{}) {
 	return raw["uid"].(string), raw["disable_resolve_message"].(bool), raw["settings"].(map[string]interface{})
 }
@@ -429,6 +495,46 @@ func packNotifierStringField(gfSettings, tfSettings *map[string]interface{}, gfK
 	}
 }
 
+func packNotifierIntField(gfSettings, tfSettings *map[string]interface{}, gfKey, tfKey string) error {
+	if v, ok := (*gfSettings)[gfKey]; ok && v != nil {
+		i, err := NotifierIntSetting(v)
+		if err != nil {
+			return fmt.Errorf("failed to parse value of '%s' to integer: %w", gfKey, err)
+		}
+		(*tfSettings)[tfKey] = i
+		delete(*gfSettings, gfKey)
+	}
+	return nil
+}
+
+// NotifierIntSetting converts a numeric notifier setting to an integer.
+// Depending on the notifier and on the Grafana version, numeric settings are returned as numbers or as strings.
+func NotifierIntSetting(v interface{}) (int, error) {
+	switch typ := v.(type) {
+	case int:
+		return typ, nil
+	case float64:
+		return int(typ), nil
+	case string:
+		return strconv.Atoi(typ)
+	default:
+		return 0, fmt.Errorf("unexpected type %T: %v", typ, typ)
+	}
+}
+
+// NotifierBoolSetting converts a boolean notifier setting to a bool.
+// Contact points provisioned from files or created by older Grafana versions can store boolean settings as strings.
+func NotifierBoolSetting(v interface{}) (bool, error) {
+	switch typ := v.(type) {
+	case bool:
+		return typ, nil
+	case string:
+		return strconv.ParseBool(typ)
+	default:
+		return false, fmt.Errorf("unexpected type %T: %v", typ, typ)
+	}
+}
+
 func packSecureFields(tfSettings, state map[string]interface{}, secureFields []string) {
 	for _, tfKey := range secureFields {
 		if v, ok := state[tfKey]; ok && v != nil {
@@ -443,6 +549,12 @@ func unpackNotifierStringField(tfSettings, gfSettings *map[string]interface{}, t
 	}
 }
 
+func unpackNotifierIntField(tfSettings, gfSettings *map[string]interface{}, tfKey, gfKey string) {
+	if v, ok := (*tfSettings)[tfKey]; ok && v != nil {
+		(*gfSettings)[gfKey] = v.(int)
+	}
+}
+
 func getNotifierConfigFromStateWithUID(data *schema.ResourceData, n notifier, uid string) map[string]interface{} {
 	if points, ok := data.GetOk(n.meta().field); ok {
 		for _, pt := range points.(*schema.Set).List() {
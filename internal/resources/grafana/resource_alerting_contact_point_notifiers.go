@@ -2,9 +2,14 @@ package grafana
 
 import (
 	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
@@ -322,7 +327,11 @@ func (e emailNotifier) pack(p *models.EmbeddedContactPoint, data *schema.Resourc
 		delete(settings, "addresses")
 	}
 	if v, ok := settings["singleEmail"]; ok && v != nil {
-		notifier["single_email"] = v.(bool)
+		singleEmail, err := NotifierBoolSetting(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse value of 'singleEmail' to boolean: %w", err)
+		}
+		notifier["single_email"] = singleEmail
 		delete(settings, "singleEmail")
 	}
 	if v, ok := settings["message"]; ok && v != nil {
@@ -668,9 +677,10 @@ func (w oncallNotifier) schema() *schema.Resource {
 		Description: "Allows a custom authorization scheme - attaches an auth header with this value. Do not use in conjunction with basic auth parameters.",
 	}
 	r.Schema["max_alerts"] = &schema.Schema{
-		Type:        schema.TypeInt,
-		Optional:    true,
-		Description: "The maximum number of alerts to send in a single request. This can be helpful in limiting the size of the request body. The default is 0, which indicates no limit.",
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(0),
+		Description:  "The maximum number of alerts to send in a single request. This can be helpful in limiting the size of the request body. The default is 0, which indicates no limit.",
 	}
 	r.Schema["message"] = &schema.Schema{
 		Type:        schema.TypeString,
@@ -697,22 +707,8 @@ func (w oncallNotifier) pack(p *models.EmbeddedContactPoint, data *schema.Resour
 	packNotifierStringField(&settings, &notifier, "authorization_credentials", "authorization_credentials")
 	packNotifierStringField(&settings, &notifier, "message", "message")
 	packNotifierStringField(&settings, &notifier, "title", "title")
-	if v, ok := settings["maxAlerts"]; ok && v != nil {
-		switch typ := v.(type) {
-		case int:
-			notifier["max_alerts"] = v.(int)
-		case float64:
-			notifier["max_alerts"] = int(v.(float64))
-		case string:
-			val, err := strconv.Atoi(typ)
-			if err != nil {
-				panic(fmt.Errorf("failed to parse value of 'maxAlerts' to integer: %w", err))
-			}
-			notifier["max_alerts"] = val
-		default:
-			panic(fmt.Sprintf("unexpected type %T for 'maxAlerts': %v", typ, typ))
-		}
-		delete(settings, "maxAlerts")
+	if err := packNotifierIntField(&settings, &notifier, "maxAlerts", "max_alerts"); err != nil {
+		return nil, err
 	}
 
 	packSecureFields(notifier, getNotifierConfigFromStateWithUID(data, w, p.UID), w.meta().secureFields)
@@ -733,16 +729,7 @@ func (w oncallNotifier) unpack(raw interface{}, name string) *models.EmbeddedCon
 	unpackNotifierStringField(&json, &settings, "authorization_credentials", "authorization_credentials")
 	unpackNotifierStringField(&json, &settings, "message", "message")
 	unpackNotifierStringField(&json, &settings, "title", "title")
-	if v, ok := json["max_alerts"]; ok && v != nil {
-		switch typ := v.(type) {
-		case int:
-			settings["maxAlerts"] = v.(int)
-		case float64:
-			settings["maxAlerts"] = int(v.(float64))
-		default:
-			panic(fmt.Sprintf("unexpected type for maxAlerts: %v", typ))
-		}
-	}
+	unpackNotifierIntField(&json, &settings, "max_alerts", "maxAlerts")
 
 	return &models.EmbeddedContactPoint{
 		UID:                   uid,
@@ -958,12 +945,13 @@ func (n pagerDutyNotifier) schema() *schema.Resource {
 		Type:        schema.TypeString,
 		Required:    true,
 		Sensitive:   true,
-		Description: "The PagerDuty API key.",
+		Description: "The PagerDuty API key: the integration key of an Events API v2 integration of the service. Grafana only sends events through the Events API v2, the keys of legacy (v1) integrations aren't supported.",
 	}
 	r.Schema["severity"] = &schema.Schema{
-		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "The PagerDuty event severity level. Default is `critical`.",
+		Type:             schema.TypeString,
+		Optional:         true,
+		Description:      "The PagerDuty event severity level. One of `critical`, `error`, `warning` or `info`, or a template returning one of them. Default is `critical`.",
+		ValidateDiagFunc: validatePagerDutySeverity,
 	}
 	r.Schema["class"] = &schema.Schema{
 		Type:        schema.TypeString,
@@ -1010,13 +998,50 @@ func (n pagerDutyNotifier) schema() *schema.Resource {
 		},
 	}
 	r.Schema["url"] = &schema.Schema{
-		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "The URL to send API requests to",
+		Type:             schema.TypeString,
+		Optional:         true,
+		Description:      "The URL to send API requests to. It must be an Events API v2 endpoint. Default is `" + pagerDutyEventsV2URL + "`.",
+		ValidateDiagFunc: validatePagerDutyURL,
 	}
 	return r
 }
 
+const (
+	pagerDutyEventsV2URL = "https://events.pagerduty.com/v2/enqueue"
+	// pagerDutyEventsV1Path is the path of the legacy Events API v1, which takes a different payload
+	pagerDutyEventsV1Path = "/generic/2010-04-15/create_event.json"
+)
+
+var pagerDutySeverities = []string{"critical", "error", "warning", "info"}
+
+// validatePagerDutySeverity checks that the severity is one of the Events API v2 severities. Templates are only checked once rendered, by Grafana.
+func validatePagerDutySeverity(i interface{}, p cty.Path) diag.Diagnostics {
+	severity := i.(string)
+	if strings.Contains(severity, "{{") || slices.Contains(pagerDutySeverities, severity) {
+		return nil
+	}
+	return diag.Diagnostics{{
+		Severity:      diag.Error,
+		Summary:       fmt.Sprintf("invalid PagerDuty severity %q", severity),
+		Detail:        fmt.Sprintf("The severity must be one of %s, or a template.", strings.Join(pagerDutySeverities, ", ")),
+		AttributePath: p,
+	}}
+}
+
+// validatePagerDutyURL rejects the endpoint of the legacy Events API v1, Grafana sends Events API v2 payloads.
+func validatePagerDutyURL(i interface{}, p cty.Path) diag.Diagnostics {
+	u, err := url.Parse(i.(string))
+	if err != nil || !strings.HasSuffix(u.Path, pagerDutyEventsV1Path) {
+		return nil
+	}
+	return diag.Diagnostics{{
+		Severity:      diag.Error,
+		Summary:       "PagerDuty Events API v1 isn't supported",
+		Detail:        fmt.Sprintf("Grafana sends events with the Events API v2. Use an Events API v2 endpoint, like %s, and the integration key of an Events API v2 integration.", pagerDutyEventsV2URL),
+		AttributePath: p,
+	}}
+}
+
 func (n pagerDutyNotifier) pack(p *models.EmbeddedContactPoint, data *schema.ResourceData) (interface{}, error) {
 	notifier := packCommonNotifierFields(p)
 	settings := p.Settings.(map[string]interface{})
@@ -1143,24 +1168,28 @@ func (n pushoverNotifier) schema() *schema.Resource {
 		Description: "The Pushover API token.",
 	}
 	r.Schema["priority"] = &schema.Schema{
-		Type:        schema.TypeInt,
-		Optional:    true,
-		Description: "The priority level of the event.",
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntBetween(-2, 2),
+		Description:  "The priority level of the event, between -2 (lowest) and 2 (emergency).",
 	}
 	r.Schema["ok_priority"] = &schema.Schema{
-		Type:        schema.TypeInt,
-		Optional:    true,
-		Description: "The priority level of the resolved event.",
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntBetween(-2, 2),
+		Description:  "The priority level of the resolved event, between -2 (lowest) and 2 (emergency).",
 	}
 	r.Schema["retry"] = &schema.Schema{
-		Type:        schema.TypeInt,
-		Optional:    true,
-		Description: "How often, in seconds, the Pushover servers will send the same notification to the user.",
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(0),
+		Description:  "How often, in seconds, the Pushover servers will send the same notification to the user.",
 	}
 	r.Schema["expire"] = &schema.Schema{
-		Type:        schema.TypeInt,
-		Optional:    true,
-		Description: "How many seconds for which the notification will continue to be retried by Pushover.",
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(0),
+		Description:  "How many seconds for which the notification will continue to be retried by Pushover.",
 	}
 	r.Schema["device"] = &schema.Schema{
 		Type:        schema.TypeString,
@@ -1206,37 +1235,17 @@ func (n pushoverNotifier) pack(p *models.EmbeddedContactPoint, data *schema.Reso
 		notifier["api_token"] = v.(string)
 		delete(settings, "apiToken")
 	}
-	if v, ok := settings["priority"]; ok && v != nil {
-		priority, err := strconv.Atoi(v.(string))
-		if err != nil {
-			return nil, err
-		}
-		notifier["priority"] = priority
-		delete(settings, "priority")
+	if err := packNotifierIntField(&settings, &notifier, "priority", "priority"); err != nil {
+		return nil, err
 	}
-	if v, ok := settings["okPriority"]; ok && v != nil {
-		priority, err := strconv.Atoi(v.(string))
-		if err != nil {
-			return nil, err
-		}
-		notifier["ok_priority"] = priority
-		delete(settings, "okPriority")
+	if err := packNotifierIntField(&settings, &notifier, "okPriority", "ok_priority"); err != nil {
+		return nil, err
 	}
-	if v, ok := settings["retry"]; ok && v != nil {
-		priority, err := strconv.Atoi(v.(string))
-		if err != nil {
-			return nil, err
-		}
-		notifier["retry"] = priority
-		delete(settings, "retry")
+	if err := packNotifierIntField(&settings, &notifier, "retry", "retry"); err != nil {
+		return nil, err
 	}
-	if v, ok := settings["expire"]; ok && v != nil {
-		priority, err := strconv.Atoi(v.(string))
-		if err != nil {
-			return nil, err
-		}
-		notifier["expire"] = priority
-		delete(settings, "expire")
+	if err := packNotifierIntField(&settings, &notifier, "expire", "expire"); err != nil {
+		return nil, err
 	}
 	if v, ok := settings["device"]; ok && v != nil {
 		notifier["device"] = v.(string)
@@ -1464,15 +1473,17 @@ func (s slackNotifier) schema() *schema.Resource {
 		Description: "A Slack webhook URL,for sending messages via the webhook method.",
 	}
 	r.Schema["token"] = &schema.Schema{
-		Type:        schema.TypeString,
-		Optional:    true,
-		Sensitive:   true,
-		Description: "A Slack API token,for sending messages directly without the webhook method.",
+		Type:             schema.TypeString,
+		Optional:         true,
+		Sensitive:        true,
+		Description:      "A Slack API token,for sending messages directly without the webhook method. Use a bot (`xoxb-`) or user (`xoxp-`) token, app-level (`xapp-`) tokens can't send messages.",
+		ValidateDiagFunc: validateSlackToken,
 	}
 	r.Schema["recipient"] = &schema.Schema{
-		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "Channel, private group, or IM channel (can be an encoded ID or a name) to send messages to.",
+		Type:             schema.TypeString,
+		Optional:         true,
+		Description:      "Channel, private group, or IM channel (can be an encoded ID or a name) to send messages to. Required when using `token`.",
+		ValidateDiagFunc: validateSlackRecipient,
 	}
 	r.Schema["text"] = &schema.Schema{
 		Type:        schema.TypeString,
@@ -1567,6 +1578,63 @@ func (s slackNotifier) unpack(raw interface{}, name string) *models.EmbeddedCont
 	}
 }
 
+var (
+	slackChannelNameRegexp = regexp.MustCompile(`^[#@]?[a-z0-9][a-z0-9._-]*$`)
+	slackIDRegexp          = regexp.MustCompile(`^[CGDUW][A-Z0-9]{8,}$`)
+)
+
+// validateSlackRecipient warns about Slack recipients that are neither a channel or user name nor an ID.
+// Grafana resolves names itself, they only fail when notifications are sent, so malformed recipients aren't rejected.
+func validateSlackRecipient(i interface{}, p cty.Path) diag.Diagnostics {
+	v := i.(string)
+	warn := func(detail string) diag.Diagnostics {
+		return diag.Diagnostics{{
+			Severity:      diag.Warning,
+			Summary:       fmt.Sprintf("Slack recipient %q is likely malformed", v),
+			Detail:        detail,
+			AttributePath: p,
+		}}
+	}
+
+	switch {
+	case v == "" || slackIDRegexp.MatchString(v) || slackChannelNameRegexp.MatchString(v):
+		return nil
+	case strings.HasPrefix(v, "http://") || strings.HasPrefix(v, "https://"):
+		return warn("Webhook URLs must be set in `url`, the recipient is a channel or user name or ID.")
+	case strings.HasPrefix(v, "<"):
+		return warn("Use the channel or user ID (e.g. `C0123456789`) instead of a Slack mention.")
+	case strings.ContainsAny(v, " \t\n"):
+		return warn("Slack channel and user names can't contain whitespace.")
+	default:
+		return warn("Expected a channel or user ID (e.g. `C0123456789`), or a lowercase channel or user name (e.g. `#alerts`).")
+	}
+}
+
+// validateSlackToken rejects app-level tokens, and warns about tokens that aren't bot, user or workspace tokens.
+func validateSlackToken(i interface{}, p cty.Path) diag.Diagnostics {
+	v := i.(string)
+	switch {
+	case v == "":
+		return nil
+	case strings.HasPrefix(v, "xapp-"):
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Slack app-level tokens can't send messages",
+			Detail:        "Use the bot token (`xoxb-`) of the Slack app instead.",
+			AttributePath: p,
+		}}
+	case strings.HasPrefix(v, "xoxb-"), strings.HasPrefix(v, "xoxp-"), strings.HasPrefix(v, "xoxe.xoxp-"), strings.HasPrefix(v, "xoxa-"):
+		return nil
+	default:
+		return diag.Diagnostics{{
+			Severity:      diag.Warning,
+			Summary:       "Unexpected Slack token",
+			Detail:        "Slack API tokens start with `xoxb-` (bot), `xoxp-` (user) or `xoxa-` (workspace). To use a webhook, set `url` instead.",
+			AttributePath: p,
+		}}
+	}
+}
+
 type snsNotifier struct{}
 
 var _ notifier = (*snsNotifier)(nil)
@@ -1695,20 +1763,21 @@ func (t teamsNotifier) meta() notifierMeta {
 func (t teamsNotifier) schema() *schema.Resource {
 	r := commonNotifierResource()
 	r.Schema["url"] = &schema.Schema{
-		Type:        schema.TypeString,
-		Required:    true,
-		Sensitive:   true,
-		Description: "A Teams webhook URL.",
+		Type:             schema.TypeString,
+		Required:         true,
+		Sensitive:        true,
+		Description:      "A Teams webhook URL: the HTTP POST URL of a Workflows flow (e.g. the `Post to a channel when a webhook request is received` template), or the URL of a legacy Office 365 connector webhook. Notifications are sent as adaptive cards.",
+		ValidateDiagFunc: validateTeamsURL,
 	}
 	r.Schema["message"] = &schema.Schema{
 		Type:        schema.TypeString,
 		Optional:    true,
-		Description: "The templated message content to send.",
+		Description: "The templated message content to send, as the body of the adaptive card.",
 	}
 	r.Schema["title"] = &schema.Schema{
 		Type:        schema.TypeString,
 		Optional:    true,
-		Description: "The templated title of the message.",
+		Description: "The templated title of the message, shown at the top of the adaptive card.",
 	}
 	r.Schema["section_title"] = &schema.Schema{
 		Type:        schema.TypeString,
@@ -1751,6 +1820,30 @@ func (t teamsNotifier) unpack(raw interface{}, name string) *models.EmbeddedCont
 	}
 }
 
+// teamsConnectorHosts are the hosts of Office 365 connector webhooks, which Microsoft retires in favor of Workflows.
+var teamsConnectorHosts = []string{"webhook.office.com", "outlook.office.com"}
+
+// validateTeamsURL warns about the Office 365 connector webhooks retired by Microsoft.
+// The URL is sensitive, so it's not part of the message.
+func validateTeamsURL(i interface{}, p cty.Path) diag.Diagnostics {
+	u, err := url.Parse(i.(string))
+	if err != nil {
+		return nil
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, connectorHost := range teamsConnectorHosts {
+		if host == connectorHost || strings.HasSuffix(host, "."+connectorHost) {
+			return diag.Diagnostics{{
+				Severity:      diag.Warning,
+				Summary:       "Office 365 connector webhooks are being retired",
+				Detail:        "Microsoft is retiring the Office 365 connectors of Teams. Create a flow in the Workflows app of the channel and use its HTTP POST URL instead.",
+				AttributePath: p,
+			}}
+		}
+	}
+	return nil
+}
+
 type telegramNotifier struct{}
 
 var _ notifier = (*telegramNotifier)(nil)
@@ -2136,9 +2229,10 @@ func (w webhookNotifier) schema() *schema.Resource {
 		Description: "Allows a custom authorization scheme - attaches an auth header with this value. Do not use in conjunction with basic auth parameters.",
 	}
 	r.Schema["max_alerts"] = &schema.Schema{
-		Type:        schema.TypeInt,
-		Optional:    true,
-		Description: "The maximum number of alerts to send in a single request. This can be helpful in limiting the size of the request body. The default is 0, which indicates no limit.",
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(0),
+		Description:  "The maximum number of alerts to send in a single request. This can be helpful in limiting the size of the request body. The default is 0, which indicates no limit.",
 	}
 	r.Schema["message"] = &schema.Schema{
 		Type:        schema.TypeString,
@@ -2165,22 +2259,8 @@ func (w webhookNotifier) pack(p *models.EmbeddedContactPoint, data *schema.Resou
 	packNotifierStringField(&settings, &notifier, "authorization_credentials", "authorization_credentials")
 	packNotifierStringField(&settings, &notifier, "message", "message")
 	packNotifierStringField(&settings, &notifier, "title", "title")
-	if v, ok := settings["maxAlerts"]; ok && v != nil {
-		switch typ := v.(type) {
-		case int:
-			notifier["max_alerts"] = v.(int)
-		case float64:
-			notifier["max_alerts"] = int(v.(float64))
-		case string:
-			val, err := strconv.Atoi(typ)
-			if err != nil {
-				panic(fmt.Errorf("failed to parse value of 'maxAlerts' to integer: %w", err))
-			}
-			notifier["max_alerts"] = val
-		default:
-			panic(fmt.Sprintf("unexpected type %T for 'maxAlerts': %v", typ, typ))
-		}
-		delete(settings, "maxAlerts")
+	if err := packNotifierIntField(&settings, &notifier, "maxAlerts", "max_alerts"); err != nil {
+		return nil, err
 	}
 
 	packSecureFields(notifier, getNotifierConfigFromStateWithUID(data, w, p.UID), w.meta().secureFields)
@@ -2201,16 +2281,7 @@ func (w webhookNotifier) unpack(raw interface{}, name string) *models.EmbeddedCo
 	unpackNotifierStringField(&json, &settings, "authorization_credentials", "authorization_credentials")
 	unpackNotifierStringField(&json, &settings, "message", "message")
 	unpackNotifierStringField(&json, &settings, "title", "title")
-	if v, ok := json["max_alerts"]; ok && v != nil {
-		switch typ := v.(type) {
-		case int:
-			settings["maxAlerts"] = v.(int)
-		case float64:
-			settings["maxAlerts"] = int(v.(float64))
-		default:
-			panic(fmt.Sprintf("unexpected type for maxAlerts: %v", typ))
-		}
-	}
+	unpackNotifierIntField(&json, &settings, "max_alerts", "maxAlerts")
 
 	return &models.EmbeddedContactPoint{
 		UID:                   uid,
@@ -144,7 +144,7 @@ func listMuteTimings(ctx context.Context, client *goapi.GrafanaHTTPAPI, data *Li
 
 	var ids []string
 	for _, orgID := range orgIDs {
-		client = client.Clone().WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 
 		// Retry if the API returns 500 because it may be that the alertmanager is not ready in the org yet.
 		// The alertmanager is provisioned asynchronously when the org is created.
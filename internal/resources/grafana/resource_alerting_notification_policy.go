@@ -2,7 +2,10 @@ package grafana
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-openapi/runtime"
@@ -34,6 +37,7 @@ This resource requires Grafana 9.1.0 or later.
 		ReadContext:   readNotificationPolicy,
 		UpdateContext: common.WithAlertingMutex[schema.UpdateContextFunc](putNotificationPolicy),
 		DeleteContext: common.WithAlertingMutex[schema.DeleteContextFunc](deleteNotificationPolicy),
+		CustomizeDiff: validateNotificationPolicyContactPoint,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -50,7 +54,7 @@ This resource requires Grafana 9.1.0 or later.
 			"contact_point": {
 				Type:        schema.TypeString,
 				Required:    true,
-				Description: "The default contact point to route all unmatched notifications to.",
+				Description: "The default contact point to route all unmatched notifications to. When it's changed, it must exist in Grafana when planning.",
 			},
 			"group_by": {
 				Type:        schema.TypeList,
@@ -199,7 +203,7 @@ func listNotificationPolicies(ctx context.Context, client *goapi.GrafanaHTTPAPI,
 
 	var ids []string
 	for _, orgID := range orgIDs {
-		client = client.Clone().WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 
 		// Retry if the API returns 500 because it may be that the alertmanager is not ready in the org yet.
 		// The alertmanager is provisioned asynchronously when the org is created.
@@ -271,6 +275,39 @@ func putNotificationPolicy(ctx context.Context, data *schema.ResourceData, meta
 	return readNotificationPolicy(ctx, data, meta)
 }
 
+// validateNotificationPolicyContactPoint is the CustomizeDiff function of grafana_notification_policy.
+// It checks that the default contact point exists when it's changed on an existing policy. New policies aren't checked: they're usually
+// created in the same apply as their contact points, which don't exist yet. Contact points that are only known after apply can't be checked either.
+// Neither are contact points planned in the same run: they are created before the policy.
+func validateNotificationPolicyContactPoint(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("contact_point") || !d.NewValueKnown("contact_point") || !d.NewValueKnown("org_id") {
+		return nil
+	}
+	client, orgID := oapiClientFromResourceDiff(meta, d)
+	if client == nil || contactPointPlanned(meta, orgID, d.Get("contact_point").(string)) {
+		return nil
+	}
+	resp, err := client.Provisioning.GetContactpoints(provisioning.NewGetContactpointsParams())
+	if err != nil {
+		return err
+	}
+	return ValidateNotificationPolicyDefaultReceiver(d.Get("contact_point").(string), resp.Payload)
+}
+
+// ValidateNotificationPolicyDefaultReceiver checks that the root policy's contact point exists.
+func ValidateNotificationPolicyDefaultReceiver(receiver string, contactPoints []*models.EmbeddedContactPoint) error {
+	var names []string
+	for _, p := range contactPoints {
+		if p.Name == receiver {
+			return nil
+		}
+		if !slices.Contains(names, p.Name) {
+			names = append(names, p.Name)
+		}
+	}
+	return fmt.Errorf("the default contact point %q does not exist. Known contact points: %s", receiver, strings.Join(names, ", "))
+}
+
 func deleteNotificationPolicy(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _, _ := OAPIClientFromExistingOrgResource(meta, data.Id())
 
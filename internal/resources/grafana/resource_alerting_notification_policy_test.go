@@ -9,6 +9,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 
 	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/resources/grafana"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
 )
 
@@ -191,6 +193,78 @@ func TestAccNotificationPolicy_error(t *testing.T) {
 	})
 }
 
+func TestAccNotificationPolicy_missingContactPoint(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=9.1.0")
+
+	config := func(contactPoint string, newContactPoint bool) string {
+		config := fmt.Sprintf(`
+		resource "grafana_contact_point" "test" {
+			name = "Policy Contact Point"
+
+			email {
+				addresses = ["one@company.org"]
+			}
+		}
+
+		resource "grafana_notification_policy" "test" {
+			group_by      = ["..."]
+			contact_point = %s
+		}`, contactPoint)
+		if newContactPoint {
+			config += `
+		resource "grafana_contact_point" "new" {
+			name = "New Policy Contact Point"
+
+			email {
+				addresses = ["two@company.org"]
+			}
+		}`
+		}
+		return config
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("grafana_contact_point.test.name", false),
+			},
+			// Changing the contact point of an existing policy is checked while planning
+			{
+				Config:             config(`"invalid"`, false),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+				ExpectError:        regexp.MustCompile(`the default contact point "invalid" does not exist. Known contact points: .*Policy Contact Point`),
+			},
+			// Contact points created in the same run are accepted
+			{
+				Config: config("grafana_contact_point.new.name", true),
+				Check:  resource.TestCheckResourceAttr("grafana_notification_policy.test", "contact_point", "New Policy Contact Point"),
+			},
+		},
+	})
+}
+
+func TestValidateNotificationPolicyDefaultReceiver(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	contactPoints := []*models.EmbeddedContactPoint{
+		{Name: "email", Type: common.Ref("email")},
+		{Name: "team-a", Type: common.Ref("slack")},
+		{Name: "team-a", Type: common.Ref("email")},
+	}
+
+	if err := grafana.ValidateNotificationPolicyDefaultReceiver("team-a", contactPoints); err != nil {
+		t.Errorf("expected no error for an existing contact point, got %v", err)
+	}
+
+	err := grafana.ValidateNotificationPolicyDefaultReceiver("missing", contactPoints)
+	expected := `the default contact point "missing" does not exist. Known contact points: email, team-a`
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected error %q, got %v", expected, err)
+	}
+}
+
 func TestAccNotificationPolicy_inOrg(t *testing.T) {
 	testutils.CheckOSSTestsEnabled(t, ">=9.1.0")
 
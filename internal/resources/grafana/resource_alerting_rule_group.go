@@ -3,9 +3,13 @@ package grafana
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"net/url"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -15,7 +19,9 @@ import (
 	goapi "github.com/grafana/grafana-openapi-client-go/client"
 	"github.com/grafana/grafana-openapi-client-go/client/provisioning"
 	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -43,6 +49,10 @@ This resource requires Grafana 9.1.0 or later.
 		ReadContext:   readAlertRuleGroup,
 		UpdateContext: putAlertRuleGroup,
 		DeleteContext: deleteAlertRuleGroup,
+		CustomizeDiff: customdiff.All(
+			validateRuleUIDsUnique,
+			validateRuleGroupContactPoints,
+		),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -74,6 +84,28 @@ This resource requires Grafana 9.1.0 or later.
 				Default:     false,
 				Description: "Allow modifying the rule group from other sources than Terraform or the Grafana API.",
 			},
+			"default_contact_point": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The contact point to route notifications to, for rules of the group that don't set `notification_settings`. " +
+					"This bypasses the notification policies for all rules of the group. When it's changed, it must exist in Grafana when planning. " +
+					"Available since Grafana 10.4, requires feature flag 'alertingSimplifiedRouting' enabled.",
+			},
+			"is_paused": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Sets whether the rules of the group should be paused or not, for rules that don't set `is_paused` themselves. " +
+					"It's read as true when all the rules of the group are paused, e.g. when importing.",
+			},
+			"validate_queries": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Run the queries and expressions of each rule once before saving the group, and fail if any of them returns an error. " +
+					"This catches invalid queries when applying, instead of the rules ending up in an `Error` state when they're evaluated. " +
+					"Queries are run over the time range covering all the queries of the rule.",
+			},
 			"rule": {
 				Type:        schema.TypeList,
 				Required:    true,
@@ -82,9 +114,12 @@ This resource requires Grafana 9.1.0 or later.
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"uid": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Description: "The unique identifier of the alert rule.",
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+							Description: "The unique identifier of the alert rule. It's automatically generated if not set. " +
+								"Set it to keep a stable identifier, for example to reference the rule in silences.",
+							ValidateFunc: alertRuleUIDValidation,
 						},
 						"name": {
 							Type:        schema.TypeString,
@@ -153,8 +188,9 @@ This resource requires Grafana 9.1.0 or later.
 									},
 									"relative_time_range": {
 										Type:        schema.TypeList,
-										Required:    true,
-										Description: "The time range, relative to when the query is executed, across which to query.",
+										Optional:    true,
+										Computed:    true,
+										Description: "The time range, relative to when the query is executed, across which to query. Either this block or `from` must be set.",
 										MaxItems:    1,
 										Elem: &schema.Resource{
 											Schema: map[string]*schema.Schema{
@@ -171,6 +207,18 @@ This resource requires Grafana 9.1.0 or later.
 											},
 										},
 									},
+									"from": {
+										Type:             schema.TypeString,
+										Optional:         true,
+										Description:      "The start of the time range, as a duration in the past relative to when the rule is evaluated (e.g. `10m` or `now-10m`). Compiled into `relative_time_range`, which it takes precedence over.",
+										ValidateDiagFunc: validateRelativeTime,
+									},
+									"to": {
+										Type:             schema.TypeString,
+										Optional:         true,
+										Description:      "The end of the time range, as a duration in the past relative to when the rule is evaluated (e.g. `5m`), or `now`. Defaults to `now` when `from` is set. Set it to the ingestion delay of the data source (e.g. `1m`) to leave out the most recent data, which may be incomplete.",
+										ValidateDiagFunc: validateRelativeTime,
+									},
 								},
 							},
 						},
@@ -192,11 +240,22 @@ This resource requires Grafana 9.1.0 or later.
 								Type: schema.TypeString,
 							},
 						},
+						"runbook_url": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The URL of the runbook of the alert rule, set as the `runbook_url` annotation. Can't be used along with a `runbook_url` key in `annotations`.",
+						},
 						"is_paused": {
 							Type:        schema.TypeBool,
 							Optional:    true,
 							Default:     false,
-							Description: "Sets whether the alert should be paused or not.",
+							Description: "Sets whether the alert should be paused or not. Overrides the group's `is_paused` when set.",
+						},
+						"simplified_mode": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether the rule is edited with the simplified query and expressions section of the alerting UI. Available since Grafana 11.1.",
 						},
 						"notification_settings": {
 							Type:        schema.TypeList,
@@ -208,7 +267,7 @@ This resource requires Grafana 9.1.0 or later.
 									"contact_point": {
 										Type:        schema.TypeString,
 										Required:    true,
-										Description: "The contact point to route notifications that match this rule to.",
+										Description: "The contact point to route notifications that match this rule to, by name or by the UID of one of its notifiers. When it's added to an existing group, it must exist in Grafana when planning.",
 									},
 									"group_by": {
 										Type:        schema.TypeList,
@@ -266,7 +325,7 @@ func listRuleGroups(ctx context.Context, client *goapi.GrafanaHTTPAPI, data *Lis
 
 	idMap := map[string]bool{}
 	for _, orgID := range orgIDs {
-		client = client.Clone().WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 
 		// Retry if the API returns 500 because it may be that the alertmanager is not ready in the org yet.
 		// The alertmanager is provisioned asynchronously when the org is created.
@@ -296,6 +355,103 @@ func listRuleGroups(ctx context.Context, client *goapi.GrafanaHTTPAPI, data *Lis
 	return ids, nil
 }
 
+var alertRuleUIDValidation = validation.All(
+	validation.StringLenBetween(1, 40),
+	validation.StringMatch(regexp.MustCompile(`^[a-zA-Z0-9\-\_]+$`), "alert rule UIDs can only be alphanumeric, dashes, or underscores"),
+)
+
+func validateRuleUIDsUnique(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	seen := map[string]bool{}
+	for _, rule := range d.Get("rule").([]interface{}) {
+		uid := rule.(map[string]interface{})["uid"].(string)
+		if uid == "" {
+			continue
+		}
+		if seen[uid] {
+			return fmt.Errorf("rule UID %q is used more than once", uid)
+		}
+		seen[uid] = true
+	}
+	return nil
+}
+
+// validateRuleGroupContactPoints checks that the default contact point and the contact points of the rules exist when planning.
+// Only contact points added to an existing group are checked: new groups are usually created along with their contact points.
+// Contact points planned in the same run are accepted, they are created before the group.
+func validateRuleGroupContactPoints(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.NewValueKnown("org_id") {
+		return nil
+	}
+
+	client, orgID := oapiClientFromResourceDiff(meta, d)
+	if client == nil {
+		return nil
+	}
+	var defaultContactPoint string
+	if d.HasChange("default_contact_point") && d.NewValueKnown("default_contact_point") && !contactPointPlanned(meta, orgID, d.Get("default_contact_point").(string)) {
+		defaultContactPoint = d.Get("default_contact_point").(string)
+	}
+	var refs []string
+	for _, ref := range newRuleContactPointReferences(d) {
+		if !contactPointPlanned(meta, orgID, ref) {
+			refs = append(refs, ref)
+		}
+	}
+	if defaultContactPoint == "" && len(refs) == 0 {
+		return nil
+	}
+
+	resp, err := client.Provisioning.GetContactpoints(provisioning.NewGetContactpointsParams())
+	if err != nil {
+		return err
+	}
+	if defaultContactPoint != "" {
+		if err := ValidateNotificationPolicyDefaultReceiver(defaultContactPoint, resp.Payload); err != nil {
+			return err
+		}
+	}
+	for _, ref := range refs {
+		if _, err := ResolveContactPoint(ref, resp.Payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newRuleContactPointReferences returns the known contact points of the rules that weren't already set in the state.
+func newRuleContactPointReferences(d *schema.ResourceDiff) []string {
+	if !d.HasChange("rule") {
+		return nil
+	}
+	rules := d.GetRawConfig().GetAttr("rule")
+	if !rules.IsKnown() || rules.IsNull() {
+		return nil
+	}
+
+	oldRules, _ := d.GetChange("rule")
+	existing := map[string]bool{}
+	for _, rule := range oldRules.([]interface{}) {
+		existing[ruleContactPointReference(rule)] = true
+	}
+	var refs []string
+	for it := rules.ElementIterator(); it.Next(); {
+		_, rule := it.Element()
+		if !rule.IsKnown() || rule.IsNull() {
+			continue
+		}
+		ns := rule.GetAttr("notification_settings")
+		if !ns.IsKnown() || ns.IsNull() || ns.LengthInt() == 0 {
+			continue
+		}
+		ref := ns.Index(cty.NumberIntVal(0)).GetAttr("contact_point")
+		if !ref.IsKnown() || ref.IsNull() || existing[ref.AsString()] {
+			continue
+		}
+		refs = append(refs, ref.AsString())
+	}
+	return refs
+}
+
 func readAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, orgID, idWithoutOrg := OAPIClientFromExistingOrgResource(meta, data.Id())
 
@@ -314,24 +470,74 @@ func readAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta int
 	data.Set("folder_uid", g.FolderUID)
 	data.Set("interval_seconds", g.Interval)
 	disableProvenance := true
+	defaultContactPoint := data.Get("default_contact_point").(string)
+	statePaused := data.Get("is_paused").(bool)
+	var contactPoints []*models.EmbeddedContactPoint
+	stateRules := data.Get("rule").([]interface{})
 	rules := make([]interface{}, 0, len(g.Rules))
-	for _, r := range g.Rules {
-		ruleResp, err := client.Provisioning.GetAlertRule(r.UID) // We need to get the rule through a separate API call to get the provenance.
-		if err != nil {
+	for i, r := range g.Rules {
+		// We need to get the rule through a separate API call to get the provenance.
+		// The editor settings aren't part of the OpenAPI client's model, so the rule is read raw.
+		var ruleResp provisionedAlertRuleWithMetadata
+		if err := getRawJSON(ctx, client, "routeGetAlertRule", "/v1/provisioning/alert-rules/"+url.PathEscape(r.UID), nil, &ruleResp); err != nil {
 			return diag.FromErr(err)
 		}
-		r := ruleResp.Payload
+		r := &ruleResp.ProvisionedAlertRule
 		data.Set("org_id", strconv.FormatInt(*r.OrgID, 10))
 		packed, err := packAlertRule(r)
 		if err != nil {
 			return diag.FromErr(err)
 		}
+		// Grafana versions without editor settings don't return them, keep the configured value in that case
+		simplifiedMode := i < len(stateRules) && stateRules[i].(map[string]interface{})["simplified_mode"].(bool)
+		if ruleResp.Metadata != nil && ruleResp.Metadata.EditorSettings != nil {
+			simplifiedMode = ruleResp.Metadata.EditorSettings.SimplifiedQueryAndExpressionsSection
+		}
+		packed.(map[string]interface{})["simplified_mode"] = simplifiedMode
+		// Queries that set their time range with durations keep them
+		if i < len(stateRules) {
+			keepRuleDataRelativeTimes(packed.(map[string]interface{}), stateRules[i])
+		}
+		// Rules that set the runbook annotation in `annotations` (e.g. created before `runbook_url` existed) keep it there
+		if i >= len(stateRules) || !ruleHasRunbookAnnotation(stateRules[i]) {
+			packRunbookURL(packed.(map[string]interface{}), r.Annotations)
+		}
 		if r.Provenance != "" {
 			disableProvenance = false
 		}
+		// Rules that set their contact point by UID keep it, Grafana returns its name
+		if i < len(stateRules) && r.NotificationSettings != nil && r.NotificationSettings.Receiver != nil {
+			if ref := ruleContactPointReference(stateRules[i]); ref != "" && ref != *r.NotificationSettings.Receiver {
+				if contactPoints == nil {
+					resp, err := client.Provisioning.GetContactpoints(provisioning.NewGetContactpointsParams())
+					if err != nil {
+						return diag.FromErr(err)
+					}
+					contactPoints = resp.Payload
+				}
+				if name, err := ResolveContactPoint(ref, contactPoints); err == nil && name == *r.NotificationSettings.Receiver {
+					packed.(map[string]interface{})["notification_settings"].([]interface{})[0].(map[string]interface{})["contact_point"] = ref
+				}
+			}
+		}
+		// Don't show the notification settings that come from the group's default contact point on rules that didn't set them
+		if i < len(stateRules) && !ruleHasNotificationSettings(stateRules[i]) && isDefaultNotificationSettings(r.NotificationSettings, defaultContactPoint) {
+			delete(packed.(map[string]interface{}), "notification_settings")
+		}
 		rules = append(rules, packed)
 	}
+	groupPaused := RuleGroupPaused(g.Rules, stateRules, statePaused)
+	for i, r := range g.Rules {
+		// Paused rules of a paused group keep their configured value, which is false for rules that use the group's value (and when importing)
+		if groupPaused && r.IsPaused {
+			paused := i < len(stateRules) && stateRules[i].(map[string]interface{})["is_paused"].(bool)
+			rules[i].(map[string]interface{})["is_paused"] = paused
+		}
+	}
+	data.Set("is_paused", groupPaused)
 	data.Set("disable_provenance", disableProvenance)
+	// Not stored in Grafana, it's kept as configured (and set to its default when importing)
+	data.Set("validate_queries", data.Get("validate_queries").(bool))
 	data.Set("rule", rules)
 	data.SetId(resourceRuleGroupID.Make(orgID, folderUID, title))
 
@@ -340,6 +546,36 @@ func readAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta int
 
 func putAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, orgID := OAPIClientFromNewOrgResource(meta, data)
+	// Dashboards read after this change must list the rules again
+	meta.(*common.Client).DashboardAlertRules.Delete(orgID)
+
+	// Contact points set by UID are resolved to their name, which is what rules reference
+	var contactPoints []*models.EmbeddedContactPoint
+	if rulesHaveNotificationSettings(data.Get("rule").([]interface{})) {
+		resp, err := client.Provisioning.GetContactpoints(provisioning.NewGetContactpointsParams())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		contactPoints = resp.Payload
+	}
+	receivers := map[int]string{}
+	for i, rule := range data.Get("rule").([]interface{}) {
+		ref := ruleContactPointReference(rule)
+		if ref == "" {
+			continue
+		}
+		name, err := ResolveContactPoint(ref, contactPoints)
+		if err != nil {
+			return diag.Errorf("rule %q: %s", rule.(map[string]interface{})["name"].(string), err)
+		}
+		receivers[i] = name
+	}
+
+	if data.Get("validate_queries").(bool) {
+		if err := validateRuleGroupQueries(ctx, client, data.Get("rule").([]interface{})); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
 	retryErr := retry.RetryContext(ctx, 2*time.Minute, func() *retry.RetryError {
 		respAlertRules, err := client.Provisioning.GetAlertRules()
@@ -362,6 +598,8 @@ func putAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta inte
 		group := data.Get("name").(string)
 		folder := data.Get("folder_uid").(string)
 		interval := data.Get("interval_seconds").(int)
+		groupPaused := data.Get("is_paused").(bool)
+		defaultContactPoint := data.Get("default_contact_point").(string)
 
 		packedRules := data.Get("rule").([]interface{})
 		rules := make([]*models.ProvisionedAlertRule, 0, len(packedRules))
@@ -371,6 +609,13 @@ func putAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta inte
 			if err != nil {
 				return retry.NonRetryableError(err)
 			}
+			if name, ok := receivers[i]; ok {
+				ruleToApply.NotificationSettings.Receiver = &name
+			}
+			ApplyDefaultContactPoint(ruleToApply, defaultContactPoint)
+			if !ruleSetsIsPaused(data, i) {
+				ruleToApply.IsPaused = groupPaused
+			}
 
 			// Check if a rule with the same name already exists within the same rule group
 			for _, r := range rules {
@@ -420,11 +665,88 @@ func putAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta inte
 		return diag.FromErr(retryErr)
 	}
 
+	if ruleGroupUsesSimplifiedMode(data) {
+		if err := putAlertRulesSimplifiedMode(ctx, client, data); err != nil {
+			// The group is saved, but the previous state is kept so that the next apply sets the editor settings again
+			data.Partial(true)
+			return diag.FromErr(err)
+		}
+	}
+
 	return readAlertRuleGroup(ctx, data, meta)
 }
 
+// provisionedAlertRuleWithMetadata is a provisioned alert rule, with the metadata that the OpenAPI client doesn't support yet.
+type provisionedAlertRuleWithMetadata struct {
+	models.ProvisionedAlertRule
+	Metadata *alertRuleMetadata `json:"metadata,omitempty"`
+}
+
+type alertRuleMetadata struct {
+	EditorSettings *alertRuleEditorSettings `json:"editor_settings,omitempty"`
+}
+
+type alertRuleEditorSettings struct {
+	SimplifiedQueryAndExpressionsSection bool `json:"simplified_query_and_expressions_section"`
+}
+
+// ruleGroupUsesSimplifiedMode returns whether any rule of the group is, or was, in simplified mode.
+// Putting the rule group resets the editor settings, so they have to be set again on every update.
+func ruleGroupUsesSimplifiedMode(data *schema.ResourceData) bool {
+	oldRules, newRules := data.GetChange("rule")
+	for _, rules := range []interface{}{oldRules, newRules} {
+		for _, rule := range rules.([]interface{}) {
+			if r, ok := rule.(map[string]interface{}); ok && r["simplified_mode"] == true {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// putAlertRulesSimplifiedMode sets the editor settings of the rules of the group.
+// They aren't part of the OpenAPI client's rule group model, so each rule is updated raw, with the `metadata` field added.
+// The group is already saved when this is called: all the rules are attempted, and the ones that failed are reported together.
+func putAlertRulesSimplifiedMode(ctx context.Context, client *goapi.GrafanaHTTPAPI, data *schema.ResourceData) error {
+	simplifiedModes := map[string]bool{}
+	for _, rule := range data.Get("rule").([]interface{}) {
+		r := rule.(map[string]interface{})
+		simplifiedModes[r["name"].(string)] = r["simplified_mode"].(bool)
+	}
+
+	var headers map[string]string
+	if data.Get("disable_provenance").(bool) {
+		headers = map[string]string{"X-Disable-Provenance": provenanceDisabled}
+	}
+
+	resp, err := client.Provisioning.GetAlertRuleGroup(data.Get("name").(string), data.Get("folder_uid").(string))
+	if err != nil {
+		return err
+	}
+	var failed []string
+	for _, r := range resp.Payload.Rules {
+		path := "/v1/provisioning/alert-rules/" + url.PathEscape(r.UID)
+		var rule map[string]interface{}
+		err := getRawJSON(ctx, client, "routeGetAlertRule", path, nil, &rule)
+		if err == nil {
+			rule["metadata"] = alertRuleMetadata{
+				EditorSettings: &alertRuleEditorSettings{SimplifiedQueryAndExpressionsSection: simplifiedModes[*r.Title]},
+			}
+			err = doRawJSONWithHeaders(ctx, client, "routePutAlertRule", http.MethodPut, path, headers, nil, rule, nil)
+		}
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%q: %s", *r.Title, err))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("the rule group was saved, but the editor settings of %d of its %d rules couldn't be set: %s", len(failed), len(resp.Payload.Rules), strings.Join(failed, "; "))
+	}
+	return nil
+}
+
 func deleteAlertRuleGroup(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _, idWithoutOrg := OAPIClientFromExistingOrgResource(meta, data.Id())
+	client, orgID, idWithoutOrg := OAPIClientFromExistingOrgResource(meta, data.Id())
+	meta.(*common.Client).DashboardAlertRules.Delete(orgID)
 
 	folderUID, title, found := strings.Cut(idWithoutOrg, common.ResourceIDSeparator)
 	if !found {
@@ -510,6 +832,14 @@ func unpackAlertRule(raw interface{}, groupName string, folderUID string, orgID
 		return nil, err
 	}
 
+	annotations := unpackMap(json["annotations"])
+	if runbookURL := json["runbook_url"].(string); runbookURL != "" {
+		if _, ok := annotations[runbookURLAnnotation]; ok {
+			return nil, fmt.Errorf("rule %q: `runbook_url` can't be used along with a `%s` annotation", json["name"].(string), runbookURLAnnotation)
+		}
+		annotations[runbookURLAnnotation] = runbookURL
+	}
+
 	rule := models.ProvisionedAlertRule{
 		UID:                  json["uid"].(string),
 		Title:                common.Ref(json["name"].(string)),
@@ -522,7 +852,7 @@ func unpackAlertRule(raw interface{}, groupName string, folderUID string, orgID
 		Data:                 data,
 		Condition:            common.Ref(json["condition"].(string)),
 		Labels:               unpackMap(json["labels"]),
-		Annotations:          unpackMap(json["annotations"]),
+		Annotations:          annotations,
 		IsPaused:             json["is_paused"].(bool),
 		NotificationSettings: ns,
 	}
@@ -567,13 +897,25 @@ func unpackRuleData(raw interface{}) ([]*models.AlertQuery, error) {
 			QueryType:     row["query_type"].(string),
 			DatasourceUID: row["datasource_uid"].(string),
 		}
-		if rtr, ok := row["relative_time_range"]; ok {
-			listShim := rtr.([]interface{})
+		from, _ := row["from"].(string)
+		to, _ := row["to"].(string)
+		if from != "" || to != "" {
+			fromSeconds, toSeconds, err := RelativeTimeRangeSeconds(from, to)
+			if err != nil {
+				return nil, fmt.Errorf("query %q: %w", stage.RefID, err)
+			}
+			stage.RelativeTimeRange = &models.RelativeTimeRange{
+				From: models.Duration(fromSeconds),
+				To:   models.Duration(toSeconds),
+			}
+		} else if listShim, ok := row["relative_time_range"].([]interface{}); ok && len(listShim) > 0 && listShim[0] != nil {
 			rtr := listShim[0].(map[string]interface{})
 			stage.RelativeTimeRange = &models.RelativeTimeRange{
 				From: models.Duration(time.Duration(rtr["from"].(int))),
 				To:   models.Duration(time.Duration(rtr["to"].(int))),
 			}
+		} else {
+			return nil, fmt.Errorf("query %q: either `relative_time_range` or `from` must be set", stage.RefID)
 		}
 		var decodedModelJSON interface{}
 		err := json.Unmarshal([]byte(row["model"].(string)), &decodedModelJSON)
@@ -586,6 +928,70 @@ func unpackRuleData(raw interface{}) ([]*models.AlertQuery, error) {
 	return result, nil
 }
 
+// RelativeTimeRangeSeconds compiles the `from` and `to` durations of a query into the seconds of its `relative_time_range`.
+// Durations can be prefixed with `now-`, and `to` defaults to `now`. The range must start before it ends.
+func RelativeTimeRangeSeconds(from, to string) (int64, int64, error) {
+	if from == "" {
+		return 0, 0, errors.New("`from` must be set along with `to`")
+	}
+	fromSeconds, err := parseRelativeTime(from)
+	if err != nil {
+		return 0, 0, err
+	}
+	toSeconds, err := parseRelativeTime(to)
+	if err != nil {
+		return 0, 0, err
+	}
+	if fromSeconds <= toSeconds {
+		return 0, 0, fmt.Errorf("`from` (%s) must be further in the past than `to` (%s)", from, to)
+	}
+	return fromSeconds, toSeconds, nil
+}
+
+// parseRelativeTime returns the number of seconds in the past of a duration like `10m`, `now-10m` or `now`.
+func parseRelativeTime(value string) (int64, error) {
+	if value == "" || value == "now" {
+		return 0, nil
+	}
+	duration, err := strfmt.ParseDuration(strings.TrimPrefix(value, "now-"))
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a valid relative time: %w", value, err)
+	}
+	if duration < 0 {
+		return 0, fmt.Errorf("%q is not a valid relative time: it must be in the past", value)
+	}
+	return int64(duration / time.Second), nil
+}
+
+func validateRelativeTime(i interface{}, _ cty.Path) diag.Diagnostics {
+	if _, err := parseRelativeTime(i.(string)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// keepRuleDataRelativeTimes keeps the `from` and `to` durations of the queries of a rule in the state, as long as they still match the time range read from Grafana.
+func keepRuleDataRelativeTimes(packed map[string]interface{}, stateRule interface{}) {
+	stateData, _ := stateRule.(map[string]interface{})["data"].([]interface{})
+	data, _ := packed["data"].([]interface{})
+	for i := range data {
+		if i >= len(stateData) || stateData[i] == nil {
+			break
+		}
+		query, stateQuery := data[i].(map[string]interface{}), stateData[i].(map[string]interface{})
+		from, _ := stateQuery["from"].(string)
+		to, _ := stateQuery["to"].(string)
+		if query["ref_id"] != stateQuery["ref_id"] || (from == "" && to == "") {
+			continue
+		}
+		timeRange := query["relative_time_range"].([]interface{})[0].(map[string]int)
+		if fromSeconds, toSeconds, err := RelativeTimeRangeSeconds(from, to); err == nil && int(fromSeconds) == timeRange["from"] && int(toSeconds) == timeRange["to"] {
+			query["from"] = from
+			query["to"] = to
+		}
+	}
+}
+
 // normalizeModelJSON is the StateFunc for the `model`. It removes well-known default
 // values from the model json, so that users do not see perma-diffs when not specifying
 // the values explicitly in their Terraform.
@@ -678,6 +1084,120 @@ func packNotificationSettings(settings *models.AlertRuleNotificationSettings) (i
 	return []interface{}{result}, nil
 }
 
+// ApplyDefaultContactPoint routes the rule to the group's default contact point, unless the rule sets its own notification settings.
+func ApplyDefaultContactPoint(rule *models.ProvisionedAlertRule, defaultContactPoint string) {
+	if rule.NotificationSettings != nil || defaultContactPoint == "" {
+		return
+	}
+	rule.NotificationSettings = &models.AlertRuleNotificationSettings{
+		Receiver: common.Ref(defaultContactPoint),
+	}
+}
+
+func isDefaultNotificationSettings(settings *models.AlertRuleNotificationSettings, defaultContactPoint string) bool {
+	return defaultContactPoint != "" && settings != nil &&
+		settings.Receiver != nil && *settings.Receiver == defaultContactPoint &&
+		len(settings.GroupBy) == 0 && len(settings.MuteTimeIntervals) == 0 &&
+		settings.GroupWait == "" && settings.GroupInterval == "" && settings.RepeatInterval == ""
+}
+
+// runbookURLAnnotation is the annotation that the alerting UI shows as the runbook of a rule.
+const runbookURLAnnotation = "runbook_url"
+
+func ruleHasRunbookAnnotation(rule interface{}) bool {
+	annotations, ok := rule.(map[string]interface{})["annotations"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	_, ok = annotations[runbookURLAnnotation]
+	return ok
+}
+
+// packRunbookURL moves the runbook annotation of a rule to its `runbook_url` attribute.
+func packRunbookURL(packed map[string]interface{}, annotations map[string]string) {
+	runbookURL, ok := annotations[runbookURLAnnotation]
+	if !ok {
+		return
+	}
+	withoutRunbook := make(map[string]string, len(annotations))
+	for k, v := range annotations {
+		if k != runbookURLAnnotation {
+			withoutRunbook[k] = v
+		}
+	}
+	packed["annotations"] = withoutRunbook
+	packed["runbook_url"] = runbookURL
+}
+
+// RuleGroupPaused returns the group's `is_paused`, derived from its rules: the group is paused when all its rules are,
+// unless they all set `is_paused` themselves in the state. A group that was paused stays paused as long as any rule is paused
+// by the group: unpaused rules can't be told apart from rules overriding the group's value with false.
+func RuleGroupPaused(rules []*models.ProvisionedAlertRule, stateRules []interface{}, statePaused bool) bool {
+	allPaused, pausedByGroup := len(rules) > 0, false
+	for i, r := range rules {
+		allPaused = allPaused && r.IsPaused
+		if r.IsPaused && (i >= len(stateRules) || !stateRules[i].(map[string]interface{})["is_paused"].(bool)) {
+			pausedByGroup = true
+		}
+	}
+	return pausedByGroup && (allPaused || statePaused)
+}
+
+// ruleSetsIsPaused returns whether the i-th rule of the group sets `is_paused` in the configuration.
+// Rules that don't set it use the group's `is_paused`.
+func ruleSetsIsPaused(data *schema.ResourceData, i int) bool {
+	config := data.GetRawConfig()
+	if !config.IsKnown() || config.IsNull() {
+		return false
+	}
+	rules := config.GetAttr("rule")
+	if !rules.IsKnown() || rules.IsNull() || rules.LengthInt() <= i {
+		return false
+	}
+	rule := rules.Index(cty.NumberIntVal(int64(i)))
+	return rule.IsKnown() && !rule.IsNull() && !rule.GetAttr("is_paused").IsNull()
+}
+
+// ResolveContactPoint returns the name of the contact point referenced by a rule, either by name or by the UID of one of its notifiers.
+// Names take precedence over UIDs.
+func ResolveContactPoint(ref string, contactPoints []*models.EmbeddedContactPoint) (string, error) {
+	for _, p := range contactPoints {
+		if p.Name == ref {
+			return p.Name, nil
+		}
+	}
+	for _, p := range contactPoints {
+		if p.UID == ref {
+			return p.Name, nil
+		}
+	}
+	return "", fmt.Errorf("the contact point %q does not exist, it must be the name of a contact point or the UID of one of its notifiers", ref)
+}
+
+func rulesHaveNotificationSettings(rules []interface{}) bool {
+	for _, rule := range rules {
+		if ruleHasNotificationSettings(rule) {
+			return true
+		}
+	}
+	return false
+}
+
+// ruleContactPointReference returns the contact point of a rule, as set in its `notification_settings`.
+func ruleContactPointReference(rule interface{}) string {
+	if !ruleHasNotificationSettings(rule) {
+		return ""
+	}
+	ns, _ := rule.(map[string]interface{})["notification_settings"].([]interface{})[0].(map[string]interface{})
+	ref, _ := ns["contact_point"].(string)
+	return ref
+}
+
+func ruleHasNotificationSettings(rule interface{}) bool {
+	ns, ok := rule.(map[string]interface{})["notification_settings"].([]interface{})
+	return ok && len(ns) > 0
+}
+
 func unpackNotificationSettings(p interface{}) (*models.AlertRuleNotificationSettings, error) {
 	if p == nil {
 		return nil, nil
@@ -0,0 +1,92 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+)
+
+// dsQueryResponse is the response of the data source query API. Queries that failed have an error in their result.
+// Errors that prevent running the queries at all (e.g. an invalid expression) are returned as a message instead.
+type dsQueryResponse struct {
+	Message string                   `json:"message"`
+	Error   string                   `json:"error"`
+	Results map[string]dsQueryResult `json:"results"`
+}
+
+type dsQueryResult struct {
+	Error string `json:"error"`
+}
+
+// validateRuleGroupQueries runs the queries and expressions of each rule once, through the data source query API.
+// Invalid queries then fail the apply, instead of leaving the rule in an `Error` state once it's evaluated.
+func validateRuleGroupQueries(ctx context.Context, client *goapi.GrafanaHTTPAPI, rules []interface{}) error {
+	for _, r := range rules {
+		rule := r.(map[string]interface{})
+		queries, err := unpackRuleData(rule["data"])
+		if err != nil {
+			return fmt.Errorf("rule %q: %w", rule["name"], err)
+		}
+		if err := validateRuleQueries(ctx, client, queries); err != nil {
+			return fmt.Errorf("rule %q: %w", rule["name"], err)
+		}
+	}
+	return nil
+}
+
+func validateRuleQueries(ctx context.Context, client *goapi.GrafanaHTTPAPI, queries []*models.AlertQuery) error {
+	// The API runs all the queries over the same time range, the one covering the time ranges of all of them
+	from, to := int64(0), int64(math.MaxInt64)
+	body := make([]map[string]interface{}, 0, len(queries))
+	for _, q := range queries {
+		query := map[string]interface{}{}
+		if model, ok := q.Model.(map[string]interface{}); ok {
+			for k, v := range model {
+				query[k] = v
+			}
+		}
+		query["refId"] = q.RefID
+		query["datasource"] = map[string]interface{}{"uid": q.DatasourceUID}
+		if q.QueryType != "" {
+			query["queryType"] = q.QueryType
+		}
+		body = append(body, query)
+
+		if q.RelativeTimeRange != nil {
+			from = max(from, int64(q.RelativeTimeRange.From))
+			to = min(to, int64(q.RelativeTimeRange.To))
+		}
+	}
+	if to == math.MaxInt64 {
+		to = 0
+	}
+
+	var resp dsQueryResponse
+	err := doRawJSON(ctx, client, "queryMetricsWithExpressions", http.MethodPost, "/ds/query", nil, map[string]interface{}{
+		"from":    fmt.Sprintf("now-%ds", from),
+		"to":      fmt.Sprintf("now-%ds", to),
+		"queries": body,
+	}, &resp, http.StatusMultiStatus, http.StatusBadRequest, http.StatusInternalServerError)
+	if err != nil {
+		return fmt.Errorf("failed to run the queries: %w", err)
+	}
+
+	for _, q := range queries {
+		if result, ok := resp.Results[q.RefID]; ok && result.Error != "" {
+			return fmt.Errorf("query %q failed: %s", q.RefID, result.Error)
+		}
+	}
+	if resp.Message != "" {
+		message := resp.Message
+		if resp.Error != "" && !strings.Contains(message, resp.Error) {
+			message += ": " + resp.Error
+		}
+		return fmt.Errorf("the queries failed: %s", message)
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/grafana/grafana-openapi-client-go/models"
@@ -11,6 +12,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/resources/grafana"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
 )
 
@@ -670,10 +673,348 @@ func TestAccAlertRule_NotificationSettings(t *testing.T) {
 					resource.TestCheckResourceAttr("grafana_rule_group.my_rule_group", "rule.0.notification_settings.0.group_by.2", "test"),
 				),
 			},
+			// Contact points added to an existing group are checked when planning
+			{
+				Config: strings.Replace(
+					testAccAlertRuleWithNotificationSettings(name, []string{"alertname", "grafana_folder", "test"}),
+					"grafana_contact_point.my_contact_point.name", `"invalid-contact-point"`, 1,
+				),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`the contact point "invalid-contact-point" does not exist`),
+			},
+			// Contact points created in the same run are accepted
+			{
+				Config: strings.Replace(
+					testAccAlertRuleWithNotificationSettings(name, []string{"alertname", "grafana_folder", "test"}),
+					"contact_point = grafana_contact_point.my_contact_point.name", "contact_point = grafana_contact_point.new_contact_point.name", 1,
+				) + testAccAlertRuleNewContactPoint(name),
+				Check: resource.TestCheckResourceAttr("grafana_rule_group.my_rule_group", "rule.0.notification_settings.0.contact_point", name+"-new-receiver"),
+			},
+			{
+				Config: strings.Replace(
+					testAccAlertRuleWithNotificationSettings(name, []string{"alertname", "grafana_folder", "test"}),
+					"interval_seconds = 60", "interval_seconds = 60\n\tdefault_contact_point = \"invalid-default\"", 1,
+				),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`the default contact point "invalid-default" does not exist`),
+			},
+			{
+				Config: strings.Replace(
+					testAccAlertRuleWithNotificationSettings(name, []string{"alertname", "grafana_folder", "test"}),
+					"interval_seconds = 60", "interval_seconds = 60\n\tdefault_contact_point = grafana_contact_point.new_contact_point.name", 1,
+				) + testAccAlertRuleNewContactPoint(name),
+				Check: resource.TestCheckResourceAttr("grafana_rule_group.my_rule_group", "default_contact_point", name+"-new-receiver"),
+			},
+		},
+	})
+}
+
+func TestAccAlertRule_pinnedUID(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=9.1.0")
+
+	var group models.AlertRuleGroup
+	var name = acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             alertingRuleGroupCheckExists.destroyed(&group, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAlertRuleWithUID(name, name+"-rule", "2m"),
+				Check: resource.ComposeTestCheckFunc(
+					alertingRuleGroupCheckExists.exists("grafana_rule_group.my_rule_group", &group),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_rule_group", "rule.0.uid", name+"-rule"),
+				),
+			},
+			// The UID is kept when the rule is updated
+			{
+				Config: testAccAlertRuleWithUID(name, name+"-rule", "5m"),
+				Check: resource.ComposeTestCheckFunc(
+					alertingRuleGroupCheckExists.exists("grafana_rule_group.my_rule_group", &group),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_rule_group", "rule.0.uid", name+"-rule"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_rule_group", "rule.0.for", "5m0s"),
+					func(s *terraform.State) error {
+						if group.Rules[0].UID != name+"-rule" {
+							return fmt.Errorf("expected the rule UID to be %s-rule, got %s", name, group.Rules[0].UID)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:      testAccAlertRuleWithUID(name, "not a valid uid", "5m"),
+				ExpectError: regexp.MustCompile("alert rule UIDs can only be alphanumeric, dashes, or underscores"),
+			},
+		},
+	})
+}
+
+func TestAccAlertRule_simplifiedMode(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=11.1.0")
+
+	var group models.AlertRuleGroup
+	var name = acctest.RandString(10)
+
+	config := func(simplifiedMode bool) string {
+		return strings.Replace(
+			testAccAlertRuleWithUID(name, name+"-rule", "2m"),
+			`name      = "My Pinned Alert"`,
+			fmt.Sprintf("name      = \"My Pinned Alert\"\n\t\tsimplified_mode = %t", simplifiedMode),
+			1,
+		)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             alertingRuleGroupCheckExists.destroyed(&group, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: config(true),
+				Check: resource.ComposeTestCheckFunc(
+					alertingRuleGroupCheckExists.exists("grafana_rule_group.my_rule_group", &group),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_rule_group", "rule.0.simplified_mode", "true"),
+				),
+			},
+			// The editor settings are kept when the rule is refreshed
+			{
+				Config:   config(true),
+				PlanOnly: true,
+			},
+			// ... and when another field of the rule is updated
+			{
+				Config: strings.Replace(config(true), `for       = "2m"`, `for       = "5m"`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_rule_group.my_rule_group", "rule.0.for", "5m0s"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_rule_group", "rule.0.simplified_mode", "true"),
+				),
+			},
+			{
+				Config: config(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_rule_group.my_rule_group", "rule.0.simplified_mode", "false"),
+				),
+			},
+			{
+				Config:   config(false),
+				PlanOnly: true,
+			},
 		},
 	})
 }
 
+func TestAccAlertRule_runbookURL(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=9.1.0")
+
+	var group models.AlertRuleGroup
+	var name = acctest.RandString(10)
+
+	config := strings.Replace(
+		testAccAlertRuleWithUID(name, name+"-rule", "2m"),
+		`name      = "My Pinned Alert"`,
+		"name      = \"My Pinned Alert\"\n\t\trunbook_url = \"https://runbooks.example.com/my-alert\"",
+		1,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             alertingRuleGroupCheckExists.destroyed(&group, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					alertingRuleGroupCheckExists.exists("grafana_rule_group.my_rule_group", &group),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_rule_group", "rule.0.runbook_url", "https://runbooks.example.com/my-alert"),
+					resource.TestCheckNoResourceAttr("grafana_rule_group.my_rule_group", "rule.0.annotations.runbook_url"),
+					func(s *terraform.State) error {
+						if runbookURL := group.Rules[0].Annotations["runbook_url"]; runbookURL != "https://runbooks.example.com/my-alert" {
+							return fmt.Errorf("expected the runbook_url annotation to be set, got %q", runbookURL)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				Config:      strings.Replace(config, `runbook_url = "https://runbooks.example.com/my-alert"`, `runbook_url = "https://runbooks.example.com/my-alert"`+"\n\t\tannotations = { runbook_url = \"https://other.example.com\" }", 1),
+				ExpectError: regexp.MustCompile("`runbook_url` can't be used along with a `runbook_url` annotation"),
+			},
+		},
+	})
+}
+
+func TestAccAlertRule_groupPaused(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=9.1.0")
+
+	var group models.AlertRuleGroup
+	var name = acctest.RandString(10)
+
+	checkRulesPaused := func(paused bool) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			for _, rule := range group.Rules {
+				if rule.IsPaused != paused {
+					return fmt.Errorf("expected rule %q to have is_paused = %t", *rule.Title, paused)
+				}
+			}
+			return nil
+		}
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             alertingRuleGroupCheckExists.destroyed(&group, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAlertRuleGroupPaused(name, true),
+				Check: resource.ComposeTestCheckFunc(
+					alertingRuleGroupCheckExists.exists("grafana_rule_group.my_rule_group", &group),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_rule_group", "is_paused", "true"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_rule_group", "rule.#", "2"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_rule_group", "rule.0.is_paused", "false"),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_rule_group", "rule.1.is_paused", "false"),
+					checkRulesPaused(true),
+				),
+			},
+			{
+				Config:   testAccAlertRuleGroupPaused(name, true),
+				PlanOnly: true,
+			},
+			// The group is imported as paused, since all its rules are
+			{
+				ResourceName:      "grafana_rule_group.my_rule_group",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccAlertRuleGroupPaused(name, false),
+				Check: resource.ComposeTestCheckFunc(
+					alertingRuleGroupCheckExists.exists("grafana_rule_group.my_rule_group", &group),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_rule_group", "is_paused", "false"),
+					checkRulesPaused(false),
+				),
+			},
+		},
+	})
+}
+
+func TestRuleGroupPaused(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	rules := func(paused ...bool) []*models.ProvisionedAlertRule {
+		result := make([]*models.ProvisionedAlertRule, len(paused))
+		for i, p := range paused {
+			result[i] = &models.ProvisionedAlertRule{IsPaused: p}
+		}
+		return result
+	}
+	stateRules := func(paused ...bool) []interface{} {
+		result := make([]interface{}, len(paused))
+		for i, p := range paused {
+			result[i] = map[string]interface{}{"is_paused": p}
+		}
+		return result
+	}
+
+	for _, tc := range []struct {
+		name        string
+		rules       []*models.ProvisionedAlertRule
+		stateRules  []interface{}
+		statePaused bool
+		expected    bool
+	}{
+		{name: "no rules", expected: false},
+		{name: "imported with all rules paused", rules: rules(true, true), expected: true},
+		{name: "imported with some rules paused", rules: rules(true, false), expected: false},
+		{name: "paused group", rules: rules(true, true), stateRules: stateRules(false, false), statePaused: true, expected: true},
+		{name: "paused group with a rule overriding it", rules: rules(true, false), stateRules: stateRules(false, false), statePaused: true, expected: true},
+		{name: "paused group with all rules unpaused", rules: rules(false, false), stateRules: stateRules(false, false), statePaused: true, expected: false},
+		{name: "rules paused by themselves", rules: rules(true, true), stateRules: stateRules(true, true), expected: false},
+		{name: "rules paused outside of Terraform", rules: rules(true, true), stateRules: stateRules(false, false), expected: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if paused := grafana.RuleGroupPaused(tc.rules, tc.stateRules, tc.statePaused); paused != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, paused)
+			}
+		})
+	}
+}
+
+func testAccAlertRuleGroupPaused(name string, paused bool) string {
+	return fmt.Sprintf(`
+resource "grafana_folder" "rule_folder" {
+	title = "%[1]s"
+}
+
+resource "grafana_rule_group" "my_rule_group" {
+	name             = "%[1]s"
+	folder_uid       = grafana_folder.rule_folder.uid
+	interval_seconds = 60
+	is_paused        = %[2]t
+
+	dynamic "rule" {
+		for_each = ["first", "second"]
+		content {
+			name      = "%[1]s ${rule.value}"
+			condition = "A"
+
+			data {
+				ref_id = "A"
+				relative_time_range {
+					from = 600
+					to   = 0
+				}
+				datasource_uid = "PD8C576611E62080A"
+				model = jsonencode({
+					hide          = false
+					intervalMs    = 1000
+					maxDataPoints = 43200
+					refId         = "A"
+				})
+			}
+		}
+	}
+}
+`, name, paused)
+}
+
+func testAccAlertRuleWithUID(name, uid, forDuration string) string {
+	return fmt.Sprintf(`
+resource "grafana_folder" "rule_folder" {
+	title = "%[1]s"
+}
+
+resource "grafana_rule_group" "my_rule_group" {
+	name             = "%[1]s"
+	folder_uid       = grafana_folder.rule_folder.uid
+	interval_seconds = 60
+
+	rule {
+		uid       = "%[2]s"
+		name      = "My Pinned Alert"
+		condition = "A"
+		for       = "%[3]s"
+
+		data {
+			ref_id = "A"
+			relative_time_range {
+				from = 600
+				to   = 0
+			}
+			datasource_uid = "PD8C576611E62080A"
+			model = jsonencode({
+				hide          = false
+				intervalMs    = 1000
+				maxDataPoints = 43200
+				refId         = "A"
+			})
+		}
+	}
+}
+`, name, uid, forDuration)
+}
+
 func testAccAlertRuleGroupInOrgConfig(name string, interval int, disableProvenance bool) string {
 	return fmt.Sprintf(`
 resource "grafana_organization" "test" {
@@ -718,6 +1059,82 @@ resource "grafana_rule_group" "test" {
 `, name, interval, disableProvenance)
 }
 
+func TestAccAlertRule_validateQueries(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=10.0.0")
+
+	var group models.AlertRuleGroup
+	var name = acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             alertingRuleGroupCheckExists.destroyed(&group, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAlertRuleValidateQueries(name, "$A > 0"),
+				Check: resource.ComposeTestCheckFunc(
+					alertingRuleGroupCheckExists.exists("grafana_rule_group.my_rule_group", &group),
+					resource.TestCheckResourceAttr("grafana_rule_group.my_rule_group", "validate_queries", "true"),
+				),
+			},
+			{
+				ResourceName:            "grafana_rule_group.my_rule_group",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"validate_queries"},
+			},
+			// The expression refers to a query that doesn't exist
+			{
+				Config:      testAccAlertRuleValidateQueries(name, "$B > 0"),
+				ExpectError: regexp.MustCompile(`rule "My Validated Alert": (query "C"|the queries) failed`),
+			},
+		},
+	})
+}
+
+func testAccAlertRuleValidateQueries(name, expression string) string {
+	return fmt.Sprintf(`
+resource "grafana_folder" "rule_folder" {
+	title = "%[1]s"
+}
+
+resource "grafana_data_source" "testdata_datasource" {
+	name = "%[1]s"
+	type = "grafana-testdata-datasource"
+}
+
+resource "grafana_rule_group" "my_rule_group" {
+	name             = "%[1]s"
+	folder_uid       = grafana_folder.rule_folder.uid
+	interval_seconds = 60
+	validate_queries = true
+
+	rule {
+		name      = "My Validated Alert"
+		condition = "C"
+
+		data {
+			ref_id         = "A"
+			from           = "10m"
+			datasource_uid = grafana_data_source.testdata_datasource.uid
+			model = jsonencode({
+				refId      = "A"
+				scenarioId = "random_walk"
+			})
+		}
+		data {
+			ref_id         = "C"
+			from           = "10m"
+			datasource_uid = "__expr__"
+			model = jsonencode({
+				refId      = "C"
+				type       = "math"
+				expression = "%[2]s"
+			})
+		}
+	}
+}`, name, expression)
+}
+
 func testAccAlertRuleZeroSeconds(name string) string {
 	return fmt.Sprintf(`
 resource "grafana_folder" "rule_folder" {
@@ -824,3 +1241,137 @@ resource "grafana_rule_group" "my_rule_group" {
 	}
 }`, name, gr)
 }
+
+func testAccAlertRuleNewContactPoint(name string) string {
+	return fmt.Sprintf(`
+resource "grafana_contact_point" "new_contact_point" {
+	name      = "%[1]s-new-receiver"
+	email {
+		addresses = [ "new@example.com" ]
+	}
+}`, name)
+}
+
+func TestApplyDefaultContactPoint(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	for _, tc := range []struct {
+		name                string
+		settings            *models.AlertRuleNotificationSettings
+		defaultContactPoint string
+		expectedReceiver    string
+	}{
+		{
+			name:             "no default, no settings",
+			expectedReceiver: "",
+		},
+		{
+			name:                "default applies to rules without settings",
+			defaultContactPoint: "default",
+			expectedReceiver:    "default",
+		},
+		{
+			name:                "rule settings take precedence over the default",
+			settings:            &models.AlertRuleNotificationSettings{Receiver: common.Ref("explicit")},
+			defaultContactPoint: "default",
+			expectedReceiver:    "explicit",
+		},
+		{
+			name:             "rule settings without default",
+			settings:         &models.AlertRuleNotificationSettings{Receiver: common.Ref("explicit")},
+			expectedReceiver: "explicit",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rule := &models.ProvisionedAlertRule{NotificationSettings: tc.settings}
+			grafana.ApplyDefaultContactPoint(rule, tc.defaultContactPoint)
+
+			receiver := ""
+			if rule.NotificationSettings != nil {
+				receiver = *rule.NotificationSettings.Receiver
+			}
+			if receiver != tc.expectedReceiver {
+				t.Errorf("expected receiver %q, got %q", tc.expectedReceiver, receiver)
+			}
+		})
+	}
+}
+
+func TestResolveContactPoint(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	contactPoints := []*models.EmbeddedContactPoint{
+		{Name: "team-a", UID: "team-a-email"},
+		{Name: "team-a", UID: "team-a-slack"},
+		{Name: "team-b", UID: "team-b-email"},
+		// A contact point named like the UID of another contact point's notifier
+		{Name: "team-a-email", UID: "other"},
+	}
+
+	for _, tc := range []struct {
+		name          string
+		ref           string
+		expectedName  string
+		expectedError string
+	}{
+		{name: "by name", ref: "team-a", expectedName: "team-a"},
+		{name: "by notifier UID", ref: "team-a-slack", expectedName: "team-a"},
+		{name: "by UID of another notifier", ref: "team-b-email", expectedName: "team-b"},
+		{name: "names take precedence", ref: "team-a-email", expectedName: "team-a-email"},
+		{name: "unknown", ref: "missing", expectedError: `the contact point "missing" does not exist`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			name, err := grafana.ResolveContactPoint(tc.ref, contactPoints)
+			if tc.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+					t.Fatalf("expected error containing %q, got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if name != tc.expectedName {
+				t.Errorf("expected contact point %q, got %q", tc.expectedName, name)
+			}
+		})
+	}
+}
+
+func TestRelativeTimeRangeSeconds(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	for _, tc := range []struct {
+		name          string
+		from, to      string
+		expectedFrom  int64
+		expectedTo    int64
+		expectedError string
+	}{
+		{name: "to defaults to now", from: "10m", expectedFrom: 600},
+		{name: "explicit now", from: "10m", to: "now", expectedFrom: 600},
+		{name: "now prefix", from: "now-1h", to: "now-5m", expectedFrom: 3600, expectedTo: 300},
+		{name: "days", from: "1d", to: "12h", expectedFrom: 86400, expectedTo: 43200},
+		{name: "seconds", from: "90s", to: "30s", expectedFrom: 90, expectedTo: 30},
+		{name: "from after to", from: "5m", to: "10m", expectedError: "must be further in the past"},
+		{name: "empty range", from: "5m", to: "5m", expectedError: "must be further in the past"},
+		{name: "to without from", to: "5m", expectedError: "`from` must be set"},
+		{name: "invalid duration", from: "ten minutes", expectedError: `"ten minutes" is not a valid relative time`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			from, to, err := grafana.RelativeTimeRangeSeconds(tc.from, tc.to)
+			if tc.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+					t.Fatalf("expected error containing %q, got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if from != tc.expectedFrom || to != tc.expectedTo {
+				t.Errorf("expected %d-%d, got %d-%d", tc.expectedFrom, tc.expectedTo, from, to)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package grafana
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"time"
 
@@ -26,6 +27,7 @@ func resourceAnnotation() *common.Resource {
 		UpdateContext: UpdateAnnotation,
 		DeleteContext: DeleteAnnotation,
 		ReadContext:   ReadAnnotation,
+		CustomizeDiff: validateAnnotationTimeRange,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -47,13 +49,19 @@ func resourceAnnotation() *common.Resource {
 			},
 
 			"time_end": {
-				Description:  "The RFC 3339-formatted time string indicating the annotation's end time.",
+				Description:  "The RFC 3339-formatted time string indicating the annotation's end time. Setting it after `time` creates a region annotation, spanning from `time` to `time_end`. If not set, the annotation marks a point in time.",
 				Type:         schema.TypeString,
 				Optional:     true,
 				Computed:     true,
 				ValidateFunc: validation.IsRFC3339Time,
 			},
 
+			"region": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the annotation is a region annotation, spanning from `time` to `time_end`, rather than marking a point in time.",
+			},
+
 			"dashboard_uid": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -95,7 +103,7 @@ func listAnnotations(ctx context.Context, client *goapi.GrafanaHTTPAPI, data *Li
 
 	var ids []string
 	for _, orgID := range orgIDs {
-		client = client.Clone().WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 
 		resp, err := client.Annotations.GetAnnotations(annotations.NewGetAnnotationsParams())
 		if err != nil {
@@ -186,11 +194,44 @@ func ReadAnnotation(ctx context.Context, d *schema.ResourceData, meta interface{
 	d.Set("tags", annotation.Tags)
 	d.Set("time", t.Format(time.RFC3339))
 	d.Set("time_end", tEnd.Format(time.RFC3339))
+	d.Set("region", annotation.TimeEnd > annotation.Time)
 	d.Set("org_id", strconv.FormatInt(orgID, 10))
 
 	return nil
 }
 
+// validateAnnotationTimeRange checks that region annotations end after they start.
+// If `time_end` isn't configured, the annotation marks a point in time and its end follows `time`.
+func validateAnnotationTimeRange(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("time") || !d.NewValueKnown("time_end") {
+		return nil
+	}
+	start, end := d.Get("time").(string), d.Get("time_end").(string)
+
+	if d.GetRawConfig().GetAttr("time_end").IsNull() {
+		if d.HasChange("time") && start != "" {
+			return d.SetNew("time_end", start)
+		}
+		return nil
+	}
+
+	if start == "" || end == "" {
+		return nil
+	}
+	startMillis, err := millisSinceEpoch(start)
+	if err != nil {
+		return err
+	}
+	endMillis, err := millisSinceEpoch(end)
+	if err != nil {
+		return err
+	}
+	if endMillis < startMillis {
+		return fmt.Errorf("`time_end` (%s) must be after `time` (%s)", end, start)
+	}
+	return nil
+}
+
 func DeleteAnnotation(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _, idStr := OAPIClientFromExistingOrgResource(meta, d.Id())
 
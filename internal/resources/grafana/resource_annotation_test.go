@@ -2,13 +2,16 @@ package grafana_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 var (
@@ -164,6 +167,63 @@ func TestAccAnnotation_dashboardUID(t *testing.T) {
 	})
 }
 
+func TestAccAnnotation_region(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=9.0.0")
+
+	var annotation models.Annotation
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             annotationsCheckExists.destroyed(&annotation, nil),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAnnotationConfigRegion("2024-01-01T10:00:00Z", "2024-01-01T09:00:00Z"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`time_end` \\(2024-01-01T09:00:00Z\\) must be after `time` \\(2024-01-01T10:00:00Z\\)"),
+			},
+			{
+				Config: testAnnotationConfigRegion("2024-01-01T10:00:00Z", "2024-01-01T11:00:00Z"),
+				Check: resource.ComposeTestCheckFunc(
+					annotationsCheckExists.exists("grafana_annotation.test", &annotation),
+					resource.TestCheckResourceAttr("grafana_annotation.test", "time", "2024-01-01T10:00:00Z"),
+					resource.TestCheckResourceAttr("grafana_annotation.test", "time_end", "2024-01-01T11:00:00Z"),
+					resource.TestCheckResourceAttr("grafana_annotation.test", "region", "true"),
+					func(s *terraform.State) error {
+						if span := annotation.TimeEnd - annotation.Time; span != time.Hour.Milliseconds() {
+							return fmt.Errorf("expected the annotation to span 1h, got %s", time.Duration(span)*time.Millisecond)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// Extend the region
+				Config: testAnnotationConfigRegion("2024-01-01T10:00:00Z", "2024-01-01T12:00:00Z"),
+				Check: resource.ComposeTestCheckFunc(
+					annotationsCheckExists.exists("grafana_annotation.test", &annotation),
+					resource.TestCheckResourceAttr("grafana_annotation.test", "time_end", "2024-01-01T12:00:00Z"),
+					resource.TestCheckResourceAttr("grafana_annotation.test", "region", "true"),
+				),
+			},
+			{
+				ResourceName:      "grafana_annotation.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Without `time_end`, the annotation marks a point in time
+				Config: testAnnotationConfigPoint("2024-01-02T10:00:00Z"),
+				Check: resource.ComposeTestCheckFunc(
+					annotationsCheckExists.exists("grafana_annotation.test", &annotation),
+					resource.TestCheckResourceAttr("grafana_annotation.test", "time", "2024-01-02T10:00:00Z"),
+					resource.TestCheckResourceAttr("grafana_annotation.test", "time_end", "2024-01-02T10:00:00Z"),
+					resource.TestCheckResourceAttr("grafana_annotation.test", "region", "false"),
+				),
+			},
+		},
+	})
+}
+
 func testAnnotationConfig(text string) string {
 	return fmt.Sprintf(`
 	resource "grafana_annotation" "test" {
@@ -171,6 +231,23 @@ func testAnnotationConfig(text string) string {
 	}`, text)
 }
 
+func testAnnotationConfigRegion(start, end string) string {
+	return fmt.Sprintf(`
+resource "grafana_annotation" "test" {
+  text     = "deployment"
+  time     = "%s"
+  time_end = "%s"
+}`, start, end)
+}
+
+func testAnnotationConfigPoint(t string) string {
+	return fmt.Sprintf(`
+resource "grafana_annotation" "test" {
+  text = "deployment"
+  time = "%s"
+}`, t)
+}
+
 func testAnnotationConfigInOrg(orgName, text string) string {
 	return fmt.Sprintf(`
 resource "grafana_organization" "test" {
@@ -5,7 +5,9 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -34,6 +36,7 @@ Manages Grafana dashboards.
 		ReadContext:   ReadDashboard,
 		UpdateContext: UpdateDashboard,
 		DeleteContext: DeleteDashboard,
+		CustomizeDiff: validateDashboardDatasources,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -66,30 +69,59 @@ Manages Grafana dashboards.
 			"folder": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "The id or UID of the folder to save the dashboard in.",
+				Description: "The id or UID of the folder to save the dashboard in. The title of the folder when `folder_by_title` is set.",
 				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					if d.Get("folder_by_title").(bool) {
+						return old == new
+					}
 					_, old = SplitOrgResourceID(old)
 					_, new = SplitOrgResourceID(new)
 					return old == "0" && new == "" || old == "" && new == "0" || old == new
 				},
 			},
+			"folder_by_title": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Description: "Set to true if `folder` is the title of the folder rather than its UID. The UID is looked up when applying. " +
+					"Applying fails if several folders have this title, use their UID instead.",
+			},
 			"config_json": {
-				Type:         schema.TypeString,
-				Required:     true,
-				StateFunc:    NormalizeDashboardConfigJSON,
-				ValidateFunc: validateDashboardConfigJSON,
-				Description:  "The complete dashboard model JSON.",
+				Type:             schema.TypeString,
+				Required:         true,
+				StateFunc:        NormalizeDashboardConfigJSON,
+				DiffSuppressFunc: SuppressEquivalentDashboardConfigJSON,
+				ValidateFunc:     validateDashboardConfigJSON,
+				Description:      "The complete dashboard model JSON.",
 			},
 			"overwrite": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Description: "Set to true if you want to overwrite existing dashboard with newer version, same dashboard title in folder or same dashboard uid.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Description: "Set to true if you want to overwrite existing dashboard with newer version, same dashboard title in folder or same dashboard uid. " +
+					"When set, the `schemaVersion` set by Grafana is also kept in `config_json` when the configured JSON doesn't have one, so that it shows as a diff.",
 			},
 			"message": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Description: "Set a commit message for the version history.",
 			},
+			"has_alerts": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether alert rules are linked to the dashboard (with the `__dashboardUid__` annotation). Deleting the dashboard breaks the links of these rules.",
+			},
+			"alert_rule_groups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Description: "The IDs of the rule groups (as used by `grafana_rule_group`) with alert rules linked to the dashboard. " +
+					"The alert rules are listed once per organization and Terraform run. Empty if they can't be read.",
+				Elem: &schema.Schema{Type: schema.TypeString},
+			},
+			"validate_data_sources": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Description: "Set to true to check, when planning, that the template variables of the dashboard reference installed data source plugins and existing data sources. " +
+					"References using other variables are not checked.",
+			},
 		},
 		SchemaVersion: 1, // The state upgrader was removed in v2. To upgrade, users can first upgrade to the last v1 release, apply, then upgrade to v2.
 	}
@@ -114,7 +146,7 @@ func listDashboardOrFolder(client *goapi.GrafanaHTTPAPI, data *ListerData, searc
 
 	uids := []string{}
 	for _, orgID := range orgIDs {
-		client = client.Clone().WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 
 		resp, err := client.Search.Search(search.NewSearchParams().WithType(common.Ref(searchType)))
 		if err != nil {
@@ -132,7 +164,7 @@ func listDashboardOrFolder(client *goapi.GrafanaHTTPAPI, data *ListerData, searc
 func CreateDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, orgID := OAPIClientFromNewOrgResource(meta, d)
 
-	dashboard, err := makeDashboard(d)
+	dashboard, err := makeDashboard(client, d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -161,7 +193,21 @@ func ReadDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}
 	d.Set("dashboard_id", int64(model["id"].(float64)))
 	d.Set("version", int64(model["version"].(float64)))
 	d.Set("url", metaClient.GrafanaSubpath(dashboard.Meta.URL))
-	d.Set("folder", dashboard.Meta.FolderUID)
+
+	// Older Grafana versions only return the folder's numeric ID. Without the UID, imported dashboards would be moved to the General folder on the next apply.
+	folderUID := dashboard.Meta.FolderUID
+	if folderUID == "" && dashboard.Meta.FolderID > 0 {
+		folder, err := GetFolderByIDorUID(client.Folders, strconv.FormatInt(dashboard.Meta.FolderID, 10))
+		if err != nil {
+			return diag.Errorf("failed to get folder %d of dashboard %s: %s", dashboard.Meta.FolderID, uid, err)
+		}
+		folderUID = folder.UID
+	}
+	if d.Get("folder_by_title").(bool) {
+		d.Set("folder", dashboard.Meta.FolderTitle)
+	} else {
+		d.Set("folder", folderUID)
+	}
 
 	configJSONBytes, err := json.Marshal(dashboard.Dashboard)
 	if err != nil {
@@ -172,33 +218,19 @@ func ReadDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}
 		return diag.FromErr(err)
 	}
 
-	configJSON := d.Get("config_json").(string)
-
-	// Skip if configJSON string is a sha256 hash
-	// If `uid` is not set in configuration, we need to delete it from the
-	// dashboard JSON we just read from the Grafana API. This is so it does not
-	// create a diff. We can assume the uid was randomly generated by Grafana or
-	// it was removed after dashboard creation. In any case, the user doesn't
-	// care to manage it.
-	if configJSON != "" && !common.SHA256Regexp.MatchString(configJSON) {
-		configuredDashJSON, err := UnmarshalDashboardConfigJSON(configJSON)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		if _, ok := configuredDashJSON["uid"].(string); !ok {
-			delete(remoteDashJSON, "uid")
-		}
+	configJSON, err := ReconcileDashboardConfigJSON(d.Get("config_json").(string), remoteDashJSON, d.Get("overwrite").(bool), defaultDatasourceMatcher(client))
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	configJSON = NormalizeDashboardConfigJSON(remoteDashJSON)
 	d.Set("config_json", configJSON)
 
-	return nil
+	return readDashboardAlertRuleGroups(meta, client, d, orgID, uid)
 }
 
 func UpdateDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, orgID := OAPIClientFromNewOrgResource(meta, d)
 
-	dashboard, err := makeDashboard(d)
+	dashboard, err := makeDashboard(client, d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -219,8 +251,15 @@ func DeleteDashboard(ctx context.Context, d *schema.ResourceData, meta interface
 	return err
 }
 
-func makeDashboard(d *schema.ResourceData) (models.SaveDashboardCommand, error) {
+func makeDashboard(client *goapi.GrafanaHTTPAPI, d *schema.ResourceData) (models.SaveDashboardCommand, error) {
 	_, folderID := SplitOrgResourceID(d.Get("folder").(string))
+	if title := d.Get("folder").(string); d.Get("folder_by_title").(bool) && title != "" {
+		uid, err := folderUIDByTitle(client, title)
+		if err != nil {
+			return models.SaveDashboardCommand{}, err
+		}
+		folderID = uid
+	}
 	dashboard := models.SaveDashboardCommand{
 		Overwrite: d.Get("overwrite").(bool),
 		Message:   d.Get("message").(string),
@@ -237,6 +276,50 @@ func makeDashboard(d *schema.ResourceData) (models.SaveDashboardCommand, error)
 	return dashboard, nil
 }
 
+const (
+	// folderSearchPageSize is the number of folders requested per page when looking up a folder by title.
+	folderSearchPageSize int64 = 1000
+	// folderSearchMaxPages bounds the lookup, for titles that partially match too many folders.
+	folderSearchMaxPages int64 = 10
+)
+
+// folderUIDByTitle returns the UID of the only folder with the given title.
+func folderUIDByTitle(client *goapi.GrafanaHTTPAPI, title string) (string, error) {
+	limit := folderSearchPageSize
+	var candidates []*models.Hit
+	for page := int64(1); ; page++ {
+		if page > folderSearchMaxPages {
+			return "", fmt.Errorf("more than %d folders match the title %q, set `folder` to the UID of the folder instead", folderSearchMaxPages*folderSearchPageSize, title)
+		}
+		params := search.NewSearchParams().WithType(common.Ref("dash-folder")).WithQuery(&title).WithLimit(&limit).WithPage(&page)
+		resp, err := client.Search.Search(params)
+		if err != nil {
+			return "", fmt.Errorf("failed to search for folder %q: %w", title, err)
+		}
+		for _, hit := range resp.Payload {
+			// The search query also matches partial titles
+			if hit.Title == title {
+				candidates = append(candidates, hit)
+			}
+		}
+		if int64(len(resp.Payload)) < limit {
+			break
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return "", fmt.Errorf("no folder found with title %q", title)
+	case 1:
+		return candidates[0].UID, nil
+	}
+	descriptions := make([]string, len(candidates))
+	for i, hit := range candidates {
+		descriptions[i] = fmt.Sprintf("%s (%s)", hit.UID, hit.URL)
+	}
+	return "", fmt.Errorf("%d folders have the title %q, set `folder` to the UID of one of them instead: %s", len(candidates), title, strings.Join(descriptions, ", "))
+}
+
 // UnmarshalDashboardConfigJSON is a convenience func for unmarshalling
 // `config_json` field.
 func UnmarshalDashboardConfigJSON(configJSON string) (map[string]interface{}, error) {
@@ -260,6 +343,50 @@ func validateDashboardConfigJSON(config interface{}, k string) ([]string, []erro
 	return nil, nil
 }
 
+// SuppressEquivalentDashboardConfigJSON is the DiffSuppressFunc for `config_json`. It suppresses the diff if both dashboards
+// are the same once normalized (see NormalizeDashboardConfigJSON), e.g. when only their formatting or the order of their keys differ.
+func SuppressEquivalentDashboardConfigJSON(k, old, new string, d *schema.ResourceData) bool {
+	if old == new {
+		return true
+	}
+	if old == "" || new == "" {
+		return false
+	}
+	return NormalizeDashboardConfigJSON(old) == NormalizeDashboardConfigJSON(new)
+}
+
+// ReconcileDashboardConfigJSON returns the normalized `config_json` of a dashboard read from Grafana, given the `config_json` in state.
+//
+// Grafana sets some fields when they're not in the saved dashboard. If they aren't in the state either, they're removed from the
+// dashboard read from Grafana so that they don't create a diff:
+//
+//   - `uid`: we can assume it was randomly generated by Grafana, or it was removed after the dashboard's creation.
+//     In any case, the user doesn't care to manage it.
+//   - `schemaVersion`: the version of the dashboard model, set by Grafana when the dashboard is migrated. It's kept when
+//     overwrite is set, for users that want their exact JSON enforced.
+//   - `panels[].datasource`: Grafana may set the org's default data source on panels that don't have one. It's removed if it's
+//     the default data source, as checked by isDefaultDatasource.
+//
+// Fields can't be compared if the state only has a SHA256 hash of the config (see StoreDashboardSHA256).
+func ReconcileDashboardConfigJSON(configJSON string, remoteDashJSON map[string]interface{}, overwrite bool, isDefaultDatasource func(string) (bool, error)) (string, error) {
+	if configJSON != "" && !common.SHA256Regexp.MatchString(configJSON) {
+		configuredDashJSON, err := UnmarshalDashboardConfigJSON(configJSON)
+		if err != nil {
+			return "", err
+		}
+		if _, ok := configuredDashJSON["uid"].(string); !ok {
+			delete(remoteDashJSON, "uid")
+		}
+		if _, ok := configuredDashJSON["schemaVersion"]; !ok && !overwrite {
+			delete(remoteDashJSON, "schemaVersion")
+		}
+		if err := removeInjectedPanelDatasources(configuredDashJSON, remoteDashJSON, isDefaultDatasource); err != nil {
+			return "", err
+		}
+	}
+	return NormalizeDashboardConfigJSON(remoteDashJSON), nil
+}
+
 // NormalizeDashboardConfigJSON is the StateFunc for the `config_json` field.
 //
 // It removes the following fields:
@@ -268,6 +395,11 @@ func validateDashboardConfigJSON(config interface{}, k string) ([]string, []erro
 //     creation. We cannot know this before creation and therefore it cannot
 //     be managed in code.
 //   - `version`: is incremented by Grafana each time a dashboard changes.
+//
+// It also sorts the `annotations.list` and `templating.list` arrays by name,
+// since Grafana may reorder them when saving the dashboard.
+//
+// Panels are normalized too, including the panels nested in collapsed rows (see normalizeDashboardPanels).
 func NormalizeDashboardConfigJSON(config interface{}) string {
 	var dashboardJSON map[string]interface{}
 	switch c := config.(type) {
@@ -283,24 +415,15 @@ func NormalizeDashboardConfigJSON(config interface{}) string {
 
 	delete(dashboardJSON, "id")
 	delete(dashboardJSON, "version")
+	removeDashboardDefaults(dashboardJSON)
 
-	// similarly to uid removal above, remove any attributes panels[].libraryPanel.*
-	// from the dashboard JSON other than "name" or "uid".
-	// Grafana will populate all other libraryPanel attributes, so delete them to avoid diff.
 	if panels, ok := dashboardJSON["panels"].([]interface{}); ok {
-		for _, panel := range panels {
-			panelMap := panel.(map[string]interface{})
-			delete(panelMap, "id")
-			if libraryPanel, ok := panelMap["libraryPanel"].(map[string]interface{}); ok {
-				for k := range libraryPanel {
-					if k != "name" && k != "uid" {
-						delete(libraryPanel, k)
-					}
-				}
-			}
-		}
+		normalizeDashboardPanels(panels)
 	}
 
+	sortDashboardListByName(dashboardJSON, "annotations")
+	sortDashboardListByName(dashboardJSON, "templating")
+
 	j, _ := json.Marshal(dashboardJSON)
 
 	if StoreDashboardSHA256 {
@@ -310,3 +433,91 @@ func NormalizeDashboardConfigJSON(config interface{}) string {
 		return string(j)
 	}
 }
+
+// normalizeDashboardPanels removes the panel attributes that Grafana sets when saving a dashboard.
+// Collapsed rows hold their panels in their own `panels` list, which is normalized the same way.
+func normalizeDashboardPanels(panels []interface{}) {
+	for _, panel := range panels {
+		panelMap, ok := panel.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		delete(panelMap, "id")
+
+		// similarly to uid removal above, remove any attributes panels[].libraryPanel.*
+		// from the dashboard JSON other than "name" or "uid".
+		// Grafana will populate all other libraryPanel attributes, so delete them to avoid diff.
+		if libraryPanel, ok := panelMap["libraryPanel"].(map[string]interface{}); ok {
+			for k := range libraryPanel {
+				if k != "name" && k != "uid" {
+					delete(libraryPanel, k)
+				}
+			}
+		}
+
+		if panelMap["type"] != "row" {
+			continue
+		}
+		// Grafana adds an empty `panels` list and `collapsed: false` to expanded rows, whose panels follow them in the dashboard
+		if collapsed, ok := panelMap["collapsed"].(bool); ok && !collapsed {
+			delete(panelMap, "collapsed")
+		}
+		if rowPanels, ok := panelMap["panels"].([]interface{}); ok {
+			if len(rowPanels) == 0 {
+				delete(panelMap, "panels")
+			} else {
+				normalizeDashboardPanels(rowPanels)
+			}
+		}
+	}
+}
+
+// dashboardDefaults are the values that Grafana fills in for dashboard settings that aren't set.
+// Settings with their default value are removed, so that a dashboard that doesn't set them has no diff.
+var dashboardDefaults = map[string]interface{}{
+	"editable":             true,
+	"fiscalYearStartMonth": float64(0),
+	"graphTooltip":         float64(0), // Default crosshair/tooltip behavior, not shared between panels
+	"timezone":             "",
+	"weekStart":            "",
+}
+
+func removeDashboardDefaults(dashboardJSON map[string]interface{}) {
+	for k, defaultValue := range dashboardDefaults {
+		v, ok := dashboardJSON[k]
+		if !ok {
+			continue
+		}
+		if i, ok := v.(int); ok {
+			v = float64(i)
+		}
+		if v == nil || v == defaultValue {
+			delete(dashboardJSON, k)
+		}
+	}
+}
+
+// sortDashboardListByName sorts the `<key>.list` array of the dashboard JSON by the `name` of its items.
+// Items without a name keep their relative order.
+func sortDashboardListByName(dashboardJSON map[string]interface{}, key string) {
+	container, ok := dashboardJSON[key].(map[string]interface{})
+	if !ok {
+		return
+	}
+	list, ok := container["list"].([]interface{})
+	if !ok {
+		return
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return dashboardListItemName(list[i]) < dashboardListItemName(list[j])
+	})
+}
+
+func dashboardListItemName(item interface{}) string {
+	if itemMap, ok := item.(map[string]interface{}); ok {
+		if name, ok := itemMap["name"].(string); ok {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,79 @@
+package grafana
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+
+	"github.com/go-openapi/runtime"
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+)
+
+// dashboardUIDAnnotation is the annotation that links an alert rule to a dashboard (and its panel, with `__panelId__`).
+const dashboardUIDAnnotation = "__dashboardUid__"
+
+// dashboardAlertRules is the listing of the alert rules of an organization, shared by the reads of its dashboards.
+type dashboardAlertRules struct {
+	once  sync.Once
+	rules []*models.ProvisionedAlertRule
+	err   error
+}
+
+// readDashboardAlertRuleGroups sets the `has_alerts` and `alert_rule_groups` attributes of a dashboard.
+// The alert rules are listed once per organization. If they can't be read (e.g. the user isn't allowed to, or Grafana has no provisioning API),
+// dashboards can still be managed: the attributes are then left empty.
+func readDashboardAlertRuleGroups(meta interface{}, client *goapi.GrafanaHTTPAPI, d *schema.ResourceData, orgID int64, dashboardUID string) diag.Diagnostics {
+	cached, _ := meta.(*common.Client).DashboardAlertRules.LoadOrStore(orgID, &dashboardAlertRules{})
+	lookup := cached.(*dashboardAlertRules)
+	lookup.once.Do(func() {
+		resp, err := client.Provisioning.GetAlertRules()
+		if err != nil {
+			lookup.err = err
+			return
+		}
+		lookup.rules = resp.Payload
+	})
+
+	if err := lookup.err; err != nil {
+		d.Set("has_alerts", false)
+		d.Set("alert_rule_groups", []string{})
+		if err, ok := err.(runtime.ClientResponseStatus); ok && (err.IsCode(403) || err.IsCode(404)) {
+			log.Printf("[WARN] can't check the alert rules of dashboard %s: %s", dashboardUID, err)
+			return nil
+		}
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Failed to read the alert rules linked to dashboard %s", dashboardUID),
+			Detail:   err.Error(),
+		}}
+	}
+
+	groups := DashboardAlertRuleGroups(orgID, dashboardUID, lookup.rules)
+	d.Set("has_alerts", len(groups) > 0)
+	d.Set("alert_rule_groups", groups)
+	return nil
+}
+
+// DashboardAlertRuleGroups returns the IDs (as used by `grafana_rule_group`) of the rule groups with rules linked to the given dashboard.
+func DashboardAlertRuleGroups(orgID int64, dashboardUID string, rules []*models.ProvisionedAlertRule) []string {
+	seen := map[string]bool{}
+	groups := []string{}
+	for _, rule := range rules {
+		if rule == nil || rule.Annotations[dashboardUIDAnnotation] != dashboardUID || rule.FolderUID == nil || rule.RuleGroup == nil {
+			continue
+		}
+		id := resourceRuleGroupID.Make(orgID, *rule.FolderUID, *rule.RuleGroup)
+		if !seen[id] {
+			seen[id] = true
+			groups = append(groups, id)
+		}
+	}
+	sort.Strings(groups)
+	return groups
+}
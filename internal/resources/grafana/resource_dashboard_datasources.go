@@ -0,0 +1,226 @@
+package grafana
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/go-openapi/runtime"
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/datasources"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Data source UIDs that are built into Grafana and can't be looked up through the API.
+var builtinDatasourceUIDs = map[string]bool{
+	"grafana":         true,
+	"-- Grafana --":   true,
+	"-- Mixed --":     true,
+	"-- Dashboard --": true,
+}
+
+// validateDashboardDatasources is the CustomizeDiff function of grafana_dashboard.
+// When `validate_data_sources` is set, it checks the data source references of the dashboard's template variables.
+func validateDashboardDatasources(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("validate_data_sources").(bool) {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChange("config_json") && !d.HasChange("validate_data_sources") {
+		return nil
+	}
+
+	// The config is read raw, the state value may be a SHA256 hash (see StoreDashboardSHA256)
+	rawConfig := d.GetRawConfig().GetAttr("config_json")
+	if !rawConfig.IsKnown() || rawConfig.IsNull() {
+		return nil
+	}
+	dashboard, err := UnmarshalDashboardConfigJSON(rawConfig.AsString())
+	if err != nil {
+		return nil // Reported by the ValidateFunc
+	}
+
+	client, orgID := oapiClientFromResourceDiff(meta, d)
+	if client == nil {
+		return nil
+	}
+	cache := &meta.(*common.Client).DashboardDatasources
+
+	pluginExists := func(pluginID string) (bool, error) {
+		return cachedDatasourceLookup(cache, fmt.Sprintf("%d/plugin/%s", orgID, pluginID), func() (bool, error) {
+			err := getRawJSON(ctx, client, "getPluginSettingsByID", "/plugins/"+url.PathEscape(pluginID)+"/settings", nil, nil)
+			return err == nil, err
+		})
+	}
+	datasourceExists := func(ref string) (bool, error) {
+		return cachedDatasourceLookup(cache, fmt.Sprintf("%d/datasource/%s", orgID, ref), func() (bool, error) {
+			return lookupDashboardDatasource(client, ref)
+		})
+	}
+
+	return ValidateDashboardDatasources(dashboard, pluginExists, datasourceExists)
+}
+
+// cachedDatasourceLookup calls lookup, unless a previous lookup of the same key found the data source or plugin.
+// Missing ones aren't cached: they may be created during the apply, before the dashboards are validated again.
+func cachedDatasourceLookup(cache *sync.Map, key string, lookup func() (bool, error)) (bool, error) {
+	if _, ok := cache.Load(key); ok {
+		return true, nil
+	}
+	found, err := lookup()
+	if err != nil && !common.IsNotFoundError(err) {
+		return false, err
+	}
+	if found {
+		cache.Store(key, true)
+	}
+	return found, nil
+}
+
+// lookupDashboardDatasource finds a data source by UID. Older dashboards reference data sources by name, so the name is tried next.
+func lookupDashboardDatasource(client *goapi.GrafanaHTTPAPI, ref string) (bool, error) {
+	_, err := client.Datasources.GetDataSourceByUID(ref)
+	if err == nil || !common.IsNotFoundError(err) {
+		return err == nil, err
+	}
+	_, err = client.Datasources.GetDataSourceByName(ref)
+	return err == nil, err
+}
+
+// ValidateDashboardDatasources checks the template variables of a dashboard model:
+//   - `datasource` variables must use the type of an installed data source plugin
+//   - `query` variables must use an existing data source
+//
+// References using other variables (e.g. `${ds}`) can't be resolved and are ignored.
+func ValidateDashboardDatasources(dashboard map[string]interface{}, pluginExists, datasourceExists func(string) (bool, error)) error {
+	templating, ok := dashboard["templating"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	list, ok := templating["list"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var errs []error
+	for _, item := range list {
+		variable, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := variable["name"].(string)
+
+		switch variable["type"] {
+		case "datasource":
+			pluginID, _ := variable["query"].(string)
+			if pluginID == "" || strings.Contains(pluginID, "$") {
+				continue
+			}
+			found, err := pluginExists(pluginID)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("template variable %q: failed to check data source plugin %q: %w", name, pluginID, err))
+			} else if !found {
+				errs = append(errs, fmt.Errorf("template variable %q: data source plugin %q is not installed", name, pluginID))
+			}
+		case "query":
+			ref := dashboardDatasourceRef(variable["datasource"])
+			if ref == "" || strings.Contains(ref, "$") || builtinDatasourceUIDs[ref] {
+				continue
+			}
+			found, err := datasourceExists(ref)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("template variable %q: failed to check data source %q: %w", name, ref, err))
+			} else if !found {
+				errs = append(errs, fmt.Errorf("template variable %q: data source %q does not exist", name, ref))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// dashboardDatasourceRef returns the data source of a template variable or panel.
+// It's either a `{"type": ..., "uid": ...}` object, or a UID or name in older dashboards.
+func dashboardDatasourceRef(datasource interface{}) string {
+	switch ds := datasource.(type) {
+	case string:
+		return ds
+	case map[string]interface{}:
+		uid, _ := ds["uid"].(string)
+		return uid
+	}
+	return ""
+}
+
+// defaultDatasourceMatcher returns a function checking whether a data source reference (UID or name) is the org's default data source.
+// The data sources are only listed on the first call. Users that can't list data sources can still manage dashboards, no reference matches then.
+func defaultDatasourceMatcher(client *goapi.GrafanaHTTPAPI) func(string) (bool, error) {
+	var defaultDatasource *models.DataSourceListItemDTO
+	listed := false
+	return func(ref string) (bool, error) {
+		if !listed {
+			resp, err := client.Datasources.GetDataSources()
+			if err != nil {
+				if apiErr, ok := err.(runtime.ClientResponseStatus); ok && apiErr.IsCode(403) {
+					log.Printf("[WARN] can't look up the default data source, the user isn't allowed to list data sources")
+					resp = &datasources.GetDataSourcesOK{}
+				} else {
+					return false, err
+				}
+			}
+			listed = true
+			for _, ds := range resp.Payload {
+				if ds.IsDefault {
+					defaultDatasource = ds
+				}
+			}
+		}
+		return defaultDatasource != nil && (ref == defaultDatasource.UID || ref == defaultDatasource.Name), nil
+	}
+}
+
+// removeInjectedPanelDatasources removes the data source that Grafana sets when saving panels that don't configure one, if it's the org's
+// default data source. The configured and remote panels are matched by position, the panels of rows are handled too.
+func removeInjectedPanelDatasources(configured, remote map[string]interface{}, isDefaultDatasource func(string) (bool, error)) error {
+	configuredPanels, _ := configured["panels"].([]interface{})
+	remotePanels, _ := remote["panels"].([]interface{})
+	for i := 0; i < len(configuredPanels) && i < len(remotePanels); i++ {
+		configuredPanel, ok := configuredPanels[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		remotePanel, ok := remotePanels[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if err := removeInjectedPanelDatasources(configuredPanel, remotePanel, isDefaultDatasource); err != nil {
+			return err
+		}
+
+		datasource, ok := remotePanel["datasource"]
+		if _, configuredOk := configuredPanel["datasource"]; configuredOk || !ok {
+			continue
+		}
+		if datasource == nil {
+			delete(remotePanel, "datasource")
+			continue
+		}
+		ref := dashboardDatasourceRef(datasource)
+		if ref == "" {
+			continue
+		}
+		isDefault, err := isDefaultDatasource(ref)
+		if err != nil {
+			return fmt.Errorf("failed to check the data source %q of panel %v: %w", ref, remotePanel["title"], err)
+		}
+		if isDefault {
+			delete(remotePanel, "datasource")
+		}
+	}
+	return nil
+}
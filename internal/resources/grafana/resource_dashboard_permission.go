@@ -8,9 +8,10 @@ import (
 
 func resourceDashboardPermission() *common.Resource {
 	crudHelper := &resourcePermissionsHelper{
-		resourceType:  dashboardsPermissionsType,
-		roleAttribute: "role",
-		getResource:   resourceDashboardPermissionGet,
+		resourceType:            dashboardsPermissionsType,
+		roleAttribute:           "role",
+		serviceAccountAttribute: true,
+		getResource:             resourceDashboardPermissionGet,
 	}
 
 	schema := &schema.Resource{
@@ -24,6 +25,7 @@ Manages the entire set of permissions for a dashboard. Permissions that aren't s
 		ReadContext:   crudHelper.readPermissions,
 		UpdateContext: crudHelper.updatePermissions,
 		DeleteContext: crudHelper.deletePermissions,
+		CustomizeDiff: crudHelper.validatePermissions,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -2,6 +2,7 @@ package grafana_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/grafana/grafana-openapi-client-go/models"
@@ -76,6 +77,49 @@ func TestAccDashboardPermission_basic(t *testing.T) {
 	})
 }
 
+func TestAccDashboardPermission_serviceAccount(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=9.0.0")
+
+	randomName := acctest.RandString(6)
+	var (
+		dashboard models.DashboardFullWithMeta
+		sa        models.ServiceAccountDTO
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDashboardPermissionConfigServiceAccount(randomName, `
+				permissions {
+					user_id            = grafana_user.test.id
+					service_account_id = grafana_service_account.test.id
+					permission         = "Edit"
+				}`),
+				ExpectError: regexp.MustCompile(`each permission item must set exactly one of user_id, team_id, service_account_id, role, found 2 in a "Edit" permission item`),
+			},
+			{
+				Config: testAccDashboardPermissionConfigServiceAccount(randomName, `
+				permissions {
+					service_account_id = grafana_service_account.test.id
+					permission         = "Edit"
+				}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					dashboardCheckExists.exists("grafana_dashboard.test", &dashboard),
+					serviceAccountCheckExists.exists("grafana_service_account.test", &sa),
+					resource.TestCheckResourceAttr("grafana_dashboard_permission.test", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("grafana_dashboard_permission.test", "permissions.0.permission", "Edit"),
+					resource.TestCheckResourceAttr("grafana_dashboard_permission.test", "permissions.0.user_id", "0"),
+					resource.TestCheckResourceAttrPair("grafana_dashboard_permission.test", "permissions.0.service_account_id", "grafana_service_account.test", "id"),
+					func(s *terraform.State) error {
+						return checkDashboardPermissions(&dashboard, []*models.DashboardACLInfoDTO{{UserID: sa.ID, PermissionName: "Edit"}}, false)
+					},
+				),
+			},
+		},
+	})
+}
+
 func checkDashboardPermissionsSet(dashboard *models.DashboardFullWithMeta, team *models.TeamDTO, user *models.UserProfileDTO, sa *models.ServiceAccountDTO, expectAdminPerm bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		expectedPerms := []*models.DashboardACLInfoDTO{
@@ -209,3 +253,30 @@ resource "grafana_dashboard_permission" "testPermission" {
 }
 `, name, perms)
 }
+
+func testAccDashboardPermissionConfigServiceAccount(name, permissions string) string {
+	return fmt.Sprintf(`
+resource "grafana_dashboard" "test" {
+  config_json = jsonencode({
+    title = "%[1]s"
+    uid   = "%[1]s"
+  })
+}
+
+resource "grafana_user" "test" {
+  email    = "%[1]s@localhost"
+  login    = "%[1]s"
+  password = "zyx987"
+}
+
+resource "grafana_service_account" "test" {
+  name = "%[1]s"
+  role = "Viewer"
+}
+
+resource "grafana_dashboard_permission" "test" {
+  dashboard_uid = grafana_dashboard.test.uid
+  %[2]s
+}
+`, name, permissions)
+}
@@ -0,0 +1,194 @@
+package grafana
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/grafana/grafana-openapi-client-go/client/snapshots"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceDashboardSnapshot() *common.Resource {
+	schema := &schema.Resource{
+
+		Description: `
+Manages Grafana dashboard snapshots. Snapshots are point-in-time copies of a dashboard, that can be shared without giving access to the dashboard.
+
+Snapshots can't be modified: any change recreates the snapshot.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/share-dashboards-panels/#publish-a-snapshot)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/snapshot/)
+`,
+
+		CreateContext: CreateDashboardSnapshot,
+		ReadContext:   ReadDashboardSnapshot,
+		DeleteContext: DeleteDashboardSnapshot,
+
+		Schema: map[string]*schema.Schema{
+			"org_id": orgIDAttribute(),
+			"config_json": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				StateFunc:    NormalizeDashboardConfigJSON,
+				ValidateFunc: validateDashboardConfigJSON,
+				Description:  "The dashboard model JSON to take a snapshot of, including the data to show in the panels.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The name of the snapshot. If not set, Grafana's default name is used.",
+			},
+			"expires": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The number of seconds after which the snapshot expires. `0` means the snapshot never expires.",
+			},
+			"key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The key of the snapshot, used to access it.",
+			},
+			"delete_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The key used to delete the snapshot.",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The public URL of the snapshot.",
+			},
+		},
+	}
+
+	return common.NewLegacySDKResource(
+		common.CategoryGrafanaOSS,
+		"grafana_dashboard_snapshot",
+		nil,
+		schema,
+	)
+}
+
+// dashboardSnapshotNeverExpires is how long Grafana keeps snapshots that are created with `expires = 0`.
+const dashboardSnapshotNeverExpires = 50 * 365 * 24 * time.Hour
+
+func CreateDashboardSnapshot(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, orgID := OAPIClientFromNewOrgResource(meta, d)
+
+	dashboard, err := UnmarshalDashboardConfigJSON(d.Get("config_json").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body := map[string]interface{}{
+		"dashboard": dashboard,
+		"expires":   d.Get("expires").(int),
+	}
+	if name := d.Get("name").(string); name != "" {
+		body["name"] = name
+	}
+
+	resp, err := client.Snapshots.CreateDashboardSnapshot(nil, withDashboardSnapshotBody(body))
+	if err != nil {
+		return diag.Errorf("failed to create dashboard snapshot: %s", err)
+	}
+	snapshot := resp.GetPayload()
+
+	d.SetId(MakeOrgResourceID(orgID, snapshot.Key))
+	d.Set("delete_key", snapshot.DeleteKey)
+	d.Set("url", snapshot.URL)
+
+	return ReadDashboardSnapshot(ctx, d, meta)
+}
+
+func ReadDashboardSnapshot(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, orgID, key := OAPIClientFromExistingOrgResource(meta, d.Id())
+
+	// Expired snapshots are deleted by Grafana.
+	var snapshot models.DashboardFullWithMeta
+	_, err := client.Snapshots.GetDashboardSnapshot(key, withDashboardSnapshotResult(&snapshot))
+	if err, shouldReturn := common.CheckReadError("dashboard snapshot", d, err); shouldReturn {
+		return err
+	}
+
+	// The name is only returned when listing snapshots.
+	params := snapshots.NewSearchDashboardSnapshotsParams()
+	if name := d.Get("name").(string); name != "" {
+		params.SetQuery(&name)
+	}
+	resp, err := client.Snapshots.SearchDashboardSnapshots(params)
+	if err != nil {
+		return diag.Errorf("failed to search dashboard snapshots: %s", err)
+	}
+	for _, s := range resp.GetPayload() {
+		if s.Key == key {
+			d.Set("name", s.Name)
+			break
+		}
+	}
+
+	d.Set("org_id", strconv.FormatInt(orgID, 10))
+	d.Set("key", key)
+	if dashboard, ok := snapshot.Dashboard.(map[string]interface{}); ok {
+		d.Set("config_json", NormalizeDashboardConfigJSON(dashboard))
+	}
+	if snapshot.Meta != nil {
+		// Grafana only stores the expiry date, the duration is rounded to absorb the time it took to create the snapshot.
+		expires := time.Time(snapshot.Meta.Expires).Sub(time.Time(snapshot.Meta.Created)).Round(time.Second)
+		if expires >= dashboardSnapshotNeverExpires {
+			expires = 0
+		}
+		d.Set("expires", int(expires.Seconds()))
+	}
+
+	return nil
+}
+
+func DeleteDashboardSnapshot(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _, _ := OAPIClientFromExistingOrgResource(meta, d.Id())
+
+	_, err := client.Snapshots.DeleteDashboardSnapshotByDeleteKey(d.Get("delete_key").(string))
+	diag, _ := common.CheckReadError("dashboard snapshot", d, err)
+	return diag
+}
+
+// withDashboardSnapshotBody sends body as the request body of createDashboardSnapshot.
+// The OpenAPI model of the command wraps the dashboard in an `Object` field, which Grafana would store as the dashboard.
+func withDashboardSnapshotBody(body interface{}) snapshots.ClientOption {
+	return func(op *runtime.ClientOperation) {
+		op.Params = runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, _ strfmt.Registry) error {
+			return r.SetBodyParam(body)
+		})
+	}
+}
+
+// withDashboardSnapshotResult decodes the response of getDashboardSnapshot into result.
+// The OpenAPI client discards the response body of that endpoint.
+func withDashboardSnapshotResult(result *models.DashboardFullWithMeta) snapshots.ClientOption {
+	return func(op *runtime.ClientOperation) {
+		reader := op.Reader
+		op.Reader = runtime.ClientResponseReaderFunc(func(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+			if response.Code() != http.StatusOK {
+				return reader.ReadResponse(response, consumer)
+			}
+			if err := consumer.Consume(response.Body(), result); err != nil {
+				return nil, err
+			}
+			return snapshots.NewGetDashboardSnapshotOK(), nil
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package grafana_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDashboardSnapshot_basic(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccDashboardSnapshotCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.TestAccExample(t, "resources/grafana_dashboard_snapshot/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("grafana_dashboard_snapshot.test", "id", defaultOrgIDRegexp),
+					resource.TestCheckResourceAttrSet("grafana_dashboard_snapshot.test", "key"),
+					resource.TestCheckResourceAttrSet("grafana_dashboard_snapshot.test", "delete_key"),
+					resource.TestCheckResourceAttrSet("grafana_dashboard_snapshot.test", "url"),
+					resource.TestCheckResourceAttr("grafana_dashboard_snapshot.test", "name", "Production Overview (incident 1234)"),
+					resource.TestCheckResourceAttr("grafana_dashboard_snapshot.test", "expires", "86400"),
+				),
+			},
+			{
+				// Snapshots that never expire, with the default name.
+				Config: `
+resource "grafana_dashboard_snapshot" "test" {
+  config_json = jsonencode({
+    title  = "Production Overview"
+    panels = []
+  })
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("grafana_dashboard_snapshot.test", "name"),
+					resource.TestCheckResourceAttr("grafana_dashboard_snapshot.test", "expires", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDashboardSnapshotCheckDestroy(s *terraform.State) error {
+	client := testutils.Provider.Meta().(*common.Client).GrafanaAPI
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "grafana_dashboard_snapshot" {
+			continue
+		}
+		_, err := client.Snapshots.GetDashboardSnapshot(rs.Primary.Attributes["key"])
+		if err == nil {
+			return fmt.Errorf("dashboard snapshot %s still exists", rs.Primary.Attributes["key"])
+		}
+		if !common.IsNotFoundError(err) {
+			return err
+		}
+	}
+	return nil
+}
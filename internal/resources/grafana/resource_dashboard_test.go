@@ -3,6 +3,7 @@ package grafana_test
 import (
 	"fmt"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -205,6 +206,101 @@ func TestAccDashboard_folder_uid(t *testing.T) {
 	})
 }
 
+func TestAccDashboard_importInFolder(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=8.0.0")
+
+	uid := acctest.RandString(10)
+
+	var dashboard models.DashboardFullWithMeta
+	var folder models.Folder
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			dashboardCheckExists.destroyed(&dashboard, nil),
+			folderCheckExists.destroyed(&folder, nil),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDashboardFolder(uid, "grafana_folder.test_folder1.id"),
+				Check: resource.ComposeTestCheckFunc(
+					folderCheckExists.exists("grafana_folder.test_folder1", &folder),
+					dashboardCheckExists.exists("grafana_dashboard.test_folder", &dashboard),
+				),
+			},
+			// Import with the bare UID, as users usually do, and check that the folder is kept
+			{
+				ImportState:        true,
+				ImportStatePersist: true,
+				ResourceName:       "grafana_dashboard.test_folder",
+				ImportStateId:      uid,
+				ImportStateCheck: func(s []*terraform.InstanceState) error {
+					if len(s) != 1 {
+						return fmt.Errorf("expected 1 imported state, got %d", len(s))
+					}
+					if folderUID := s[0].Attributes["folder"]; folderUID != uid+"-1" {
+						return fmt.Errorf("expected folder %s-1, got %q", uid, folderUID)
+					}
+					return nil
+				},
+			},
+			// The imported state must not move the dashboard
+			{
+				Config:   testAccDashboardFolder(uid, "grafana_folder.test_folder1.id"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccDashboard_folderByTitle(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=8.0.0")
+
+	name := acctest.RandString(10)
+
+	var dashboard models.DashboardFullWithMeta
+	var folder models.Folder
+
+	config := fmt.Sprintf(`
+resource "grafana_folder" "test" {
+	title = "%[1]s"
+}
+
+resource "grafana_dashboard" "test" {
+	folder          = grafana_folder.test.title
+	folder_by_title = true
+	config_json     = jsonencode({
+		title = "%[1]s"
+		uid   = "%[1]s"
+	})
+}
+`, name)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			dashboardCheckExists.destroyed(&dashboard, nil),
+			folderCheckExists.destroyed(&folder, nil),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					folderCheckExists.exists("grafana_folder.test", &folder),
+					dashboardCheckExists.exists("grafana_dashboard.test", &dashboard),
+					testAccDashboardCheckExistsInFolder(&dashboard, &folder),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "folder", name),
+				),
+			},
+			// The title read back must not cause a diff
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 func TestAccDashboard_inOrg(t *testing.T) {
 	testutils.CheckOSSTestsEnabled(t)
 
@@ -303,6 +399,26 @@ func Test_NormalizeDashboardConfigJSON(t *testing.T) {
 			args: args{config: givenPanels},
 			want: expectedPanels,
 		},
+		{
+			name: "Reordered template variables are sorted by name",
+			args: args{config: `{"templating":{"list":[{"name":"b","type":"custom"},{"name":"a","type":"query"}]}}`},
+			want: `{"templating":{"list":[{"name":"a","type":"query"},{"name":"b","type":"custom"}]}}`,
+		},
+		{
+			name: "Reordered annotations are sorted by name",
+			args: args{config: `{"annotations":{"list":[{"name":"Deployments"},{"name":"Annotations & Alerts"}]}}`},
+			want: `{"annotations":{"list":[{"name":"Annotations \u0026 Alerts"},{"name":"Deployments"}]}}`,
+		},
+		{
+			name: "Default settings are removed",
+			args: args{config: `{"title":"New Dashboard","fiscalYearStartMonth":0,"timezone":"","weekStart":""}`},
+			want: expected,
+		},
+		{
+			name: "Non-default settings are kept",
+			args: args{config: map[string]interface{}{"title": d, "fiscalYearStartMonth": 3, "timezone": "utc", "weekStart": "monday"}},
+			want: `{"fiscalYearStartMonth":3,"timezone":"utc","title":"New Dashboard","weekStart":"monday"}`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -313,6 +429,343 @@ func Test_NormalizeDashboardConfigJSON(t *testing.T) {
 	}
 }
 
+func Test_NormalizeDashboardConfigJSON_ReorderedLists(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	configured := `{"title":"test","templating":{"list":[{"name":"env","query":"prod"},{"name":"cluster","query":"eu"}]}}`
+	remote := `{"title":"test","templating":{"list":[{"name":"cluster","query":"eu"},{"name":"env","query":"prod"}]}}`
+	if grafana.NormalizeDashboardConfigJSON(configured) != grafana.NormalizeDashboardConfigJSON(remote) {
+		t.Errorf("expected reordered template variables to produce no diff")
+	}
+
+	edited := `{"title":"test","templating":{"list":[{"name":"cluster","query":"us"},{"name":"env","query":"prod"}]}}`
+	if grafana.NormalizeDashboardConfigJSON(configured) == grafana.NormalizeDashboardConfigJSON(edited) {
+		t.Errorf("expected an edited template variable to produce a diff")
+	}
+
+	removed := `{"title":"test","templating":{"list":[{"name":"env","query":"prod"}]}}`
+	if grafana.NormalizeDashboardConfigJSON(configured) == grafana.NormalizeDashboardConfigJSON(removed) {
+		t.Errorf("expected a removed template variable to produce a diff")
+	}
+}
+
+func Test_NormalizeDashboardConfigJSON_CollapsedRows(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	configured := `{"title":"test","panels":[
+		{"type":"row","title":"Expanded","gridPos":{"h":1,"w":24,"x":0,"y":0}},
+		{"type":"timeseries","title":"CPU","gridPos":{"h":8,"w":12,"x":0,"y":1}},
+		{"type":"row","title":"Collapsed","collapsed":true,"gridPos":{"h":1,"w":24,"x":0,"y":9},"panels":[
+			{"type":"stat","title":"Memory","gridPos":{"h":8,"w":12,"x":0,"y":10}},
+			{"type":"stat","title":"Library","libraryPanel":{"uid":"lib","name":"Library"}}
+		]}
+	]}`
+	// Grafana assigns IDs to all panels, including the nested ones, and fills in the rows' defaults
+	remote := `{"title":"test","version":3,"panels":[
+		{"id":1,"type":"row","title":"Expanded","collapsed":false,"panels":[],"gridPos":{"h":1,"w":24,"x":0,"y":0}},
+		{"id":2,"type":"timeseries","title":"CPU","gridPos":{"h":8,"w":12,"x":0,"y":1}},
+		{"id":3,"type":"row","title":"Collapsed","collapsed":true,"gridPos":{"h":1,"w":24,"x":0,"y":9},"panels":[
+			{"id":4,"type":"stat","title":"Memory","gridPos":{"h":8,"w":12,"x":0,"y":10}},
+			{"id":5,"type":"stat","title":"Library","libraryPanel":{"uid":"lib","name":"Library","version":2,"meta":{}}}
+		]}
+	]}`
+	if grafana.NormalizeDashboardConfigJSON(configured) != grafana.NormalizeDashboardConfigJSON(remote) {
+		t.Errorf("expected a round-trip of collapsed rows to produce no diff: %s != %s", grafana.NormalizeDashboardConfigJSON(configured), grafana.NormalizeDashboardConfigJSON(remote))
+	}
+
+	edited := strings.Replace(configured, `"title":"Memory"`, `"title":"Swap"`, 1)
+	if grafana.NormalizeDashboardConfigJSON(configured) == grafana.NormalizeDashboardConfigJSON(edited) {
+		t.Errorf("expected an edited panel in a collapsed row to produce a diff")
+	}
+
+	expanded := strings.Replace(configured, `"collapsed":true`, `"collapsed":false`, 1)
+	if grafana.NormalizeDashboardConfigJSON(configured) == grafana.NormalizeDashboardConfigJSON(expanded) {
+		t.Errorf("expected an expanded row to produce a diff")
+	}
+}
+
+func TestSuppressEquivalentDashboardConfigJSON(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	old := `{"panels":[{"gridPos":{"h":8,"w":12},"targets":[{"expr":"up","refId":"A"}],"title":"Up","type":"stat"}],"tags":["a","b"],"title":"test"}`
+	for _, tc := range []struct {
+		name     string
+		new      string
+		suppress bool
+	}{
+		{
+			name:     "identical",
+			new:      old,
+			suppress: true,
+		},
+		{
+			name: "whitespace",
+			new: `{
+				"panels": [
+					{"gridPos": {"h": 8, "w": 12}, "targets": [{"expr": "up", "refId": "A"}], "title": "Up", "type": "stat"}
+				],
+				"tags": ["a", "b"],
+				"title": "test"
+			}`,
+			suppress: true,
+		},
+		{
+			name:     "reordered keys",
+			new:      `{"title":"test","tags":["a","b"],"panels":[{"type":"stat","title":"Up","targets":[{"refId":"A","expr":"up"}],"gridPos":{"w":12,"h":8}}]}`,
+			suppress: true,
+		},
+		{
+			name:     "server-managed fields",
+			new:      `{"id":12,"version":3,"panels":[{"id":1,"gridPos":{"h":8,"w":12},"targets":[{"expr":"up","refId":"A"}],"title":"Up","type":"stat"}],"tags":["a","b"],"title":"test"}`,
+			suppress: true,
+		},
+		{
+			name: "changed nested value",
+			new:  `{"panels":[{"gridPos":{"h":8,"w":12},"targets":[{"expr":"down","refId":"A"}],"title":"Up","type":"stat"}],"tags":["a","b"],"title":"test"}`,
+		},
+		{
+			name: "reordered array",
+			new:  `{"panels":[{"gridPos":{"h":8,"w":12},"targets":[{"expr":"up","refId":"A"}],"title":"Up","type":"stat"}],"tags":["b","a"],"title":"test"}`,
+		},
+		{
+			name: "removed",
+			new:  "",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := grafana.SuppressEquivalentDashboardConfigJSON("config_json", old, tc.new, nil); got != tc.suppress {
+				t.Errorf("expected suppress to be %t, got %t", tc.suppress, got)
+			}
+		})
+	}
+}
+
+func Test_NormalizeDashboardConfigJSON_ServerDefaults(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	configured := `{"title":"test","panels":[]}`
+	remote := `{"title":"test","panels":[],"fiscalYearStartMonth":0,"timezone":"","weekStart":""}`
+	if grafana.NormalizeDashboardConfigJSON(configured) != grafana.NormalizeDashboardConfigJSON(remote) {
+		t.Errorf("expected the settings filled in by Grafana to produce no diff")
+	}
+
+	edited := `{"title":"test","panels":[],"fiscalYearStartMonth":4}`
+	if grafana.NormalizeDashboardConfigJSON(configured) == grafana.NormalizeDashboardConfigJSON(edited) {
+		t.Errorf("expected a changed fiscal year start month to produce a diff")
+	}
+}
+
+func Test_NormalizeDashboardConfigJSON_EditableAndGraphTooltip(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	configured := `{"title":"test","panels":[]}`
+	remote := `{"title":"test","panels":[],"editable":true,"graphTooltip":0}`
+	if grafana.NormalizeDashboardConfigJSON(configured) != grafana.NormalizeDashboardConfigJSON(remote) {
+		t.Errorf("expected the editable and graphTooltip defaults filled in by Grafana to produce no diff")
+	}
+
+	sharedCrosshair := `{"title":"test","panels":[],"editable":true,"graphTooltip":1}`
+	if grafana.NormalizeDashboardConfigJSON(configured) == grafana.NormalizeDashboardConfigJSON(sharedCrosshair) {
+		t.Errorf("expected a changed graphTooltip to produce a diff")
+	}
+
+	readOnly := `{"title":"test","panels":[],"editable":false}`
+	if grafana.NormalizeDashboardConfigJSON(configured) == grafana.NormalizeDashboardConfigJSON(readOnly) {
+		t.Errorf("expected a non-editable dashboard to produce a diff")
+	}
+}
+
+func TestReconcileDashboardConfigJSON(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	configured := `{"title":"test","panels":[{"id":1,"type":"graph"}]}`
+	remote := func() map[string]interface{} {
+		dashboard, err := grafana.UnmarshalDashboardConfigJSON(`{"title":"test","panels":[{"id":1,"type":"graph"}],"id":12,"uid":"abc","version":7,"schemaVersion":39}`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return dashboard
+	}
+
+	for _, tc := range []struct {
+		name       string
+		configured string
+		overwrite  bool
+		expected   string
+	}{
+		{name: "server-managed fields", configured: configured, expected: grafana.NormalizeDashboardConfigJSON(configured)},
+		{name: "configured schemaVersion", configured: `{"title":"test","panels":[],"schemaVersion":38}`, expected: `{"panels":[{"type":"graph"}],"schemaVersion":39,"title":"test"}`},
+		{name: "configured uid", configured: `{"title":"test","panels":[],"uid":"abc"}`, expected: `{"panels":[{"type":"graph"}],"title":"test","uid":"abc"}`},
+		{name: "overwrite", configured: configured, overwrite: true, expected: `{"panels":[{"type":"graph"}],"schemaVersion":39,"title":"test"}`},
+		{name: "import", configured: "", expected: `{"panels":[{"type":"graph"}],"schemaVersion":39,"title":"test","uid":"abc"}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := grafana.ReconcileDashboardConfigJSON(tc.configured, remote(), tc.overwrite, func(string) (bool, error) { return false, nil })
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestReconcileDashboardConfigJSON_InjectedDefaultDatasource(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	isDefaultDatasource := func(ref string) (bool, error) {
+		return ref == "default-uid" || ref == "Default", nil
+	}
+
+	for _, tc := range []struct {
+		name       string
+		configured string
+		remote     string
+		expected   string
+	}{
+		{
+			name:       "injected default",
+			configured: `{"title":"test","panels":[{"type":"timeseries"}]}`,
+			remote:     `{"title":"test","panels":[{"type":"timeseries","datasource":{"type":"prometheus","uid":"default-uid"}}]}`,
+			expected:   `{"panels":[{"type":"timeseries"}],"title":"test"}`,
+		},
+		{
+			name:       "injected default by name",
+			configured: `{"title":"test","panels":[{"type":"timeseries"}]}`,
+			remote:     `{"title":"test","panels":[{"type":"timeseries","datasource":"Default"}]}`,
+			expected:   `{"panels":[{"type":"timeseries"}],"title":"test"}`,
+		},
+		{
+			name:       "injected null",
+			configured: `{"title":"test","panels":[{"type":"timeseries"}]}`,
+			remote:     `{"title":"test","panels":[{"type":"timeseries","datasource":null}]}`,
+			expected:   `{"panels":[{"type":"timeseries"}],"title":"test"}`,
+		},
+		{
+			name:       "panel in row",
+			configured: `{"title":"test","panels":[{"type":"row","panels":[{"type":"timeseries"}]}]}`,
+			remote:     `{"title":"test","panels":[{"type":"row","panels":[{"type":"timeseries","datasource":{"uid":"default-uid"}}]}]}`,
+			expected:   `{"panels":[{"panels":[{"type":"timeseries"}],"type":"row"}],"title":"test"}`,
+		},
+		{
+			name:       "other data source",
+			configured: `{"title":"test","panels":[{"type":"timeseries"}]}`,
+			remote:     `{"title":"test","panels":[{"type":"timeseries","datasource":{"uid":"other-uid"}}]}`,
+			expected:   `{"panels":[{"datasource":{"uid":"other-uid"},"type":"timeseries"}],"title":"test"}`,
+		},
+		{
+			name:       "configured default",
+			configured: `{"title":"test","panels":[{"type":"timeseries","datasource":{"uid":"default-uid"}}]}`,
+			remote:     `{"title":"test","panels":[{"type":"timeseries","datasource":{"uid":"default-uid"}}]}`,
+			expected:   `{"panels":[{"datasource":{"uid":"default-uid"},"type":"timeseries"}],"title":"test"}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			remote, err := grafana.UnmarshalDashboardConfigJSON(tc.remote)
+			if err != nil {
+				t.Fatal(err)
+			}
+			actual, err := grafana.ReconcileDashboardConfigJSON(tc.configured, remote, false, isDefaultDatasource)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestValidateDashboardDatasources(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	installedPlugins := map[string]bool{"prometheus": true, "loki": true}
+	existingDatasources := map[string]bool{"prom-uid": true}
+	lookups := 0
+	pluginExists := func(pluginID string) (bool, error) {
+		lookups++
+		return installedPlugins[pluginID], nil
+	}
+	datasourceExists := func(ref string) (bool, error) {
+		lookups++
+		return existingDatasources[ref], nil
+	}
+
+	dashboard, err := grafana.UnmarshalDashboardConfigJSON(`{
+		"title": "test",
+		"templating": {
+			"list": [
+				{"name": "ds", "type": "datasource", "query": "prometheus"},
+				{"name": "other_ds", "type": "datasource", "query": "grafana-unknown-datasource"},
+				{"name": "job", "type": "query", "datasource": {"type": "prometheus", "uid": "prom-uid"}},
+				{"name": "instance", "type": "query", "datasource": {"type": "prometheus", "uid": "${ds}"}},
+				{"name": "legacy", "type": "query", "datasource": "missing-uid"},
+				{"name": "mixed", "type": "query", "datasource": {"uid": "-- Mixed --"}},
+				{"name": "env", "type": "custom", "query": "dev,prod"}
+			]
+		}
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = grafana.ValidateDashboardDatasources(dashboard, pluginExists, datasourceExists)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, expected := range []string{
+		`template variable "other_ds": data source plugin "grafana-unknown-datasource" is not installed`,
+		`template variable "legacy": data source "missing-uid" does not exist`,
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error to contain %q, got %q", expected, err)
+		}
+	}
+	if strings.Count(err.Error(), "template variable") != 2 {
+		t.Errorf("expected exactly two errors, got %q", err)
+	}
+	// Variables referencing other variables and built-in data sources aren't looked up
+	if lookups != 4 {
+		t.Errorf("expected 4 lookups, got %d", lookups)
+	}
+
+	valid, _ := grafana.UnmarshalDashboardConfigJSON(`{"templating": {"list": [{"name": "ds", "type": "datasource", "query": "loki"}]}}`)
+	if err := grafana.ValidateDashboardDatasources(valid, pluginExists, datasourceExists); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+}
+
+func TestDashboardAlertRuleGroups(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	rule := func(folderUID, group, dashboardUID string) *models.ProvisionedAlertRule {
+		annotations := map[string]string{"summary": "test"}
+		if dashboardUID != "" {
+			annotations["__dashboardUid__"] = dashboardUID
+			annotations["__panelId__"] = "1"
+		}
+		return &models.ProvisionedAlertRule{FolderUID: &folderUID, RuleGroup: &group, Annotations: annotations}
+	}
+	rules := []*models.ProvisionedAlertRule{
+		rule("folder-b", "group", "my-dashboard"),
+		rule("folder-a", "group", "my-dashboard"),
+		rule("folder-a", "group", "my-dashboard"), // Same group as the previous rule
+		rule("folder-a", "other-group", "other-dashboard"),
+		rule("folder-a", "unlinked-group", ""),
+	}
+
+	groups := grafana.DashboardAlertRuleGroups(2, "my-dashboard", rules)
+	expected := []string{"2:folder-a:group", "2:folder-b:group"}
+	if !reflect.DeepEqual(groups, expected) {
+		t.Errorf("expected %v, got %v", expected, groups)
+	}
+
+	if groups := grafana.DashboardAlertRuleGroups(2, "unknown-dashboard", rules); len(groups) != 0 {
+		t.Errorf("expected no rule groups, got %v", groups)
+	}
+}
+
 func testAccDashboardFolder(uid string, folderRef string) string {
 	return fmt.Sprintf(`
 resource "grafana_folder" "test_folder1" {
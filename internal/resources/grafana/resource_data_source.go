@@ -2,13 +2,17 @@ package grafana
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -18,6 +22,47 @@ import (
 	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
 )
 
+var datasourceJSONDataTypes = []datasourceJSONDataType{
+	alertmanagerJSONData{},
+	enterpriseMetricsJSONData{},
+	falconLogScaleJSONData{},
+	grafanaCloudAppJSONData{field: "adaptive_metrics", pluginID: "grafana-adaptive-metrics-datasource", app: "Adaptive Metrics"},
+	grafanaCloudAppJSONData{field: "asserts", pluginID: "grafana-asserts-datasource", app: "Asserts", endpoint: "Asserts API of the stack"},
+	grafanaCloudAppJSONData{field: "frontend_observability", pluginID: "grafana-kowalski-datasource", app: "Frontend Observability (Faro)", endpoint: "Faro API of the stack's region", collector: "Faro collector of the stack's region"},
+	grafanaCloudAppJSONData{field: "incident", pluginID: "grafana-incident-datasource", app: "Incident", outputs: map[string]datasourceJSONDataOutput{
+		"webhook_url": {gfKey: "webhookUrl", desc: "The URL other integrations (e.g. OnCall or contact points) send incident events to."},
+	}},
+	grafanaCloudAppJSONData{field: "irm", pluginID: "grafana-irm-datasource", app: "IRM", outputs: oncallIntegrationOutputs},
+	grafanaCloudAppJSONData{field: "k6", pluginID: "grafana-k6-datasource", app: "k6 Performance Testing", endpoint: "k6 Cloud API", checkProject: checkK6Project},
+	grafanaCloudAppJSONData{field: "machine_learning", pluginID: "grafana-ml-datasource", app: "Machine Learning"},
+	grafanaCloudAppJSONData{field: "oncall", pluginID: "grafana-oncall-datasource", app: "OnCall", outputs: oncallIntegrationOutputs},
+	grafanaCloudUsageJSONData{},
+	lokiJSONData{},
+	mongoDBJSONData{},
+	mysqlJSONData{},
+	prometheusJSONData{},
+	syntheticMonitoringJSONData{},
+	tempoJSONData{},
+	vertamediaClickHouseJSONData{},
+}
+
+// oncallIntegrationOutputs are the URLs of the integrations created along with the OnCall and IRM data sources.
+// They can be used as the URL of `oncall` contact point notifiers.
+var oncallIntegrationOutputs = map[string]datasourceJSONDataOutput{
+	"alertmanager_integration_url": {gfKey: "alertmanagerIntegrationUrl", desc: "The URL that Grafana Alerting (e.g. the `url` of a contact point's `oncall` notifier) sends alerts to."},
+	"webhook_integration_url":      {gfKey: "webhookIntegrationUrl", desc: "The URL that other tools send alerts to, as JSON webhooks."},
+}
+
+// datasourceTenantHeaders are the headers used to select the tenant of multi-tenant backends (Loki, Mimir, Tempo, Pyroscope), by data source type.
+// Mimir's (and Cortex's) Alertmanager and ruler are multi-tenant too.
+var datasourceTenantHeaders = map[string]string{
+	"alertmanager":                 "X-Scope-OrgID",
+	"grafana-pyroscope-datasource": "X-Scope-OrgID",
+	"loki":                         "X-Scope-OrgID",
+	"prometheus":                   "X-Scope-OrgID",
+	"tempo":                        "X-Scope-OrgID",
+}
+
 func resourceDataSource() *common.Resource {
 	schema := &schema.Resource{
 
@@ -27,16 +72,25 @@ func resourceDataSource() *common.Resource {
 
 The required arguments for this resource vary depending on the type of data
 source selected (via the 'type' argument).
+
+Data sources can also be imported by name, e.g. ` + "`terraform import grafana_data_source.name \"My Data Source\"`" + `.
 `,
 
 		CreateContext: CreateDataSource,
 		UpdateContext: UpdateDataSource,
 		DeleteContext: DeleteDataSource,
 		ReadContext:   ReadDataSource,
+		CustomizeDiff: customdiff.All(
+			validateDatasourceJSONData,
+			validateDatasourceTenantID,
+			validateDatasourceAlertmanager,
+			validateDatasourceTracesToProfiles,
+			validateDatasourcePrometheusLinks,
+		),
 		SchemaVersion: 1,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importDataSource,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -93,17 +147,33 @@ source selected (via the 'type' argument).
 					return oldValue == "true" && newValue == "false" || oldValue == newValue
 				},
 			},
+			"is_default_force": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "When `is_default` is set, make the data source the default again on the next apply if another data source was made the default since. " +
+					"If several data sources set it (e.g. during a migration), the last one applied is the default. " +
+					"If not set, a warning is shown instead.",
+			},
 			"url": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Description: "The URL for the data source. The type of URL required varies depending on the chosen data source type.",
 			},
+			"tenant_id": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				Description: "The tenant to query, for multi-tenant backends. It's sent in the `X-Scope-OrgID` header. " +
+					"Can only be used with data sources of type `" + strings.Join(datasourceTenantHeaderTypes(), "`, `") + "`.",
+			},
 			"username": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Default:     "",
 				Description: "(Required by some data source types) The username to use to authenticate to the data source.",
 			},
+			"json_data":                datasourceTypedJSONDataAttribute(),
 			"json_data_encoded":        datasourceJSONDataAttribute(),
 			"secure_json_data_encoded": datasourceSecureJSONDataAttribute(),
 		},
@@ -182,6 +252,134 @@ func datasourceSecureJSONDataAttribute() *schema.Schema {
 	}
 }
 
+// datasourceHTTPClientSettings are the settings of the HTTP client of data sources, set directly in the `json_data` block since they apply to all types.
+func datasourceHTTPClientSettings() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"tls_skip_verify": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Whether to skip the verification of the data source's TLS certificate.",
+		},
+		"tls_client_auth": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Whether to authenticate to the data source with a TLS client certificate, set with `tls_client_cert` and `tls_client_key`.",
+		},
+		"tls_ca_cert":     datasourceTLSCertificateSchema("The CA certificate that the data source's certificate is verified with"),
+		"tls_client_cert": datasourceTLSCertificateSchema("The client certificate sent to the data source"),
+		"tls_client_key":  datasourceTLSCertificateSchema("The private key of `tls_client_cert`"),
+		"timeout": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Description:  "The timeout of the requests to the data source, in seconds. Grafana's default is used if not set.",
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+	}
+}
+
+// datasourceTLSCertificateFields are the TLS certificates of the HTTP client settings, mapped to their secure JSON data keys.
+// They're also used by SQL data sources, e.g. `postgres` and `mysql` with the `file-content` TLS configuration method.
+var datasourceTLSCertificateFields = map[string]string{
+	"tls_ca_cert":     "tlsCACert",
+	"tls_client_cert": "tlsClientCert",
+	"tls_client_key":  "tlsClientKey",
+}
+
+func datasourceTLSCertificateSchema(desc string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Sensitive:   true,
+		Description: desc + ", in PEM format. It can also be base64-encoded, e.g. read with `filebase64()`. It's written to the secure JSON data, it can't be read back from Grafana.",
+		ValidateFunc: func(i interface{}, k string) ([]string, []error) {
+			if _, err := DatasourcePEM(i.(string)); err != nil {
+				return nil, []error{fmt.Errorf("%s: %w", k, err)}
+			}
+			return nil, nil
+		},
+	}
+}
+
+// DatasourcePEM returns the PEM content of a TLS certificate or key, given as PEM or as base64-encoded PEM.
+func DatasourcePEM(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	if !strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN") {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+		if err != nil {
+			return "", errors.New("expected a PEM block or base64-encoded PEM")
+		}
+		value = string(decoded)
+	}
+	if block, _ := pem.Decode([]byte(value)); block == nil {
+		return "", errors.New("expected a PEM block or base64-encoded PEM")
+	}
+	return value, nil
+}
+
+func hasDatasourceHTTPClientSettings(tfSettings map[string]interface{}) bool {
+	for field := range datasourceTLSCertificateFields {
+		if tfSettings[field].(string) != "" {
+			return true
+		}
+	}
+	return tfSettings["tls_skip_verify"].(bool) || tfSettings["tls_client_auth"].(bool) || tfSettings["timeout"].(int) != 0
+}
+
+func packDatasourceHTTPClientSettings(jsonData, tfSettings, state map[string]interface{}) {
+	packJSONDataBool(jsonData, tfSettings, "tlsSkipVerify", "tls_skip_verify")
+	packJSONDataBool(jsonData, tfSettings, "tlsAuth", "tls_client_auth")
+	packJSONDataInt(jsonData, tfSettings, "timeout", "timeout")
+	// The certificates are secure, they can't be read from the API
+	for field := range datasourceTLSCertificateFields {
+		if v, ok := state[field].(string); ok {
+			tfSettings[field] = v
+		}
+	}
+}
+
+func unpackDatasourceHTTPClientSettings(tfSettings, jsonData map[string]interface{}, secureJSONData map[string]string) error {
+	unpackJSONDataBool(tfSettings, jsonData, "tls_skip_verify", "tlsSkipVerify")
+	unpackJSONDataBool(tfSettings, jsonData, "tls_client_auth", "tlsAuth")
+	unpackJSONDataInt(tfSettings, jsonData, "timeout", "timeout")
+	for field, gfKey := range datasourceTLSCertificateFields {
+		v, _ := tfSettings[field].(string)
+		if v == "" {
+			continue
+		}
+		cert, err := DatasourcePEM(v)
+		if err != nil {
+			return fmt.Errorf("json_data.0.%s: %w", field, err)
+		}
+		secureJSONData[gfKey] = cert
+	}
+	return nil
+}
+
+func datasourceTypedJSONDataAttribute() *schema.Schema {
+	typedSchema := datasourceHTTPClientSettings()
+	for _, t := range datasourceJSONDataTypes {
+		typedSchema[t.meta().field] = &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: fmt.Sprintf("%s Can only be used with data sources of type `%s`.", t.meta().desc, strings.Join(t.meta().pluginIDs, "`, `")),
+			Elem:        t.schema(),
+		}
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Typed configuration options for the data source. The values set here are merged with (and take precedence over) the ones set in `json_data_encoded` and `secure_json_data_encoded`.",
+		Elem: &schema.Resource{
+			Schema: typedSchema,
+		},
+	}
+}
+
 func listDatasources(ctx context.Context, client *goapi.GrafanaHTTPAPI, data *ListerData) ([]string, error) {
 	orgIDs, err := data.OrgIDs(client)
 	if err != nil {
@@ -190,7 +388,7 @@ func listDatasources(ctx context.Context, client *goapi.GrafanaHTTPAPI, data *Li
 
 	var ids []string
 	for _, orgID := range orgIDs {
-		client = client.Clone().WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 		resp, err := client.Datasources.GetDataSources()
 		if err != nil {
 			return nil, err
@@ -213,13 +411,17 @@ func CreateDataSource(ctx context.Context, d *schema.ResourceData, meta interfac
 		return diag.FromErr(err)
 	}
 
+	if err := resolveDatasourcePrometheusLinks(client, d, dataSource.JSONData); err != nil {
+		return diag.FromErr(err)
+	}
+
 	resp, err := client.Datasources.AddDataSource(dataSource)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	d.SetId(MakeOrgResourceID(orgID, resp.Payload.Datasource.UID))
-	return ReadDataSource(ctx, d, meta)
+	return append(datasourceAlertmanagerWarnings(client, d), ReadDataSource(ctx, d, meta)...)
 }
 
 // UpdateDataSource updates a Grafana datasource
@@ -230,6 +432,12 @@ func UpdateDataSource(ctx context.Context, d *schema.ResourceData, meta interfac
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if err := resolveDatasourcePrometheusLinks(client, d, dataSource.JSONData); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := keepDataSourceConfigMarker(client, idStr, dataSource.JSONData); err != nil {
+		return diag.FromErr(err)
+	}
 	body := models.UpdateDataSourceCommand{
 		Access:          dataSource.Access,
 		BasicAuth:       dataSource.BasicAuth,
@@ -245,9 +453,32 @@ func UpdateDataSource(ctx context.Context, d *schema.ResourceData, meta interfac
 		User:            dataSource.User,
 		WithCredentials: dataSource.WithCredentials,
 	}
-	_, err = client.Datasources.UpdateDataSourceByUID(idStr, &body)
+	if _, err = client.Datasources.UpdateDataSourceByUID(idStr, &body); err != nil {
+		return diag.FromErr(err)
+	}
 
-	return diag.FromErr(err)
+	return datasourceAlertmanagerWarnings(client, d)
+}
+
+// importDataSource imports a data source by UID or, if no data source has that UID, by name.
+// Either way, the ID in the state is the usual <orgID>:<uid>.
+func importDataSource(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client, orgID, ref := OAPIClientFromExistingOrgResource(meta, d.Id())
+
+	_, err := client.Datasources.GetDataSourceByUID(ref)
+	if err == nil || !common.IsNotFoundError(err) {
+		return []*schema.ResourceData{d}, nil // Other errors are reported by the read
+	}
+
+	resp, err := client.Datasources.GetDataSourceByName(ref)
+	if err != nil {
+		if common.IsNotFoundError(err) {
+			return nil, fmt.Errorf("no data source found with UID or name %q", ref)
+		}
+		return nil, err
+	}
+	d.SetId(MakeOrgResourceID(orgID, resp.Payload.UID))
+	return []*schema.ResourceData{d}, nil
 }
 
 // ReadDataSource reads a Grafana datasource
@@ -259,7 +490,45 @@ func ReadDataSource(ctx context.Context, d *schema.ResourceData, meta interface{
 		return err
 	}
 
-	return datasourceToState(d, resp.Payload)
+	// Like other headers, the tenant can't be read back. Only unset it if the header was removed.
+	if jsonData, ok := resp.Payload.JSONData.(map[string]interface{}); ok {
+		header, _ := DatasourceTenantHeader(resp.Payload.Type)
+		if _, headers := removeHeadersFromJSONData(jsonData); header == "" || headers[header] == "" {
+			d.Set("tenant_id", "")
+		}
+	}
+
+	stateIsDefault, force := d.Get("is_default").(bool), d.Get("is_default_force").(bool)
+
+	// Imported data sources have no JSON data in their state, their HTTP client settings are read into the `json_data` block
+	imported := d.Get("json_data_encoded").(string) == "" && len(d.Get("json_data").([]interface{})) == 0
+	if imported {
+		d.Set("json_data", []interface{}{map[string]interface{}{}})
+	}
+	diags := datasourceToState(d, resp.Payload)
+	if block, ok := typedJSONDataBlock(d.Get("json_data")); imported && (!ok || !hasDatasourceHTTPClientSettings(block)) {
+		d.Set("json_data", nil)
+	}
+	isDefault, warnings := ReconcileDatasourceIsDefault(resp.Payload.Name, stateIsDefault, force, resp.Payload.IsDefault)
+	d.Set("is_default", isDefault)
+	d.Set("is_default_force", force) // Not stored in Grafana, set for imports
+	return append(diags, warnings...)
+}
+
+// ReconcileDatasourceIsDefault returns the `is_default` value to store in the state of a data source.
+// Grafana only has one default data source: setting a data source as default silently unsets the previous one.
+// Unless `is_default_force` is set, the data source is kept as default in the state (with a warning),
+// so that two data sources setting `is_default` don't keep taking the default from each other.
+func ReconcileDatasourceIsDefault(name string, stateIsDefault, force, isDefault bool) (bool, diag.Diagnostics) {
+	if !stateIsDefault || isDefault || force {
+		return isDefault, nil
+	}
+	return true, diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("The data source %q is no longer the default data source", name),
+		Detail: "Another data source was set as the default data source, only one data source can be the default. " +
+			"Only set `is_default` on one data source, or set `is_default_force` to make this data source the default again on the next apply.",
+	}}
 }
 
 // DeleteDataSource deletes a Grafana datasource
@@ -291,12 +560,17 @@ func datasourceToState(d *schema.ResourceData, dataSource *models.DataSource) di
 
 func datasourceConfigToState(d *schema.ResourceData, dataSource *models.DataSource) diag.Diagnostics {
 	gottenJSONData, gottenHeaders := removeHeadersFromJSONData(dataSource.JSONData.(map[string]interface{}))
+	delete(gottenJSONData, dataSourceConfigMarker) // Set by grafana_data_source_config, it isn't part of the config
+	packDatasourceTypedJSONData(d, dataSource.Type, gottenJSONData)
 	encodedJSONData, err := json.Marshal(gottenJSONData)
 	if err != nil {
 		return diag.Errorf("Failed to marshal JSON data: %s", err)
 	}
 	d.Set("json_data_encoded", string(encodedJSONData))
 
+	secureJSONData, diags := ReconcileDatasourceSecureJSONData(d.Get("secure_json_data_encoded").(string), dataSource)
+	d.Set("secure_json_data_encoded", secureJSONData)
+
 	// For headers, we do not know the value (the API does not return secret data)
 	// so we only remove keys from the state that are no longer present in the API.
 	if currentHeadersInterface, ok := d.GetOk("http_headers"); ok {
@@ -308,7 +582,42 @@ func datasourceConfigToState(d *schema.ResourceData, dataSource *models.DataSour
 		}
 		d.Set("http_headers", currentHeaders)
 	}
-	return nil
+	return diags
+}
+
+// ReconcileDatasourceSecureJSONData returns the `secure_json_data_encoded` value to store in the state of a data source.
+// Secrets can't be read back, but Grafana lists the keys that are set in `secureJsonFields`. Keys of the state that Grafana reports as unset
+// (e.g. deleted from the UI) are removed, so that the next apply sets them again.
+func ReconcileDatasourceSecureJSONData(secureJSONData string, dataSource *models.DataSource) (string, diag.Diagnostics) {
+	if secureJSONData == "" {
+		return secureJSONData, nil
+	}
+	values := map[string]string{}
+	if err := json.Unmarshal([]byte(secureJSONData), &values); err != nil {
+		return secureJSONData, nil
+	}
+
+	var unset []string
+	for key := range values {
+		if !dataSource.SecureJSONFields[key] {
+			unset = append(unset, key)
+			delete(values, key)
+		}
+	}
+	if len(unset) == 0 {
+		return secureJSONData, nil
+	}
+	sort.Strings(unset)
+
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		return secureJSONData, diag.Errorf("Failed to marshal secure JSON data: %s", err)
+	}
+	return string(encoded), diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Secure JSON data of the data source %q is no longer set", dataSource.Name),
+		Detail:   fmt.Sprintf("Grafana reports these keys of `secure_json_data_encoded` as unset: %s. They will be set again on the next apply.", strings.Join(unset, ", ")),
+	}}
 }
 
 func stateToDatasource(d *schema.ResourceData) (*models.AddDataSourceCommand, error) {
@@ -339,6 +648,12 @@ func stateToDatasourceConfig(d *schema.ResourceData) (map[string]interface{}, ma
 	for key, value := range d.Get("http_headers").(map[string]interface{}) {
 		httpHeaders[key] = fmt.Sprintf("%v", value)
 	}
+	// Only grafana_data_source has the type and tenant_id attributes
+	if tenantID, ok := d.Get("tenant_id").(string); ok && tenantID != "" {
+		if header, ok := DatasourceTenantHeader(d.Get("type").(string)); ok {
+			httpHeaders[header] = tenantID
+		}
+	}
 
 	jd, err := makeJSONData(d)
 	if err != nil {
@@ -348,6 +663,10 @@ func stateToDatasourceConfig(d *schema.ResourceData) (map[string]interface{}, ma
 	if err != nil {
 		return nil, nil, err
 	}
+	if err := unpackDatasourceTypedJSONData(d, jd, sd); err != nil {
+		return nil, nil, err
+	}
+	defaultAlertmanagerHandleGrafanaManagedAlerts(d, jd)
 
 	jd, sd = jsonDataWithHeaders(jd, sd, httpHeaders)
 	return jd, sd, nil
@@ -411,3 +730,421 @@ func removeHeadersFromJSONData(input map[string]interface{}) (map[string]interfa
 
 	return jsonData, headers
 }
+
+type datasourceJSONDataType interface {
+	meta() datasourceJSONDataTypeMeta
+	schema() *schema.Resource
+	// pack reads the typed fields from the JSON data returned by the API, removing the keys it consumes.
+	// Secure fields can't be read from the API, they have to be copied from the given state.
+	pack(jsonData map[string]interface{}, state map[string]interface{}) map[string]interface{}
+	// unpack writes the typed fields into the JSON data and secure JSON data sent to the API.
+	unpack(raw map[string]interface{}, jsonData map[string]interface{}, secureJSONData map[string]string) error
+}
+
+// datasourceJSONDataValidator can be implemented by typed JSON data blocks to validate their fields at plan time.
+type datasourceJSONDataValidator interface {
+	validate(d *schema.ResourceDiff, raw map[string]interface{}) error
+}
+
+type datasourceJSONDataTypeMeta struct {
+	field        string
+	pluginIDs    []string
+	desc         string
+	secureFields []string
+}
+
+func (m datasourceJSONDataTypeMeta) supports(dsType string) bool {
+	for _, id := range m.pluginIDs {
+		if id == dsType {
+			return true
+		}
+	}
+	return false
+}
+
+// typedJSONDataBlock returns the content of the `json_data` block, if it is set.
+func typedJSONDataBlock(v interface{}) (map[string]interface{}, bool) {
+	list, ok := v.([]interface{})
+	if !ok || len(list) == 0 || list[0] == nil {
+		return nil, false
+	}
+	return list[0].(map[string]interface{}), true
+}
+
+func validateDatasourceJSONData(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	block, ok := typedJSONDataBlock(d.Get("json_data"))
+	if !ok {
+		return nil
+	}
+
+	dsType := d.Get("type").(string)
+	for _, t := range datasourceJSONDataTypes {
+		raw, ok := typedJSONDataBlock(block[t.meta().field])
+		if !ok {
+			continue
+		}
+		if d.NewValueKnown("type") && !t.meta().supports(dsType) {
+			return fmt.Errorf("json_data.0.%s can only be used with data sources of type `%s`, got `%s`", t.meta().field, strings.Join(t.meta().pluginIDs, "`, `"), dsType)
+		}
+		if v, ok := t.(datasourceJSONDataValidator); ok {
+			if err := v.validate(d, raw); err != nil {
+				return fmt.Errorf("json_data.0.%s: %w", t.meta().field, err)
+			}
+		}
+	}
+	return nil
+}
+
+// DatasourceTenantHeader returns the header used to select the tenant of the given data source type, if it supports multi-tenancy.
+func DatasourceTenantHeader(dsType string) (string, bool) {
+	header, ok := datasourceTenantHeaders[dsType]
+	return header, ok
+}
+
+func datasourceTenantHeaderTypes() []string {
+	types := make([]string, 0, len(datasourceTenantHeaders))
+	for dsType := range datasourceTenantHeaders {
+		types = append(types, dsType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func validateDatasourceTenantID(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if tenantID, ok := d.GetOk("tenant_id"); !ok || tenantID.(string) == "" || !d.NewValueKnown("type") {
+		return nil
+	}
+
+	dsType := d.Get("type").(string)
+	header, ok := DatasourceTenantHeader(dsType)
+	if !ok {
+		return fmt.Errorf("tenant_id can only be used with data sources of type `%s`, got `%s`", strings.Join(datasourceTenantHeaderTypes(), "`, `"), dsType)
+	}
+	for name := range d.Get("http_headers").(map[string]interface{}) {
+		if strings.EqualFold(name, header) {
+			return fmt.Errorf("the %s header is set by tenant_id, it can't also be set in http_headers", header)
+		}
+	}
+	return nil
+}
+
+// datasourceManagedAlertmanagerUID returns the `alertmanager_uid` of the typed `json_data` block, if `manage_alerts` is enabled.
+func datasourceManagedAlertmanagerUID(jsonData interface{}) string {
+	block, ok := typedJSONDataBlock(jsonData)
+	if !ok {
+		return ""
+	}
+	prometheus, ok := typedJSONDataBlock(block["prometheus"])
+	if !ok || !prometheus["manage_alerts"].(bool) {
+		return ""
+	}
+	return prometheus["alertmanager_uid"].(string)
+}
+
+// ValidateDatasourceAlertmanager checks that the data source that alerts are sent to is an Alertmanager.
+// A nil alertmanager means that no data source with the given UID was found.
+func ValidateDatasourceAlertmanager(alertmanagerUID string, alertmanager *models.DataSource) error {
+	if alertmanager == nil {
+		return fmt.Errorf("the alertmanager data source %q does not exist", alertmanagerUID)
+	}
+	if alertmanager.Type != "alertmanager" {
+		return fmt.Errorf("the data source %q is of type `%s`, alertmanager_uid must reference an `alertmanager` data source", alertmanagerUID, alertmanager.Type)
+	}
+	return nil
+}
+
+func getLinkedDatasource(client *goapi.GrafanaHTTPAPI, uid string) (*models.DataSource, error) {
+	resp, err := client.Datasources.GetDataSourceByUID(uid)
+	if err != nil {
+		if common.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return resp.Payload, nil
+}
+
+func getLinkedDatasourceByName(client *goapi.GrafanaHTTPAPI, name string) (*models.DataSource, error) {
+	resp, err := client.Datasources.GetDataSourceByName(name)
+	if err != nil {
+		if common.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return resp.Payload, nil
+}
+
+// validateDatasourceAlertmanager checks the data source referenced by `alertmanager_uid` at plan time.
+// The referenced data source may be created in the same apply, so a missing data source is only reported as a warning when applying.
+func validateDatasourceAlertmanager(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("json_data") {
+		return nil
+	}
+	alertmanagerUID := datasourceManagedAlertmanagerUID(d.Get("json_data"))
+	if alertmanagerUID == "" {
+		return nil
+	}
+	client, _ := oapiClientFromResourceDiff(meta, d)
+	if client == nil {
+		return nil
+	}
+
+	alertmanager, err := getLinkedDatasource(client, alertmanagerUID)
+	if err != nil || alertmanager == nil {
+		return nil
+	}
+	if err := ValidateDatasourceAlertmanager(alertmanagerUID, alertmanager); err != nil {
+		return fmt.Errorf("json_data.0.prometheus: %w", err)
+	}
+	return nil
+}
+
+// validateDatasourceTracesToProfiles checks that Tempo data sources link to Pyroscope data sources.
+// Like with alertmanager_uid, the linked data source may be created in the same apply, so only existing data sources are checked.
+func validateDatasourceTracesToProfiles(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("json_data") {
+		return nil
+	}
+	block, ok := typedJSONDataBlock(d.Get("json_data"))
+	if !ok {
+		return nil
+	}
+	tempo, ok := typedJSONDataBlock(block["tempo"])
+	if !ok {
+		return nil
+	}
+	link, ok := typedJSONDataBlock(tempo["traces_to_profiles"])
+	if !ok || link["datasource_uid"].(string) == "" {
+		return nil
+	}
+	client, _ := oapiClientFromResourceDiff(meta, d)
+	if client == nil {
+		return nil
+	}
+
+	uid := link["datasource_uid"].(string)
+	linked, err := getLinkedDatasource(client, uid)
+	if err != nil || linked == nil {
+		return nil
+	}
+	if linked.Type != "grafana-pyroscope-datasource" {
+		return fmt.Errorf("json_data.0.tempo.0.traces_to_profiles: the data source %q is of type `%s`, datasource_uid must reference a `grafana-pyroscope-datasource` data source", uid, linked.Type)
+	}
+	return nil
+}
+
+// tempoPrometheusLinks are the blocks of Tempo data sources that link to a Prometheus data source, with the JSON data key they're stored in.
+// The linked data source can be set by name, it's then resolved to its UID when applying.
+var tempoPrometheusLinks = []struct {
+	field string
+	key   string
+}{
+	{field: "service_map", key: "serviceMap"},
+	{field: "traces_to_metrics", key: "tracesToMetrics"},
+}
+
+// datasourceTempoLink returns the given block of Tempo data sources, if it is set.
+func datasourceTempoLink(jsonDataBlock interface{}, field string) (map[string]interface{}, bool) {
+	block, ok := typedJSONDataBlock(jsonDataBlock)
+	if !ok {
+		return nil, false
+	}
+	tempo, ok := typedJSONDataBlock(block["tempo"])
+	if !ok {
+		return nil, false
+	}
+	return typedJSONDataBlock(tempo[field])
+}
+
+// ValidateDatasourcePrometheusLink checks that a block of a Tempo data source (`service_map` or `traces_to_metrics`) is linked to a Prometheus data source.
+func ValidateDatasourcePrometheusLink(field, ref string, linked *models.DataSource) error {
+	if linked.Type != "prometheus" {
+		return fmt.Errorf("json_data.0.tempo.0.%s: the data source %q is of type `%s`, it must be a `prometheus` data source", field, ref, linked.Type)
+	}
+	return nil
+}
+
+// validateDatasourcePrometheusLinks checks the data sources linked to the service graph and the traces to metrics links of Tempo data sources at plan time.
+// Like with alertmanager_uid, the linked data sources may be created in the same apply, so only existing data sources are checked.
+func validateDatasourcePrometheusLinks(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("json_data") {
+		return nil
+	}
+	for _, l := range tempoPrometheusLinks {
+		link, ok := datasourceTempoLink(d.Get("json_data"), l.field)
+		if !ok {
+			continue
+		}
+		client, _ := oapiClientFromResourceDiff(meta, d)
+		if client == nil {
+			return nil
+		}
+
+		var linked *models.DataSource
+		var err error
+		ref := link["datasource_name"].(string)
+		if ref != "" {
+			linked, err = getLinkedDatasourceByName(client, ref)
+		} else if ref = link["datasource_uid"].(string); ref != "" {
+			linked, err = getLinkedDatasource(client, ref)
+		}
+		if err != nil || linked == nil {
+			continue
+		}
+		if err := ValidateDatasourcePrometheusLink(l.field, ref, linked); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveDatasourcePrometheusLinks sets the UID of the data sources linked to the service graph and the traces to metrics links of Tempo
+// data sources, when they're set by name.
+func resolveDatasourcePrometheusLinks(client *goapi.GrafanaHTTPAPI, d *schema.ResourceData, jsonData interface{}) error {
+	for _, l := range tempoPrometheusLinks {
+		link, ok := datasourceTempoLink(d.Get("json_data"), l.field)
+		if !ok || link["datasource_name"].(string) == "" {
+			continue
+		}
+		name := link["datasource_name"].(string)
+		linked, err := getLinkedDatasourceByName(client, name)
+		if err != nil {
+			return err
+		}
+		if linked == nil {
+			return fmt.Errorf("json_data.0.tempo.0.%s: the data source %q does not exist", l.field, name)
+		}
+		if err := ValidateDatasourcePrometheusLink(l.field, name, linked); err != nil {
+			return err
+		}
+		gfLink, ok := jsonData.(map[string]interface{})[l.key].(map[string]interface{})
+		if !ok {
+			gfLink = map[string]interface{}{}
+			jsonData.(map[string]interface{})[l.key] = gfLink
+		}
+		gfLink["datasourceUid"] = linked.UID
+	}
+	return nil
+}
+
+func datasourceAlertmanagerWarnings(client *goapi.GrafanaHTTPAPI, d *schema.ResourceData) diag.Diagnostics {
+	alertmanagerUID := datasourceManagedAlertmanagerUID(d.Get("json_data"))
+	if alertmanagerUID == "" {
+		return nil
+	}
+
+	alertmanager, err := getLinkedDatasource(client, alertmanagerUID)
+	if err == nil {
+		err = ValidateDatasourceAlertmanager(alertmanagerUID, alertmanager)
+	}
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "Alerts of the data source may not be delivered",
+			Detail:   err.Error(),
+		}}
+	}
+	return nil
+}
+
+// unpackDatasourceTypedJSONData merges the typed `json_data` block into the JSON data and secure JSON data sent to the API.
+func unpackDatasourceTypedJSONData(d *schema.ResourceData, jsonData map[string]interface{}, secureJSONData map[string]string) error {
+	block, ok := typedJSONDataBlock(d.Get("json_data"))
+	if !ok {
+		return nil
+	}
+
+	if err := unpackDatasourceHTTPClientSettings(block, jsonData, secureJSONData); err != nil {
+		return err
+	}
+	for _, t := range datasourceJSONDataTypes {
+		raw, ok := typedJSONDataBlock(block[t.meta().field])
+		if !ok {
+			continue
+		}
+		if err := t.unpack(raw, jsonData, secureJSONData); err != nil {
+			return fmt.Errorf("json_data.0.%s: %w", t.meta().field, err)
+		}
+	}
+	return nil
+}
+
+// packDatasourceTypedJSONData sets the typed `json_data` block from the JSON data returned by the API.
+// Only the blocks that are already in the state are read, so that users of `json_data_encoded` don't get a diff.
+// The keys consumed by the typed blocks are removed from the given JSON data.
+func packDatasourceTypedJSONData(d *schema.ResourceData, dsType string, jsonData map[string]interface{}) {
+	block, ok := typedJSONDataBlock(d.Get("json_data"))
+	if !ok {
+		return
+	}
+
+	packed := map[string]interface{}{}
+	packDatasourceHTTPClientSettings(jsonData, packed, block)
+	for _, t := range datasourceJSONDataTypes {
+		state, ok := typedJSONDataBlock(block[t.meta().field])
+		if !ok || !t.meta().supports(dsType) {
+			continue
+		}
+		packed[t.meta().field] = []interface{}{t.pack(jsonData, state)}
+	}
+	d.Set("json_data", []interface{}{packed})
+}
+
+func packJSONDataString(jsonData, tfSettings map[string]interface{}, gfKey, tfKey string) {
+	if v, ok := jsonData[gfKey].(string); ok {
+		tfSettings[tfKey] = v
+		delete(jsonData, gfKey)
+	}
+}
+
+func packJSONDataBool(jsonData, tfSettings map[string]interface{}, gfKey, tfKey string) {
+	if v, ok := jsonData[gfKey].(bool); ok {
+		tfSettings[tfKey] = v
+		delete(jsonData, gfKey)
+	}
+}
+
+func packJSONDataInt(jsonData, tfSettings map[string]interface{}, gfKey, tfKey string) {
+	if v, ok := jsonData[gfKey].(float64); ok {
+		tfSettings[tfKey] = int(v)
+		delete(jsonData, gfKey)
+	}
+}
+
+func packJSONDataStringList(jsonData, tfSettings map[string]interface{}, gfKey, tfKey string) {
+	if v, ok := jsonData[gfKey].([]interface{}); ok {
+		tfSettings[tfKey] = v
+		delete(jsonData, gfKey)
+	}
+}
+
+func unpackJSONDataString(tfSettings, jsonData map[string]interface{}, tfKey, gfKey string) {
+	if v, ok := tfSettings[tfKey].(string); ok && v != "" {
+		jsonData[gfKey] = v
+	}
+}
+
+func unpackJSONDataBool(tfSettings, jsonData map[string]interface{}, tfKey, gfKey string) {
+	if v, ok := tfSettings[tfKey].(bool); ok && v {
+		jsonData[gfKey] = v
+	}
+}
+
+func unpackJSONDataInt(tfSettings, jsonData map[string]interface{}, tfKey, gfKey string) {
+	if v, ok := tfSettings[tfKey].(int); ok && v != 0 {
+		jsonData[gfKey] = v
+	}
+}
+
+func unpackJSONDataStringList(tfSettings, jsonData map[string]interface{}, tfKey, gfKey string) {
+	if v, ok := tfSettings[tfKey].([]interface{}); ok && len(v) > 0 {
+		jsonData[gfKey] = common.ListToStringSlice(v)
+	}
+}
+
+func unpackSecureJSONDataString(tfSettings map[string]interface{}, secureJSONData map[string]string, tfKey, gfKey string) {
+	if v, ok := tfSettings[tfKey].(string); ok && v != "" {
+		secureJSONData[gfKey] = v
+	}
+}
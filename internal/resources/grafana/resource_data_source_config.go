@@ -2,7 +2,9 @@ package grafana
 
 import (
 	"context"
+	"fmt"
 	"strconv"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -21,7 +23,7 @@ func resourceDataSourceConfig() *common.Resource {
 	schema := &schema.Resource{
 		Description: resourceDataSourceConfigDescription,
 
-		CreateContext: UpdateDataSourceConfig,
+		CreateContext: CreateDataSourceConfig,
 		UpdateContext: UpdateDataSourceConfig,
 		ReadContext:   ReadDataSourceConfig,
 		DeleteContext: DeleteDataSourceConfig,
@@ -52,9 +54,58 @@ func resourceDataSourceConfig() *common.Resource {
 	)
 }
 
+// dataSourceConfigMarker is the JSON data key that `grafana_data_source_config` sets on the data sources it manages.
+// Two resources managing the config of the same data source would keep overwriting each other's changes, the marker
+// lets a resource detect that the config is already managed, possibly from another Terraform state.
+const dataSourceConfigMarker = "terraformManagedConfig"
+
+// dataSourceConfigCreateMutex serializes the ownership check and the write of the marker, for resources created in the same apply.
+// Resources created at the same time from separate applies aren't serialized.
+var dataSourceConfigCreateMutex sync.Mutex
+
+func CreateDataSourceConfig(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _ := OAPIClientFromNewOrgResource(meta, d)
+	uid := d.Get("uid").(string)
+
+	dataSourceConfigCreateMutex.Lock()
+	defer dataSourceConfigCreateMutex.Unlock()
+
+	resp, err := client.Datasources.GetDataSourceByUID(uid)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	original := resp.GetPayload()
+	if jsonData, ok := original.JSONData.(map[string]interface{}); ok && jsonData[dataSourceConfigMarker] != nil {
+		return diag.Errorf("the config of data source %q is already managed by another grafana_data_source_config resource. Import it instead of creating it", uid)
+	}
+
+	if diags := updateGrafanaDataSourceConfig(d, uid, client, true); diags.HasError() {
+		return rollbackDataSourceConfigCreation(client, original, d, diags)
+	}
+	return ReadDataSourceConfig(ctx, d, meta)
+}
+
+// rollbackDataSourceConfigCreation restores the JSON data of a data source whose config couldn't be written.
+// This removes the marker, so that creating the resource can be retried.
+func rollbackDataSourceConfigCreation(client *goapi.GrafanaHTTPAPI, original *models.DataSource, d *schema.ResourceData, diags diag.Diagnostics) diag.Diagnostics {
+	resp, err := client.Datasources.GetDataSourceByUID(original.UID)
+	if err == nil {
+		_, err = client.Datasources.UpdateDataSourceByUID(original.UID, dataSourceConfigUpdateCommand(resp.GetPayload(), original.JSONData, nil))
+	}
+	if err != nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("failed to restore the JSON data of data source %q after its config failed", original.UID),
+			Detail:   fmt.Sprintf("Remove the %q key from its JSON data before retrying: %s", dataSourceConfigMarker, err),
+		})
+	}
+	d.SetId("")
+	return diags
+}
+
 func UpdateDataSourceConfig(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _ := OAPIClientFromNewOrgResource(meta, d)
-	if diag := updateGrafanaDataSourceConfig(d, d.Get("uid").(string), client); diag.HasError() {
+	if diag := updateGrafanaDataSourceConfig(d, d.Get("uid").(string), client, true); diag.HasError() {
 		return diag
 	}
 	return ReadDataSourceConfig(ctx, d, meta)
@@ -76,10 +127,12 @@ func ReadDataSourceConfig(ctx context.Context, d *schema.ResourceData, meta inte
 func DeleteDataSourceConfig(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _, idStr := OAPIClientFromExistingOrgResource(meta, d.Id())
 	d.Set("json_data_encoded", "")
-	return updateGrafanaDataSourceConfig(d, idStr, client)
+	return updateGrafanaDataSourceConfig(d, idStr, client, false)
 }
 
-func updateGrafanaDataSourceConfig(d *schema.ResourceData, dataSourceUID string, client *goapi.GrafanaHTTPAPI) diag.Diagnostics {
+// updateGrafanaDataSourceConfig writes the config of the resource to the data source. If managed is true, the data source is marked as
+// managed by a `grafana_data_source_config` resource.
+func updateGrafanaDataSourceConfig(d *schema.ResourceData, dataSourceUID string, client *goapi.GrafanaHTTPAPI, managed bool) diag.Diagnostics {
 	resp, err := client.Datasources.GetDataSourceByUID(dataSourceUID)
 	if err != nil {
 		return diag.FromErr(err)
@@ -91,8 +144,17 @@ func updateGrafanaDataSourceConfig(d *schema.ResourceData, dataSourceUID string,
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if managed {
+		jd[dataSourceConfigMarker] = true
+	}
 
-	body := models.UpdateDataSourceCommand{
+	_, err = client.Datasources.UpdateDataSourceByUID(dataSourceUID, dataSourceConfigUpdateCommand(ds, jd, sd))
+	return diag.FromErr(err)
+}
+
+// dataSourceConfigUpdateCommand returns the update of a data source that only changes its config.
+func dataSourceConfigUpdateCommand(ds *models.DataSource, jsonData interface{}, secureJSONData map[string]string) *models.UpdateDataSourceCommand {
+	return &models.UpdateDataSourceCommand{
 		Access:          ds.Access,
 		BasicAuth:       ds.BasicAuth,
 		BasicAuthUser:   ds.BasicAuthUser,
@@ -104,9 +166,24 @@ func updateGrafanaDataSourceConfig(d *schema.ResourceData, dataSourceUID string,
 		URL:             ds.URL,
 		User:            ds.User,
 		WithCredentials: ds.WithCredentials,
-		JSONData:        jd,
-		SecureJSONData:  sd,
+		JSONData:        jsonData,
+		SecureJSONData:  secureJSONData,
 	}
-	_, err = client.Datasources.UpdateDataSourceByUID(dataSourceUID, &body)
-	return diag.FromErr(err)
+}
+
+// keepDataSourceConfigMarker copies the marker of a data source into the JSON data that `grafana_data_source` writes.
+// The marker is hidden from its `json_data_encoded`, so the config would otherwise be unmarked by any update of the data source.
+func keepDataSourceConfigMarker(client *goapi.GrafanaHTTPAPI, uid string, jsonData models.JSON) error {
+	jd, ok := jsonData.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	resp, err := client.Datasources.GetDataSourceByUID(uid)
+	if err != nil {
+		return err
+	}
+	if current, ok := resp.GetPayload().JSONData.(map[string]interface{}); ok && current[dataSourceConfigMarker] != nil {
+		jd[dataSourceConfigMarker] = current[dataSourceConfigMarker]
+	}
+	return nil
 }
@@ -1,14 +1,30 @@
 package grafana_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
 	"reflect"
 	"regexp"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/resources/grafana"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -101,6 +117,441 @@ func TestAccDataSource_Loki(t *testing.T) {
 	})
 }
 
+func TestAccDataSource_LokiTypedJSONData(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var dataSource models.DataSource
+	dsName := acctest.RandString(10)
+
+	config := func(loki string) string {
+		return fmt.Sprintf(`
+		resource "grafana_data_source" "loki" {
+			type = "loki"
+			name = "%s"
+			url  = "http://acc-test.invalid/"
+			json_data_encoded = jsonencode({
+				derivedFields = [{
+					name         = "traceID"
+					matcherRegex = "traceID=(\\w+)"
+					url          = "$${__value.raw}"
+				}]
+			})
+
+			json_data {
+				loki {
+					%s
+				}
+			}
+		}`, dsName, loki)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: config(`
+					keep_cookies  = ["session", "csrf"]
+					manage_alerts = true`),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.loki", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.loki", "json_data.0.loki.0.keep_cookies.#", "2"),
+					resource.TestCheckResourceAttr("grafana_data_source.loki", "json_data.0.loki.0.keep_cookies.0", "session"),
+					resource.TestCheckResourceAttr("grafana_data_source.loki", "json_data.0.loki.0.manage_alerts", "true"),
+					func(s *terraform.State) error {
+						jsonData := dataSource.JSONData.(map[string]interface{})
+						if !reflect.DeepEqual(jsonData["keepCookies"], []interface{}{"session", "csrf"}) {
+							return fmt.Errorf("bad keepCookies: %#v", jsonData["keepCookies"])
+						}
+						if jsonData["manageAlerts"] != true {
+							return fmt.Errorf("bad manageAlerts: %#v", jsonData["manageAlerts"])
+						}
+						if jsonData["derivedFields"] == nil {
+							return fmt.Errorf("expected derived fields")
+						}
+						return nil
+					},
+				),
+			},
+			// Imported data sources have all of their JSON data in json_data_encoded
+			{
+				ResourceName: "grafana_data_source.loki",
+				ImportState:  true,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					var jsonData map[string]interface{}
+					if err := json.Unmarshal([]byte(states[0].Attributes["json_data_encoded"]), &jsonData); err != nil {
+						return err
+					}
+					if !reflect.DeepEqual(jsonData["keepCookies"], []interface{}{"session", "csrf"}) || jsonData["manageAlerts"] != true || jsonData["derivedFields"] == nil {
+						return fmt.Errorf("bad imported json_data_encoded: %#v", jsonData)
+					}
+					return nil
+				},
+			},
+			// Unset fields are left out of the JSON data
+			{
+				Config: config(`keep_cookies = ["session"]`),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.loki", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.loki", "json_data.0.loki.0.manage_alerts", "false"),
+					func(s *terraform.State) error {
+						if _, ok := dataSource.JSONData.(map[string]interface{})["manageAlerts"]; ok {
+							return fmt.Errorf("expected manageAlerts to be unset, got json data: %#v", dataSource.JSONData)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:   config(`keep_cookies = ["session"]`),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccDataSource_LokiDerivedFields(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var dataSource models.DataSource
+	dsName := acctest.RandString(10)
+
+	config := func(derivedFields string) string {
+		return fmt.Sprintf(`
+		resource "grafana_data_source" "tempo" {
+			type = "tempo"
+			name = "%[1]s-tempo"
+			url  = "http://acc-test.invalid/"
+		}
+
+		resource "grafana_data_source" "loki" {
+			type = "loki"
+			name = "%[1]s-loki"
+			url  = "http://acc-test.invalid/"
+
+			json_data {
+				loki {
+					%[2]s
+				}
+			}
+		}`, dsName, derivedFields)
+	}
+	derivedFields := `
+		derived_field {
+			name           = "TraceID"
+			matcher_type   = "label"
+			matcher_regex  = "trace_id"
+			url            = "$${__value.raw}"
+			datasource_uid = grafana_data_source.tempo.uid
+		}
+		derived_field {
+			name              = "Order"
+			matcher_regex     = "order=(\\w+)"
+			url               = "https://orders.acc-test.invalid/$${__value.raw}"
+			url_display_label = "Open order"
+			target_blank      = true
+		}`
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: config(derivedFields),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.loki", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.loki", "json_data.0.loki.0.derived_field.#", "2"),
+					resource.TestCheckResourceAttrPair("grafana_data_source.loki", "json_data.0.loki.0.derived_field.0.datasource_uid", "grafana_data_source.tempo", "uid"),
+					resource.TestCheckResourceAttr("grafana_data_source.loki", "json_data.0.loki.0.derived_field.1.matcher_type", "regex"),
+					resource.TestCheckResourceAttr("grafana_data_source.loki", "json_data.0.loki.0.derived_field.1.target_blank", "true"),
+					func(s *terraform.State) error {
+						tempoUID := s.RootModule().Resources["grafana_data_source.tempo"].Primary.Attributes["uid"]
+						expected := []interface{}{
+							map[string]interface{}{
+								"name":          "TraceID",
+								"matcherType":   "label",
+								"matcherRegex":  "trace_id",
+								"url":           "${__value.raw}",
+								"datasourceUid": tempoUID,
+							},
+							map[string]interface{}{
+								"name":            "Order",
+								"matcherType":     "regex",
+								"matcherRegex":    `order=(\w+)`,
+								"url":             "https://orders.acc-test.invalid/${__value.raw}",
+								"urlDisplayLabel": "Open order",
+								"targetBlank":     true,
+							},
+						}
+						if derivedFields := dataSource.JSONData.(map[string]interface{})["derivedFields"]; !reflect.DeepEqual(derivedFields, expected) {
+							return fmt.Errorf("bad derivedFields: %#v", derivedFields)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:   config(derivedFields),
+				PlanOnly: true,
+			},
+			{
+				Config: config(`
+					derived_field {
+						name           = "TraceID"
+						matcher_regex  = "traceID=(\\w+)"
+						url            = "$${__value.raw}"
+						datasource_uid = grafana_data_source.tempo.uid
+						target_blank   = true
+					}`),
+				ExpectError: regexp.MustCompile("derived_field.0: `target_blank` can only be set on external links, without `datasource_uid`"),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_ExploreDefaults(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var loki, prometheus models.DataSource
+	dsName := acctest.RandString(10)
+
+	config := fmt.Sprintf(`
+	resource "grafana_data_source" "loki" {
+		type = "loki"
+		name = "%[1]s-loki"
+		url  = "http://acc-test.invalid/"
+
+		json_data {
+			loki {
+				max_lines = 2500
+			}
+		}
+	}
+
+	resource "grafana_data_source" "prometheus" {
+		type = "prometheus"
+		name = "%[1]s-prometheus"
+		url  = "http://acc-test.invalid/"
+
+		json_data {
+			prometheus {
+				default_editor = "code"
+				cache_level    = "High"
+			}
+		}
+	}`, dsName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&loki, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.loki", &loki),
+					datasourceCheckExists.exists("grafana_data_source.prometheus", &prometheus),
+					resource.TestCheckResourceAttr("grafana_data_source.loki", "json_data.0.loki.0.max_lines", "2500"),
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "json_data.0.prometheus.0.default_editor", "code"),
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "json_data.0.prometheus.0.cache_level", "High"),
+					func(s *terraform.State) error {
+						// Stored like Grafana's UI stores them
+						if maxLines := loki.JSONData.(map[string]interface{})["maxLines"]; maxLines != "2500" {
+							return fmt.Errorf("expected maxLines to be \"2500\", got %#v", maxLines)
+						}
+						jsonData := prometheus.JSONData.(map[string]interface{})
+						if jsonData["defaultEditor"] != "code" || jsonData["cacheLevel"] != "High" {
+							return fmt.Errorf("bad prometheus json data: %#v", jsonData)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				Config:      strings.Replace(config, "max_lines = 2500", "max_lines = 0", 1),
+				ExpectError: regexp.MustCompile(`expected json_data.0.loki.0.max_lines to be in the range \(1 - 100000\)`),
+			},
+			{
+				Config:      strings.Replace(config, `default_editor = "code"`, `default_editor = "text"`, 1),
+				ExpectError: regexp.MustCompile(`expected json_data.0.prometheus.0.default_editor to be one of`),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_LokiTenantID(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var dataSource models.DataSource
+	dsName := acctest.RandString(10)
+
+	config := func(tenantID string) string {
+		return fmt.Sprintf(`
+		resource "grafana_data_source" "loki" {
+			type      = "loki"
+			name      = "%s"
+			url       = "http://acc-test.invalid/"
+			tenant_id = "%s"
+		}`, dsName, tenantID)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: config("tenant-1"),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.loki", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.loki", "tenant_id", "tenant-1"),
+					resource.TestCheckResourceAttr("grafana_data_source.loki", "json_data_encoded", "{}"),
+					resource.TestCheckNoResourceAttr("grafana_data_source.loki", "http_headers.X-Scope-OrgID"),
+					func(s *terraform.State) error {
+						if name := dataSource.JSONData.(map[string]interface{})["httpHeaderName1"]; name != "X-Scope-OrgID" {
+							return fmt.Errorf("expected the X-Scope-OrgID header, got %v", name)
+						}
+						if !dataSource.SecureJSONFields["httpHeaderValue1"] {
+							return fmt.Errorf("expected the header value to be set")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: config("tenant-2"),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.loki", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.loki", "tenant_id", "tenant-2"),
+				),
+			},
+			{
+				Config: `
+				resource "grafana_data_source" "influx" {
+					type      = "influxdb"
+					name      = "anything"
+					tenant_id = "tenant-1"
+				}`,
+				ExpectError: regexp.MustCompile("tenant_id can only be used with data sources of type"),
+			},
+			{
+				Config: `
+				resource "grafana_data_source" "loki" {
+					type      = "loki"
+					name      = "anything"
+					tenant_id = "tenant-1"
+					http_headers = {
+						"x-scope-orgid" = "tenant-2"
+					}
+				}`,
+				ExpectError: regexp.MustCompile("the X-Scope-OrgID header is set by tenant_id"),
+			},
+		},
+	})
+}
+
+func TestReconcileDatasourceIsDefault(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	for _, tc := range []struct {
+		name              string
+		stateIsDefault    bool
+		force             bool
+		isDefault         bool
+		expectedIsDefault bool
+		expectWarning     bool
+	}{
+		{name: "not default", expectedIsDefault: false},
+		{name: "made default outside of Terraform", isDefault: true, expectedIsDefault: true},
+		{name: "still default", stateIsDefault: true, isDefault: true, expectedIsDefault: true},
+		// Two data sources set is_default: the one applied last took the default from this one
+		{name: "default taken by another data source", stateIsDefault: true, expectedIsDefault: true, expectWarning: true},
+		{name: "default taken by another data source, forced", stateIsDefault: true, force: true, expectedIsDefault: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			isDefault, diags := grafana.ReconcileDatasourceIsDefault("test", tc.stateIsDefault, tc.force, tc.isDefault)
+			if isDefault != tc.expectedIsDefault {
+				t.Errorf("expected is_default = %t, got %t", tc.expectedIsDefault, isDefault)
+			}
+			if hasWarning := len(diags) > 0; hasWarning != tc.expectWarning {
+				t.Errorf("expected a warning: %t, got %v", tc.expectWarning, diags)
+			}
+			if diags.HasError() {
+				t.Errorf("expected no errors, got %v", diags)
+			}
+		})
+	}
+}
+
+func TestReconcileDatasourceSecureJSONData(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	for _, tc := range []struct {
+		name             string
+		secureJSONData   string
+		secureJSONFields map[string]bool
+		expected         string
+		expectWarning    bool
+	}{
+		{name: "no secure JSON data", secureJSONData: "", expected: ""},
+		{name: "all set", secureJSONData: `{"password":"secret","token":"abc"}`, secureJSONFields: map[string]bool{"password": true, "token": true}, expected: `{"password":"secret","token":"abc"}`},
+		{name: "set outside of Terraform", secureJSONData: `{"password":"secret"}`, secureJSONFields: map[string]bool{"password": true, "token": true}, expected: `{"password":"secret"}`},
+		{name: "deleted outside of Terraform", secureJSONData: `{"password":"secret","token":"abc"}`, secureJSONFields: map[string]bool{"password": true}, expected: `{"password":"secret"}`, expectWarning: true},
+		{name: "reported as unset", secureJSONData: `{"password":"secret"}`, secureJSONFields: map[string]bool{"password": false}, expected: `{}`, expectWarning: true},
+		{name: "no secure JSON fields", secureJSONData: `{"password":"secret"}`, expected: `{}`, expectWarning: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dataSource := &models.DataSource{Name: "test", SecureJSONFields: tc.secureJSONFields}
+			actual, diags := grafana.ReconcileDatasourceSecureJSONData(tc.secureJSONData, dataSource)
+			if actual != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, actual)
+			}
+			if hasWarning := len(diags) > 0; hasWarning != tc.expectWarning {
+				t.Errorf("expected a warning: %t, got %v", tc.expectWarning, diags)
+			}
+			if diags.HasError() {
+				t.Errorf("expected no errors, got %v", diags)
+			}
+		})
+	}
+}
+
+func TestValidateDatasourceAlertmanager(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	for _, tc := range []struct {
+		name         string
+		alertmanager *models.DataSource
+		expectedErr  string
+	}{
+		{
+			name:         "alertmanager",
+			alertmanager: &models.DataSource{UID: "am", Type: "alertmanager"},
+		},
+		{
+			name:        "missing",
+			expectedErr: `the alertmanager data source "am" does not exist`,
+		},
+		{
+			name:         "not an alertmanager",
+			alertmanager: &models.DataSource{UID: "am", Type: "loki"},
+			expectedErr:  "the data source \"am\" is of type `loki`, alertmanager_uid must reference an `alertmanager` data source",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := grafana.ValidateDatasourceAlertmanager("am", tc.alertmanager)
+			if tc.expectedErr == "" && err != nil {
+				t.Errorf("expected no error, got %s", err)
+			}
+			if tc.expectedErr != "" && (err == nil || err.Error() != tc.expectedErr) {
+				t.Errorf("expected error %q, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
 func TestAccDataSource_TestData(t *testing.T) {
 	testutils.CheckOSSTestsEnabled(t)
 
@@ -151,6 +602,55 @@ func TestAccDataSource_TestData(t *testing.T) {
 	})
 }
 
+func TestAccDataSource_importByName(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var dataSource models.DataSource
+	dsName := "My DS " + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "grafana_data_source" "x" {
+					type = "grafana-testdata-datasource"
+					name = "%s"
+				}`, dsName),
+				Check: datasourceCheckExists.exists("grafana_data_source.x", &dataSource),
+			},
+			// By name, the state is the same as when importing by UID
+			{
+				ResourceName:            "grafana_data_source.x",
+				ImportState:             true,
+				ImportStateId:           dsName,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secure_json_data_encoded", "http_headers."},
+			},
+			{
+				ResourceName:            "grafana_data_source.x",
+				ImportState:             true,
+				ImportStateId:           "1:" + dsName,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secure_json_data_encoded", "http_headers."},
+			},
+			{
+				ResourceName:            "grafana_data_source.x",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secure_json_data_encoded", "http_headers."},
+			},
+			{
+				ResourceName:  "grafana_data_source.x",
+				ImportState:   true,
+				ImportStateId: "Missing " + dsName,
+				ExpectError:   regexp.MustCompile(`no data source found with UID or name "Missing ` + dsName + `"`),
+			},
+		},
+	})
+}
+
 func TestAccDataSource_Influx(t *testing.T) {
 	testutils.CheckOSSTestsEnabled(t)
 
@@ -400,6 +900,8 @@ func TestAccDataSource_SeparateConfig(t *testing.T) {
 							"tlsAuthWithCACert": false,
 							"version":           "Flux",
 							"httpHeaderName1":   "Authorization",
+							// Marks the data source as managed by grafana_data_source_config
+							"terraformManagedConfig": true,
 						}
 						jsonData := dataSource.JSONData.(map[string]interface{})
 						if !reflect.DeepEqual(jsonData, expected) {
@@ -425,6 +927,2165 @@ func TestAccDataSource_SeparateConfig(t *testing.T) {
 	})
 }
 
+func TestAccDataSource_SeparateConfigConflict(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=v9.0.0")
+
+	var dataSource models.DataSource
+
+	dsName := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "grafana_data_source" "influx" {
+					type = "influxdb"
+					name = "%s"
+					url  = "http://acc-test.invalid/"
+
+					lifecycle {
+						ignore_changes = [json_data_encoded, http_headers]
+					}
+				}
+
+				resource "grafana_data_source_config" "first" {
+					uid               = grafana_data_source.influx.uid
+					json_data_encoded = jsonencode({ defaultBucket = "first" })
+				}
+
+				resource "grafana_data_source_config" "second" {
+					uid               = grafana_data_source.influx.uid
+					json_data_encoded = jsonencode({ defaultBucket = "second" })
+				}`, dsName),
+				ExpectError: regexp.MustCompile(`the config of data source ".+" is already managed by another grafana_data_source_config resource`),
+			},
+		},
+	})
+}
+
+// The config of a data source managed from another Terraform state can't be taken over
+func TestAccDataSource_SeparateConfigConflictOtherState(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=v9.0.0")
+
+	var dataSource models.DataSource
+
+	dsName := acctest.RandString(10)
+	config := fmt.Sprintf(`
+	resource "grafana_data_source" "influx" {
+		type = "influxdb"
+		name = "%s"
+		url  = "http://acc-test.invalid/"
+
+		lifecycle {
+			ignore_changes = [json_data_encoded, http_headers]
+		}
+	}`, dsName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  datasourceCheckExists.exists("grafana_data_source.influx", &dataSource),
+			},
+			{
+				PreConfig: func() {
+					// Manage the config from "another state"
+					_, err := grafanaTestClient().Datasources.UpdateDataSourceByUID(dataSource.UID, &models.UpdateDataSourceCommand{
+						Access:   dataSource.Access,
+						Name:     dataSource.Name,
+						Type:     dataSource.Type,
+						UID:      dataSource.UID,
+						URL:      dataSource.URL,
+						JSONData: map[string]interface{}{"terraformManagedConfig": true},
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config + `
+				resource "grafana_data_source_config" "influx" {
+					uid               = grafana_data_source.influx.uid
+					json_data_encoded = jsonencode({ defaultBucket = "telegraf" })
+				}`,
+				ExpectError: regexp.MustCompile(`the config of data source ".+" is already managed by another grafana_data_source_config resource`),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_VertamediaClickHouse(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+	checkPluginInstalled(t, "vertamedia-clickhouse-datasource")
+
+	var dataSource models.DataSource
+	dsName := acctest.RandString(10)
+
+	config := func(database string) string {
+		return fmt.Sprintf(`
+	resource "grafana_data_source" "clickhouse" {
+		type                = "vertamedia-clickhouse-datasource"
+		name                = "%s"
+		url                 = "http://acc-test.invalid:8123/"
+		basic_auth_enabled  = true
+		basic_auth_username = "default"
+
+		json_data {
+			vertamedia_clickhouse {
+				default_database = "%s"
+				add_cors_header  = true
+				use_compression  = true
+				compression_type = "gzip"
+				password         = "secret"
+			}
+		}
+	}`, dsName, database)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: config("logs"),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.clickhouse", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.clickhouse", "json_data.0.vertamedia_clickhouse.0.default_database", "logs"),
+					resource.TestCheckResourceAttr("grafana_data_source.clickhouse", "json_data.0.vertamedia_clickhouse.0.compression_type", "gzip"),
+					resource.TestCheckResourceAttr("grafana_data_source.clickhouse", "json_data_encoded", "{}"),
+					func(s *terraform.State) error {
+						expected := map[string]interface{}{
+							"defaultDatabase": "logs",
+							"addCorsHeader":   true,
+							"useCompression":  true,
+							"compressionType": "gzip",
+						}
+						if !reflect.DeepEqual(dataSource.JSONData, expected) {
+							return fmt.Errorf("bad json data: %#v. Expected: %+v", dataSource.JSONData, expected)
+						}
+						if !dataSource.SecureJSONFields["basicAuthPassword"] {
+							return fmt.Errorf("basicAuthPassword not set")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: config("metrics"),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.clickhouse", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.clickhouse", "json_data.0.vertamedia_clickhouse.0.default_database", "metrics"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_VertamediaClickHouseValidation(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "grafana_data_source" "clickhouse" {
+					type = "vertamedia-clickhouse-datasource"
+					name = "anything"
+					url  = "http://acc-test.invalid:8123/"
+					json_data {
+						vertamedia_clickhouse {
+							use_compression = true
+						}
+					}
+				}`,
+				ExpectError: regexp.MustCompile("`compression_type` is required when `use_compression` is enabled"),
+			},
+			{
+				Config: `
+				resource "grafana_data_source" "clickhouse" {
+					type = "prometheus"
+					name = "anything"
+					url  = "http://acc-test.invalid:8123/"
+					json_data {
+						vertamedia_clickhouse {
+							default_database = "logs"
+						}
+					}
+				}`,
+				ExpectError: regexp.MustCompile(`json_data.0.vertamedia_clickhouse can only be used with data sources of type`),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_PrometheusAzureAD(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var dataSource models.DataSource
+	dsName := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "grafana_data_source" "prometheus" {
+					type = "prometheus"
+					name = "%s"
+					url  = "https://acc-test.prometheus.monitor.azure.com"
+
+					json_data {
+						prometheus {
+							http_method = "POST"
+							azure_credentials {
+								auth_type     = "clientsecret"
+								tenant_id     = "00000000-0000-0000-0000-000000000001"
+								client_id     = "00000000-0000-0000-0000-000000000002"
+								client_secret = "secret"
+								resource_id   = "https://prometheus.monitor.azure.com"
+							}
+						}
+					}
+				}`, dsName),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.prometheus", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "json_data.0.prometheus.0.azure_credentials.0.auth_type", "clientsecret"),
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "json_data.0.prometheus.0.azure_credentials.0.cloud", "AzureCloud"),
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "json_data.0.prometheus.0.azure_credentials.0.client_secret", "secret"),
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "json_data_encoded", "{}"),
+					func(s *terraform.State) error {
+						expected := map[string]interface{}{
+							"httpMethod":              "POST",
+							"azureEndpointResourceId": "https://prometheus.monitor.azure.com",
+							"azureCredentials": map[string]interface{}{
+								"authType":   "clientsecret",
+								"azureCloud": "AzureCloud",
+								"tenantId":   "00000000-0000-0000-0000-000000000001",
+								"clientId":   "00000000-0000-0000-0000-000000000002",
+							},
+						}
+						if !reflect.DeepEqual(dataSource.JSONData, expected) {
+							return fmt.Errorf("bad json data: %#v. Expected: %+v", dataSource.JSONData, expected)
+						}
+						if !dataSource.SecureJSONFields["azureClientSecret"] {
+							return fmt.Errorf("azureClientSecret not set")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: `
+				resource "grafana_data_source" "prometheus" {
+					type = "prometheus"
+					name = "anything"
+					json_data {
+						prometheus {
+							azure_credentials {
+								auth_type = "clientsecret"
+								tenant_id = "00000000-0000-0000-0000-000000000001"
+							}
+						}
+					}
+				}`,
+				ExpectError: regexp.MustCompile("`client_id` is required with `clientsecret` authentication"),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_PrometheusSigV4(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var dataSource models.DataSource
+	dsName := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "grafana_data_source" "prometheus" {
+					type = "prometheus"
+					name = "%s"
+					url  = "https://aps-workspaces.us-east-1.amazonaws.com/workspaces/ws-acc-test"
+
+					json_data {
+						prometheus {
+							sigv4 {
+								auth_type     = "keys"
+								region        = "us-east-1"
+								access_key    = "ASIAACCTEST"
+								secret_key    = "secret"
+								session_token = "session-token"
+							}
+						}
+					}
+				}`, dsName),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.prometheus", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "json_data.0.prometheus.0.sigv4.0.auth_type", "keys"),
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "json_data.0.prometheus.0.sigv4.0.region", "us-east-1"),
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "json_data.0.prometheus.0.sigv4.0.session_token", "session-token"),
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "json_data_encoded", "{}"),
+					func(s *terraform.State) error {
+						expected := map[string]interface{}{
+							"sigV4Auth":     true,
+							"sigV4AuthType": "keys",
+							"sigV4Region":   "us-east-1",
+						}
+						if !reflect.DeepEqual(dataSource.JSONData, expected) {
+							return fmt.Errorf("bad json data: %#v. Expected: %+v", dataSource.JSONData, expected)
+						}
+						for _, field := range []string{"sigV4AccessKey", "sigV4SecretKey", "sigV4SessionToken"} {
+							if !dataSource.SecureJSONFields[field] {
+								return fmt.Errorf("%s not set", field)
+							}
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "grafana_data_source.prometheus",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Secrets can't be read back
+				ImportStateVerifyIgnore: []string{
+					"json_data.0.prometheus.0.sigv4.0.access_key",
+					"json_data.0.prometheus.0.sigv4.0.secret_key",
+					"json_data.0.prometheus.0.sigv4.0.session_token",
+				},
+			},
+			{
+				Config: `
+				resource "grafana_data_source" "prometheus" {
+					type = "prometheus"
+					name = "anything"
+					json_data {
+						prometheus {
+							sigv4 {
+								auth_type     = "default"
+								region        = "us-east-1"
+								session_token = "session-token"
+							}
+						}
+					}
+				}`,
+				ExpectError: regexp.MustCompile("`session_token` can only be used with `keys` authentication"),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_PrometheusQueryOptions(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var dataSource models.DataSource
+	dsName := acctest.RandString(10)
+	config := fmt.Sprintf(`
+	resource "grafana_data_source" "prometheus" {
+		type = "prometheus"
+		name = "%s"
+		url  = "http://prometheus:9090"
+
+		json_data {
+			prometheus {
+				time_interval           = "30s"
+				custom_query_parameters = "max_source_resolution=5m&timeout=10"
+				keep_cookies            = ["session"]
+				disable_metrics_lookup  = true
+			}
+		}
+	}`, dsName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.prometheus", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "json_data.0.prometheus.0.time_interval", "30s"),
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "json_data.0.prometheus.0.custom_query_parameters", "max_source_resolution=5m&timeout=10"),
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "json_data.0.prometheus.0.keep_cookies.#", "1"),
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "json_data_encoded", "{}"),
+					func(s *terraform.State) error {
+						expected := map[string]interface{}{
+							"timeInterval":          "30s",
+							"customQueryParameters": "max_source_resolution=5m&timeout=10",
+							"keepCookies":           []interface{}{"session"},
+							"disableMetricsLookup":  true,
+						}
+						if !reflect.DeepEqual(dataSource.JSONData, expected) {
+							return fmt.Errorf("bad json data: %#v. Expected: %+v", dataSource.JSONData, expected)
+						}
+						return nil
+					},
+				),
+			},
+			// The parameters read back from Grafana must match the configured string
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				Config: `
+				resource "grafana_data_source" "prometheus" {
+					type = "prometheus"
+					name = "anything"
+					json_data {
+						prometheus {
+							custom_query_parameters = "a=%zz"
+						}
+					}
+				}`,
+				ExpectError: regexp.MustCompile("is not a valid URL query string"),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_PrometheusRuler(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var dataSource models.DataSource
+	dsName := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "grafana_data_source" "mimir" {
+					type = "prometheus"
+					name = "%s"
+					url  = "http://mimir-query-frontend:8080/prometheus"
+
+					json_data {
+						prometheus {
+							ruler {
+								url                 = "http://mimir-ruler:8080"
+								basic_auth_username = "ruler"
+								basic_auth_password = "secret"
+							}
+						}
+					}
+				}`, dsName),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.mimir", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.mimir", "url", "http://mimir-query-frontend:8080/prometheus"),
+					resource.TestCheckResourceAttr("grafana_data_source.mimir", "json_data.0.prometheus.0.ruler.0.url", "http://mimir-ruler:8080"),
+					resource.TestCheckResourceAttr("grafana_data_source.mimir", "json_data.0.prometheus.0.ruler.0.basic_auth_password", "secret"),
+					resource.TestCheckResourceAttr("grafana_data_source.mimir", "json_data_encoded", "{}"),
+					func(s *terraform.State) error {
+						expected := map[string]interface{}{
+							"rulerUrl":           "http://mimir-ruler:8080",
+							"rulerBasicAuthUser": "ruler",
+						}
+						if !reflect.DeepEqual(dataSource.JSONData, expected) {
+							return fmt.Errorf("bad json data: %#v. Expected: %+v", dataSource.JSONData, expected)
+						}
+						if !dataSource.SecureJSONFields["rulerBasicAuthPassword"] {
+							return fmt.Errorf("rulerBasicAuthPassword not set")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: `
+				resource "grafana_data_source" "mimir" {
+					type = "prometheus"
+					name = "anything"
+					json_data {
+						prometheus {
+							ruler {
+								url = "not a url"
+							}
+						}
+					}
+				}`,
+				ExpectError: regexp.MustCompile(`expected "json_data.0.prometheus.0.ruler.0.url" to have a host`),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_PrometheusCloudEndpoints(t *testing.T) {
+	testutils.CheckCloudInstanceTestsEnabled(t)
+
+	var dataSource models.DataSource
+	dsName := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "grafana_data_source" "mimir" {
+					type = "prometheus"
+					name = "%s"
+					url  = "https://prometheus-query.example.com/api/prom"
+
+					json_data {
+						prometheus {
+							ruler {
+								url                 = "https://prometheus-ruler.example.com"
+								basic_auth_username = "123456"
+								basic_auth_password = "ruler-token"
+							}
+							remote_write {
+								url                 = "https://prometheus-write.example.com/api/prom/push"
+								basic_auth_username = "654321"
+								basic_auth_password = "write-token"
+							}
+						}
+					}
+				}`, dsName),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.mimir", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.mimir", "json_data.0.prometheus.0.ruler.0.url", "https://prometheus-ruler.example.com"),
+					resource.TestCheckResourceAttr("grafana_data_source.mimir", "json_data.0.prometheus.0.remote_write.0.url", "https://prometheus-write.example.com/api/prom/push"),
+					resource.TestCheckResourceAttr("grafana_data_source.mimir", "json_data.0.prometheus.0.remote_write.0.basic_auth_password", "write-token"),
+					resource.TestCheckResourceAttr("grafana_data_source.mimir", "json_data_encoded", "{}"),
+					func(s *terraform.State) error {
+						expected := map[string]interface{}{
+							"rulerUrl":                 "https://prometheus-ruler.example.com",
+							"rulerBasicAuthUser":       "123456",
+							"remoteWriteUrl":           "https://prometheus-write.example.com/api/prom/push",
+							"remoteWriteBasicAuthUser": "654321",
+						}
+						if !reflect.DeepEqual(dataSource.JSONData, expected) {
+							return fmt.Errorf("bad json data: %#v. Expected: %+v", dataSource.JSONData, expected)
+						}
+						for _, field := range []string{"rulerBasicAuthPassword", "remoteWriteBasicAuthPassword"} {
+							if !dataSource.SecureJSONFields[field] {
+								return fmt.Errorf("%s not set", field)
+							}
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: `
+				resource "grafana_data_source" "mimir" {
+					type = "prometheus"
+					name = "anything"
+					json_data {
+						prometheus {
+							remote_write {
+								url                 = "https://prometheus-write.example.com/api/prom/push"
+								basic_auth_password = "write-token"
+							}
+						}
+					}
+				}`,
+				ExpectError: regexp.MustCompile("remote_write: `basic_auth_password` requires `basic_auth_username` to be set"),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_FalconLogScale(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+	checkPluginInstalled(t, "grafana-falconlogscale-datasource")
+
+	var dataSource models.DataSource
+	dsName := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "grafana_data_source" "logscale" {
+					type = "grafana-falconlogscale-datasource"
+					name = "%s"
+
+					json_data {
+						falcon_logscale {
+							base_url           = "https://cloud.community.humio.com"
+							default_repository = "sandbox"
+							token              = "logscale-token"
+						}
+					}
+				}`, dsName),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.logscale", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.logscale", "json_data.0.falcon_logscale.0.base_url", "https://cloud.community.humio.com"),
+					resource.TestCheckResourceAttr("grafana_data_source.logscale", "json_data.0.falcon_logscale.0.default_repository", "sandbox"),
+					resource.TestCheckResourceAttr("grafana_data_source.logscale", "json_data.0.falcon_logscale.0.token", "logscale-token"),
+					func(s *terraform.State) error {
+						if !dataSource.SecureJSONFields["accessToken"] {
+							return fmt.Errorf("accessToken not set")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: `
+				resource "grafana_data_source" "logscale" {
+					type = "grafana-falconlogscale-datasource"
+					name = "anything"
+					json_data {
+						falcon_logscale {
+							base_url           = "https://cloud.community.humio.com"
+							default_repository = "not a repository"
+						}
+					}
+				}`,
+				ExpectError: regexp.MustCompile("repository names can only contain"),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_MongoDB(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+	checkPluginInstalled(t, "grafana-mongodb-datasource")
+
+	var dataSource models.DataSource
+	dsName := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "grafana_data_source" "mongodb" {
+					type = "grafana-mongodb-datasource"
+					name = "%s"
+
+					json_data {
+						mongodb {
+							connection_string  = "mongodb://mongodb:27017"
+							connection_options = "authSource=admin"
+							default_database   = "metrics"
+							username           = "grafana"
+							password           = "secret"
+						}
+					}
+				}`, dsName),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.mongodb", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.mongodb", "json_data.0.mongodb.0.default_database", "metrics"),
+					resource.TestCheckResourceAttr("grafana_data_source.mongodb", "json_data.0.mongodb.0.connection_string", "mongodb://mongodb:27017"),
+					resource.TestCheckResourceAttr("grafana_data_source.mongodb", "json_data_encoded", "{}"),
+					func(s *terraform.State) error {
+						expected := map[string]interface{}{
+							"connectionOptions": "authSource=admin",
+							"database":          "metrics",
+							"user":              "grafana",
+						}
+						if !reflect.DeepEqual(dataSource.JSONData, expected) {
+							return fmt.Errorf("bad json data: %#v. Expected: %+v", dataSource.JSONData, expected)
+						}
+						for _, field := range []string{"connection", "password"} {
+							if !dataSource.SecureJSONFields[field] {
+								return fmt.Errorf("%s not set", field)
+							}
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: `
+				resource "grafana_data_source" "mongodb" {
+					type = "grafana-mongodb-datasource"
+					name = "anything"
+					json_data {
+						mongodb {
+							connection_string = "postgres://mongodb:27017"
+						}
+					}
+				}`,
+				ExpectError: regexp.MustCompile("the connection string must start with"),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_GrafanaCloudUsage(t *testing.T) {
+	testutils.CheckCloudInstanceTestsEnabled(t)
+
+	var dataSource models.DataSource
+	dsName := acctest.RandString(10)
+	config := func(auth string) string {
+		return fmt.Sprintf(`
+		resource "grafana_data_source" "usage" {
+			type = "prometheus"
+			name = "%[1]s-usage"
+			url  = "https://billing.grafana.net/api/prom"
+			%[2]s
+
+			json_data {
+				grafana_cloud_usage {
+					token = "usage-token"
+				}
+			}
+		}`, dsName, auth)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: config(`
+			basic_auth_enabled  = true
+			basic_auth_username = "123456"`),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.usage", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.usage", "json_data.0.grafana_cloud_usage.0.token", "usage-token"),
+					resource.TestCheckResourceAttr("grafana_data_source.usage", "json_data_encoded", "{}"),
+					func(s *terraform.State) error {
+						if !dataSource.BasicAuth || dataSource.BasicAuthUser != "123456" || !dataSource.SecureJSONFields["basicAuthPassword"] {
+							return fmt.Errorf("expected the data source to use basic auth with the token as password, got %+v", dataSource)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:      config(""),
+				ExpectError: regexp.MustCompile("`basic_auth_enabled` must be set"),
+			},
+			{
+				Config: config(`
+			basic_auth_enabled = true`),
+				ExpectError: regexp.MustCompile("`basic_auth_username` must be set to the ID of the Grafana Cloud organization"),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_EnterpriseMetrics(t *testing.T) {
+	testutils.CheckEnterpriseTestsEnabled(t)
+
+	var tenantDataSource, adminDataSource models.DataSource
+	dsName := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&tenantDataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "grafana_data_source" "tenant" {
+					type                = "prometheus"
+					name                = "%[1]s-tenant"
+					url                 = "http://gem.invalid/prometheus"
+					basic_auth_enabled  = true
+					basic_auth_username = "team-a"
+
+					json_data {
+						enterprise_metrics {
+							access_token = "tenant-token"
+						}
+					}
+				}
+
+				resource "grafana_data_source" "admin" {
+					type                = "prometheus"
+					name                = "%[1]s-admin"
+					url                 = "http://gem.invalid/prometheus"
+					basic_auth_enabled  = true
+					basic_auth_username = "team-a|team-b"
+
+					json_data {
+						enterprise_metrics {
+							admin_token = "admin-token"
+						}
+					}
+				}`, dsName),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.tenant", &tenantDataSource),
+					datasourceCheckExists.exists("grafana_data_source.admin", &adminDataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.tenant", "json_data.0.enterprise_metrics.0.access_token", "tenant-token"),
+					resource.TestCheckResourceAttr("grafana_data_source.admin", "json_data.0.enterprise_metrics.0.admin_token", "admin-token"),
+					resource.TestCheckResourceAttr("grafana_data_source.tenant", "json_data_encoded", "{}"),
+					func(s *terraform.State) error {
+						for _, ds := range []models.DataSource{tenantDataSource, adminDataSource} {
+							if !ds.BasicAuth || !ds.SecureJSONFields["basicAuthPassword"] {
+								return fmt.Errorf("expected data source %s to use basic auth with a password", ds.Name)
+							}
+						}
+						if adminDataSource.BasicAuthUser != "team-a|team-b" {
+							return fmt.Errorf("bad basic auth user: %s", adminDataSource.BasicAuthUser)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: `
+				resource "grafana_data_source" "tenant" {
+					type = "prometheus"
+					name = "anything"
+					json_data {
+						enterprise_metrics {
+							access_token = "tenant-token"
+						}
+					}
+				}`,
+				ExpectError: regexp.MustCompile("`basic_auth_enabled` must be set"),
+			},
+			{
+				Config: `
+				resource "grafana_data_source" "tenant" {
+					type                = "prometheus"
+					name                = "anything"
+					basic_auth_enabled  = true
+					basic_auth_username = "team-a"
+					json_data {
+						enterprise_metrics {
+							access_token = "tenant-token"
+							admin_token  = "admin-token"
+						}
+					}
+				}`,
+				ExpectError: regexp.MustCompile("exactly one of `access_token` or `admin_token` must be set"),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_HTTPClientSettings(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var dataSource models.DataSource
+	dsName := acctest.RandString(10)
+
+	config := func(timeout int) string {
+		return fmt.Sprintf(`
+		resource "grafana_data_source" "prometheus" {
+			type = "prometheus"
+			name = "%s"
+			url  = "https://acc-test.invalid/"
+
+			json_data {
+				tls_skip_verify = true
+				timeout         = %d
+
+				prometheus {
+					http_method = "POST"
+				}
+			}
+		}`, dsName, timeout)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: config(30),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.prometheus", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "json_data.0.tls_skip_verify", "true"),
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "json_data.0.tls_client_auth", "false"),
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "json_data.0.timeout", "30"),
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "json_data.0.prometheus.0.http_method", "POST"),
+					resource.TestCheckResourceAttr("grafana_data_source.prometheus", "json_data_encoded", "{}"),
+					func(s *terraform.State) error {
+						expected := map[string]interface{}{
+							"tlsSkipVerify": true,
+							"timeout":       float64(30),
+							"httpMethod":    "POST",
+						}
+						if !reflect.DeepEqual(dataSource.JSONData, expected) {
+							return fmt.Errorf("bad json data: %#v. Expected: %+v", dataSource.JSONData, expected)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName: "grafana_data_source.prometheus",
+				ImportState:  true,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					attrs := states[0].Attributes
+					if attrs["json_data.0.tls_skip_verify"] != "true" || attrs["json_data.0.timeout"] != "30" {
+						return fmt.Errorf("expected the HTTP client settings to be imported, got %v", attrs)
+					}
+					return nil
+				},
+			},
+			{
+				Config:      config(-1),
+				ExpectError: regexp.MustCompile(`expected json_data.0.timeout to be at least \(0\)`),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_TLSCertificates(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var dataSource models.DataSource
+	dsName := acctest.RandString(10)
+	cert, key := testTLSCertificate(t)
+
+	config := fmt.Sprintf(`
+	resource "grafana_data_source" "postgres" {
+		type     = "grafana-postgresql-datasource"
+		name     = "%s"
+		url      = "postgres.acc-test.invalid:5432"
+		username = "grafana"
+		json_data_encoded = jsonencode({
+			database               = "grafana"
+			sslmode                = "verify-full"
+			tlsConfigurationMethod = "file-content"
+		})
+
+		json_data {
+			tls_client_auth = true
+			tls_ca_cert     = %q
+			tls_client_cert = %q
+			tls_client_key  = base64encode(%q)
+		}
+	}`, dsName, cert, cert, key)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.postgres", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.postgres", "json_data.0.tls_client_auth", "true"),
+					resource.TestCheckResourceAttr("grafana_data_source.postgres", "json_data.0.tls_ca_cert", cert),
+					func(s *terraform.State) error {
+						for _, field := range []string{"tlsCACert", "tlsClientCert", "tlsClientKey"} {
+							if !dataSource.SecureJSONFields[field] {
+								return fmt.Errorf("expected %s to be set in the secure JSON data, got %v", field, dataSource.SecureJSONFields)
+							}
+						}
+						if dataSource.JSONData.(map[string]interface{})["tlsAuth"] != true {
+							return fmt.Errorf("expected tlsAuth to be enabled, got %v", dataSource.JSONData)
+						}
+						return nil
+					},
+				),
+			},
+			// The certificates can't be read back, they mustn't cause a diff
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				Config:      strings.Replace(config, `tls_ca_cert     = `, `tls_ca_cert     = "not a certificate" # `, 1),
+				ExpectError: regexp.MustCompile(`json_data.0.tls_ca_cert: expected a PEM block or base64-encoded PEM`),
+			},
+		},
+	})
+}
+
+func TestDatasourcePEM(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	cert, _ := testTLSCertificate(t)
+	for _, tc := range []struct {
+		name        string
+		value       string
+		expected    string
+		expectedErr bool
+	}{
+		{name: "empty"},
+		{name: "PEM", value: cert, expected: cert},
+		{name: "base64-encoded PEM", value: base64.StdEncoding.EncodeToString([]byte(cert)), expected: cert},
+		{name: "not base64", value: "not a certificate", expectedErr: true},
+		{name: "base64 but not PEM", value: base64.StdEncoding.EncodeToString([]byte("not a certificate")), expectedErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := grafana.DatasourcePEM(tc.value)
+			if tc.expectedErr != (err != nil) {
+				t.Fatalf("expected error: %t, got %v", tc.expectedErr, err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+// testTLSCertificate returns a self-signed certificate and its private key, in PEM format.
+func testTLSCertificate(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "acc-test.invalid"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return string(cert), string(keyPEM)
+}
+
+func TestAccDataSource_MySQLCompatible(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+	checkPluginInstalled(t, "mysql")
+
+	var dataSource models.DataSource
+	dsName := acctest.RandString(10)
+
+	// MariaDB (like Percona Server) is queried through the MySQL plugin
+	config := func(pool string) string {
+		return fmt.Sprintf(`
+		resource "grafana_data_source" "mariadb" {
+			type          = "mysql"
+			name          = "%s"
+			url           = "mariadb:3306"
+			database_name = "metrics"
+			username      = "grafana"
+
+			json_data {
+				mysql {
+					timezone                  = "+02:00"
+					allow_cleartext_passwords = true
+					password                  = "secret"
+					%s
+				}
+			}
+		}`, dsName, pool)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: config(`
+					max_open_conns           = 10
+					max_idle_conns           = 5
+					conn_max_lifetime        = 3600
+					tls_configuration_method = "file-content"`),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.mariadb", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.mariadb", "json_data.0.mysql.0.timezone", "+02:00"),
+					resource.TestCheckResourceAttr("grafana_data_source.mariadb", "json_data.0.mysql.0.max_open_conns", "10"),
+					resource.TestCheckResourceAttr("grafana_data_source.mariadb", "json_data_encoded", "{}"),
+					func(s *terraform.State) error {
+						expected := map[string]interface{}{
+							"timezone":                "+02:00",
+							"allowCleartextPasswords": true,
+							"maxOpenConns":            float64(10),
+							"maxIdleConns":            float64(5),
+							"connMaxLifetime":         float64(3600),
+							"tlsConfigurationMethod":  "file-content",
+						}
+						if !reflect.DeepEqual(dataSource.JSONData, expected) {
+							return fmt.Errorf("bad json data: %#v. Expected: %+v", dataSource.JSONData, expected)
+						}
+						if !dataSource.SecureJSONFields["password"] {
+							return fmt.Errorf("password not set")
+						}
+						return nil
+					},
+				),
+			},
+			// Unset connection pool settings are left out, so that Grafana's defaults apply
+			{
+				Config: config("max_idle_conns_auto = true"),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.mariadb", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.mariadb", "json_data.0.mysql.0.max_open_conns", "0"),
+					func(s *terraform.State) error {
+						for _, key := range []string{"maxOpenConns", "maxIdleConns", "connMaxLifetime", "tlsConfigurationMethod"} {
+							if _, ok := dataSource.JSONData.(map[string]interface{})[key]; ok {
+								return fmt.Errorf("expected %s to be unset, got json data: %#v", key, dataSource.JSONData)
+							}
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:   config("max_idle_conns_auto = true"),
+				PlanOnly: true,
+			},
+			{
+				Config: config(`
+					max_idle_conns_auto = true
+					max_idle_conns      = 5`),
+				ExpectError: regexp.MustCompile("`max_idle_conns` can't be set when `max_idle_conns_auto` is enabled"),
+			},
+			{
+				Config:      strings.Replace(config(""), `"+02:00"`, `"not a time zone"`, 1),
+				ExpectError: regexp.MustCompile("the time zone must be an offset"),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_Alertmanager(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var dataSource models.DataSource
+	dsName := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "grafana_data_source" "alertmanager" {
+					type = "alertmanager"
+					name = "%s"
+					url  = "http://alertmanager:9093"
+
+					json_data {
+						alertmanager {
+							implementation                = "prometheus"
+							handle_grafana_managed_alerts = true
+						}
+					}
+				}`, dsName),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.alertmanager", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.alertmanager", "json_data.0.alertmanager.0.implementation", "prometheus"),
+					resource.TestCheckResourceAttr("grafana_data_source.alertmanager", "json_data.0.alertmanager.0.handle_grafana_managed_alerts", "true"),
+					resource.TestCheckResourceAttr("grafana_data_source.alertmanager", "json_data_encoded", "{}"),
+					func(s *terraform.State) error {
+						expected := map[string]interface{}{
+							"implementation":             "prometheus",
+							"handleGrafanaManagedAlerts": true,
+						}
+						if !reflect.DeepEqual(dataSource.JSONData, expected) {
+							return fmt.Errorf("bad json data: %#v. Expected: %+v", dataSource.JSONData, expected)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: `
+				resource "grafana_data_source" "alertmanager" {
+					type = "alertmanager"
+					name = "anything"
+					url  = "http://alertmanager:9093"
+					json_data {
+						alertmanager {
+							implementation                = "cortex"
+							handle_grafana_managed_alerts = true
+						}
+					}
+				}`,
+				ExpectError: regexp.MustCompile("only supported by the `prometheus` and `mimir` implementations"),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_AlertmanagerTenantID(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var dataSource models.DataSource
+	dsName := acctest.RandString(10)
+
+	config := func(implementation, settings string) string {
+		return fmt.Sprintf(`
+		resource "grafana_data_source" "alertmanager" {
+			type      = "alertmanager"
+			name      = "%s"
+			url       = "https://mimir.acc-test.invalid/"
+			tenant_id = "tenant-1"
+
+			json_data {
+				alertmanager {
+					implementation = "%s"
+					%s
+				}
+			}
+		}`, dsName, implementation, settings)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			// Grafana-managed alerts are sent to the tenant by default
+			{
+				Config: config("mimir", ""),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.alertmanager", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.alertmanager", "tenant_id", "tenant-1"),
+					resource.TestCheckResourceAttr("grafana_data_source.alertmanager", "json_data.0.alertmanager.0.implementation", "mimir"),
+					resource.TestCheckResourceAttr("grafana_data_source.alertmanager", "json_data.0.alertmanager.0.handle_grafana_managed_alerts", "true"),
+					resource.TestCheckNoResourceAttr("grafana_data_source.alertmanager", "http_headers.X-Scope-OrgID"),
+					func(s *terraform.State) error {
+						expected := map[string]interface{}{
+							"implementation":             "mimir",
+							"handleGrafanaManagedAlerts": true,
+							"httpHeaderName1":            "X-Scope-OrgID",
+						}
+						if !reflect.DeepEqual(dataSource.JSONData, expected) {
+							return fmt.Errorf("bad json data: %#v. Expected: %+v", dataSource.JSONData, expected)
+						}
+						if !dataSource.SecureJSONFields["httpHeaderValue1"] {
+							return fmt.Errorf("expected the tenant header value to be set")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:   config("mimir", ""),
+				PlanOnly: true,
+			},
+			{
+				Config: config("mimir", "handle_grafana_managed_alerts = false"),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.alertmanager", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.alertmanager", "json_data.0.alertmanager.0.handle_grafana_managed_alerts", "false"),
+					func(s *terraform.State) error {
+						if _, ok := dataSource.JSONData.(map[string]interface{})["handleGrafanaManagedAlerts"]; ok {
+							return fmt.Errorf("expected handleGrafanaManagedAlerts to be unset, got %#v", dataSource.JSONData)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:      config("prometheus", ""),
+				ExpectError: regexp.MustCompile("`tenant_id` is only supported by the multi-tenant `mimir` and `cortex` implementations"),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_Asserts(t *testing.T) {
+	testutils.CheckCloudInstanceTestsEnabled(t)
+
+	var dataSource models.DataSource
+	dsName := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "grafana_data_source" "asserts" {
+					type = "grafana-asserts-datasource"
+					name = "%s"
+
+					json_data {
+						asserts {
+							stack_id     = 1234
+							api_endpoint = "https://asserts.grafana.net"
+							token        = "glc_token"
+						}
+					}
+				}`, dsName),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.asserts", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.asserts", "json_data.0.asserts.0.api_endpoint", "https://asserts.grafana.net"),
+					resource.TestCheckResourceAttr("grafana_data_source.asserts", "json_data.0.asserts.0.token", "glc_token"),
+					resource.TestCheckResourceAttr("grafana_data_source.asserts", "json_data_encoded", "{}"),
+					func(s *terraform.State) error {
+						expected := map[string]interface{}{
+							"stackId":     float64(1234),
+							"apiEndpoint": "https://asserts.grafana.net",
+						}
+						if !reflect.DeepEqual(dataSource.JSONData, expected) {
+							return fmt.Errorf("bad json data: %#v. Expected: %+v", dataSource.JSONData, expected)
+						}
+						if !dataSource.SecureJSONFields["token"] {
+							return fmt.Errorf("token not set")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_FrontendObservability(t *testing.T) {
+	testutils.CheckCloudInstanceTestsEnabled(t)
+
+	var dataSource models.DataSource
+	dsName := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "grafana_data_source" "faro" {
+					type = "grafana-kowalski-datasource"
+					name = "%s"
+
+					json_data {
+						frontend_observability {
+							stack_id     = 1234
+							api_endpoint = "https://faro-api-prod-us-central-0.grafana.net/faro"
+							token        = "glc_token"
+						}
+					}
+				}`, dsName),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.faro", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.faro", "json_data.0.frontend_observability.0.stack_id", "1234"),
+					resource.TestCheckResourceAttr("grafana_data_source.faro", "json_data.0.frontend_observability.0.api_endpoint", "https://faro-api-prod-us-central-0.grafana.net/faro"),
+					resource.TestCheckResourceAttr("grafana_data_source.faro", "json_data.0.frontend_observability.0.token", "glc_token"),
+					resource.TestCheckResourceAttr("grafana_data_source.faro", "json_data_encoded", "{}"),
+					func(s *terraform.State) error {
+						expected := map[string]interface{}{
+							"stackId":     float64(1234),
+							"apiEndpoint": "https://faro-api-prod-us-central-0.grafana.net/faro",
+						}
+						if !reflect.DeepEqual(dataSource.JSONData, expected) {
+							return fmt.Errorf("bad json data: %#v. Expected: %+v", dataSource.JSONData, expected)
+						}
+						if !dataSource.SecureJSONFields["token"] {
+							return fmt.Errorf("token not set")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_TempoTracesToProfiles(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=10.1.0")
+
+	var tempo, pyroscope models.DataSource
+	name := acctest.RandString(10)
+	config := func(linkedUID string) string {
+		return fmt.Sprintf(`
+		resource "grafana_data_source" "pyroscope" {
+			type = "grafana-pyroscope-datasource"
+			name = "%[1]s-pyroscope"
+			uid  = "%[1]s-pyroscope"
+			url  = "http://pyroscope:4040"
+		}
+
+		resource "grafana_data_source" "prometheus" {
+			type = "prometheus"
+			name = "%[1]s-prometheus"
+			uid  = "%[1]s-prometheus"
+			url  = "http://prometheus:9090"
+		}
+
+		resource "grafana_data_source" "tempo" {
+			type = "tempo"
+			name = "%[1]s-tempo"
+			url  = "http://tempo:3200"
+
+			json_data {
+				tempo {
+					traces_to_profiles {
+						datasource_uid  = %[2]s
+						profile_type_id = "process_cpu:cpu:nanoseconds:cpu:nanoseconds"
+						tags = {
+							"service.name" = "service_name"
+							"namespace"    = ""
+						}
+					}
+				}
+			}
+		}`, name, linkedUID)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			datasourceCheckExists.destroyed(&tempo, nil),
+			datasourceCheckExists.destroyed(&pyroscope, nil),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: config("grafana_data_source.pyroscope.uid"),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.pyroscope", &pyroscope),
+					datasourceCheckExists.exists("grafana_data_source.tempo", &tempo),
+					resource.TestCheckResourceAttr("grafana_data_source.tempo", "json_data.0.tempo.0.traces_to_profiles.0.datasource_uid", name+"-pyroscope"),
+					resource.TestCheckResourceAttr("grafana_data_source.tempo", "json_data.0.tempo.0.traces_to_profiles.0.tags.service.name", "service_name"),
+					resource.TestCheckResourceAttr("grafana_data_source.tempo", "json_data_encoded", "{}"),
+					func(s *terraform.State) error {
+						expected := map[string]interface{}{
+							"tracesToProfiles": map[string]interface{}{
+								"datasourceUid": name + "-pyroscope",
+								"profileTypeId": "process_cpu:cpu:nanoseconds:cpu:nanoseconds",
+								"tags": []interface{}{
+									map[string]interface{}{"key": "namespace", "value": ""},
+									map[string]interface{}{"key": "service.name", "value": "service_name"},
+								},
+							},
+						}
+						if !reflect.DeepEqual(tempo.JSONData, expected) {
+							return fmt.Errorf("bad json data: %#v. Expected: %+v", tempo.JSONData, expected)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:      config(fmt.Sprintf("%q", name+"-prometheus")),
+				ExpectError: regexp.MustCompile("datasource_uid must reference a `grafana-pyroscope-datasource` data source"),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_TempoTracesToLogsAndMetrics(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=10.1.0")
+
+	var tempo models.DataSource
+	name := acctest.RandString(10)
+	config := fmt.Sprintf(`
+	resource "grafana_data_source" "loki" {
+		type = "loki"
+		name = "%[1]s-loki"
+		url  = "http://loki:3100"
+	}
+
+	resource "grafana_data_source" "prometheus" {
+		type = "prometheus"
+		name = "%[1]s-prometheus"
+		url  = "http://prometheus:9090"
+	}
+
+	resource "grafana_data_source" "tempo" {
+		type = "tempo"
+		name = "%[1]s-tempo"
+		url  = "http://tempo:3200"
+
+		json_data {
+			tempo {
+				traces_to_logs {
+					datasource_uid        = grafana_data_source.loki.uid
+					span_start_time_shift = "-1h"
+					span_end_time_shift   = "1h"
+					filter_by_trace_id    = true
+					tags = {
+						"service.name" = "service_name"
+						"namespace"    = ""
+					}
+				}
+				traces_to_metrics {
+					datasource_uid        = grafana_data_source.prometheus.uid
+					span_start_time_shift = "-2m"
+					span_end_time_shift   = "2m"
+					tags = {
+						"service.name" = "service"
+					}
+					queries {
+						name  = "Request rate"
+						query = "sum(rate(traces_spanmetrics_calls_total{$__tags}[5m]))"
+					}
+				}
+			}
+		}
+	}`, name)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&tempo, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.tempo", &tempo),
+					resource.TestCheckResourceAttrPair("grafana_data_source.tempo", "json_data.0.tempo.0.traces_to_logs.0.datasource_uid", "grafana_data_source.loki", "uid"),
+					resource.TestCheckResourceAttr("grafana_data_source.tempo", "json_data.0.tempo.0.traces_to_logs.0.tags.service.name", "service_name"),
+					resource.TestCheckResourceAttrPair("grafana_data_source.tempo", "json_data.0.tempo.0.traces_to_metrics.0.datasource_uid", "grafana_data_source.prometheus", "uid"),
+					resource.TestCheckResourceAttr("grafana_data_source.tempo", "json_data.0.tempo.0.traces_to_metrics.0.queries.0.name", "Request rate"),
+					resource.TestCheckResourceAttr("grafana_data_source.tempo", "json_data_encoded", "{}"),
+					func(s *terraform.State) error {
+						jsonData := tempo.JSONData.(map[string]interface{})
+						logs := jsonData["tracesToLogsV2"].(map[string]interface{})
+						expectedLogs := map[string]interface{}{
+							"datasourceUid":      logs["datasourceUid"],
+							"spanStartTimeShift": "-1h",
+							"spanEndTimeShift":   "1h",
+							"filterByTraceID":    true,
+							"tags": []interface{}{
+								map[string]interface{}{"key": "namespace", "value": ""},
+								map[string]interface{}{"key": "service.name", "value": "service_name"},
+							},
+						}
+						if !reflect.DeepEqual(logs, expectedLogs) {
+							return fmt.Errorf("bad tracesToLogsV2: %#v. Expected: %+v", logs, expectedLogs)
+						}
+						metrics := jsonData["tracesToMetrics"].(map[string]interface{})
+						expectedMetrics := map[string]interface{}{
+							"datasourceUid":      metrics["datasourceUid"],
+							"spanStartTimeShift": "-2m",
+							"spanEndTimeShift":   "2m",
+							"tags": []interface{}{
+								map[string]interface{}{"key": "service.name", "value": "service"},
+							},
+							"queries": []interface{}{
+								map[string]interface{}{"name": "Request rate", "query": "sum(rate(traces_spanmetrics_calls_total{$__tags}[5m]))"},
+							},
+						}
+						if !reflect.DeepEqual(metrics, expectedMetrics) {
+							return fmt.Errorf("bad tracesToMetrics: %#v. Expected: %+v", metrics, expectedMetrics)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+			// Imported data sources keep the nested links as they are in Grafana
+			{
+				ResourceName: "grafana_data_source.tempo",
+				ImportState:  true,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					var jsonData map[string]interface{}
+					if err := json.Unmarshal([]byte(states[0].Attributes["json_data_encoded"]), &jsonData); err != nil {
+						return err
+					}
+					if !reflect.DeepEqual(jsonData["tracesToLogsV2"], tempo.JSONData.(map[string]interface{})["tracesToLogsV2"]) ||
+						!reflect.DeepEqual(jsonData["tracesToMetrics"], tempo.JSONData.(map[string]interface{})["tracesToMetrics"]) {
+						return fmt.Errorf("bad imported json_data_encoded: %#v", jsonData)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccDataSource_TempoMetricsGenerator(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=10.1.0")
+
+	var tempo, prometheus models.DataSource
+	name := acctest.RandString(10)
+	config := func(secondQuery string) string {
+		return fmt.Sprintf(`
+		resource "grafana_data_source" "prometheus" {
+			type = "prometheus"
+			name = "%[1]s-prometheus"
+			url  = "http://prometheus:9090"
+		}
+
+		resource "grafana_data_source" "tempo" {
+			type = "tempo"
+			name = "%[1]s-tempo"
+			url  = "http://tempo:3200"
+
+			json_data {
+				tempo {
+					node_graph = true
+					service_map {
+						datasource_name = grafana_data_source.prometheus.name
+					}
+					traces_to_metrics {
+						datasource_name = grafana_data_source.prometheus.name
+						queries {
+							name  = "Request rate"
+							query = "sum(rate(traces_spanmetrics_calls_total{$__tags}[5m]))"
+						}
+						queries {
+							name  = "Error rate"
+							query = "%[2]s"
+						}
+					}
+				}
+			}
+		}`, name, secondQuery)
+	}
+	errorRate := `sum(rate(traces_spanmetrics_calls_total{$__tags,status_code=\"STATUS_CODE_ERROR\"}[5m]))`
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			datasourceCheckExists.destroyed(&tempo, nil),
+			datasourceCheckExists.destroyed(&prometheus, nil),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: config(errorRate),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.prometheus", &prometheus),
+					datasourceCheckExists.exists("grafana_data_source.tempo", &tempo),
+					resource.TestCheckResourceAttr("grafana_data_source.tempo", "json_data.0.tempo.0.node_graph", "true"),
+					resource.TestCheckResourceAttrPair("grafana_data_source.tempo", "json_data.0.tempo.0.traces_to_metrics.0.datasource_uid", "grafana_data_source.prometheus", "uid"),
+					resource.TestCheckResourceAttr("grafana_data_source.tempo", "json_data.0.tempo.0.traces_to_metrics.0.queries.#", "2"),
+					resource.TestCheckResourceAttr("grafana_data_source.tempo", "json_data.0.tempo.0.traces_to_metrics.0.queries.1.name", "Error rate"),
+					resource.TestCheckResourceAttr("grafana_data_source.tempo", "json_data_encoded", "{}"),
+					func(s *terraform.State) error {
+						expected := map[string]interface{}{
+							"nodeGraph":  map[string]interface{}{"enabled": true},
+							"serviceMap": map[string]interface{}{"datasourceUid": prometheus.UID},
+							"tracesToMetrics": map[string]interface{}{
+								"datasourceUid": prometheus.UID,
+								"queries": []interface{}{
+									map[string]interface{}{"name": "Request rate", "query": "sum(rate(traces_spanmetrics_calls_total{$__tags}[5m]))"},
+									map[string]interface{}{"name": "Error rate", "query": `sum(rate(traces_spanmetrics_calls_total{$__tags,status_code="STATUS_CODE_ERROR"}[5m]))`},
+								},
+							},
+						}
+						if !reflect.DeepEqual(tempo.JSONData, expected) {
+							return fmt.Errorf("bad json data: %#v. Expected: %+v", tempo.JSONData, expected)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:   config(errorRate),
+				PlanOnly: true,
+			},
+			{
+				Config:      config(" "),
+				ExpectError: regexp.MustCompile("traces_to_metrics: the query of `queries.1` can't be empty"),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_TempoServiceMap(t *testing.T) {
+	testutils.CheckCloudInstanceTestsEnabled(t)
+
+	var tempo, prometheus models.DataSource
+	name := acctest.RandString(10)
+	config := func(linkedName string) string {
+		return fmt.Sprintf(`
+		resource "grafana_data_source" "prometheus" {
+			type = "prometheus"
+			name = "%[1]s-prometheus"
+			url  = "http://prometheus:9090"
+		}
+
+		resource "grafana_data_source" "loki" {
+			type = "loki"
+			name = "%[1]s-loki"
+			url  = "http://loki:3100"
+		}
+
+		resource "grafana_data_source" "tempo" {
+			type = "tempo"
+			name = "%[1]s-tempo"
+			url  = "http://tempo:3200"
+
+			json_data {
+				tempo {
+					service_map {
+						datasource_name = %[2]s
+					}
+				}
+			}
+		}`, name, linkedName)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			datasourceCheckExists.destroyed(&tempo, nil),
+			datasourceCheckExists.destroyed(&prometheus, nil),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: config("grafana_data_source.prometheus.name"),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.prometheus", &prometheus),
+					datasourceCheckExists.exists("grafana_data_source.tempo", &tempo),
+					resource.TestCheckResourceAttrPair("grafana_data_source.tempo", "json_data.0.tempo.0.service_map.0.datasource_uid", "grafana_data_source.prometheus", "uid"),
+					resource.TestCheckResourceAttr("grafana_data_source.tempo", "json_data.0.tempo.0.service_map.0.datasource_name", name+"-prometheus"),
+					resource.TestCheckResourceAttr("grafana_data_source.tempo", "json_data_encoded", "{}"),
+					func(s *terraform.State) error {
+						expected := map[string]interface{}{
+							"serviceMap": map[string]interface{}{"datasourceUid": prometheus.UID},
+						}
+						if !reflect.DeepEqual(tempo.JSONData, expected) {
+							return fmt.Errorf("bad json data: %#v. Expected: %+v", tempo.JSONData, expected)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:   config("grafana_data_source.prometheus.name"),
+				PlanOnly: true,
+			},
+			{
+				Config:      config(fmt.Sprintf("%q", name+"-loki")),
+				ExpectError: regexp.MustCompile("it must be a `prometheus` data source"),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_K6(t *testing.T) {
+	testutils.CheckCloudInstanceTestsEnabled(t)
+
+	var dataSource models.DataSource
+	dsName := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "grafana_data_source" "k6" {
+					type = "grafana-k6-datasource"
+					name = "%s"
+
+					json_data {
+						k6 {
+							stack_id     = 1234
+							api_endpoint = "https://api.k6.io"
+							token        = "glc_token"
+						}
+					}
+				}`, dsName),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.k6", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.k6", "json_data.0.k6.0.stack_id", "1234"),
+					resource.TestCheckResourceAttr("grafana_data_source.k6", "json_data.0.k6.0.api_endpoint", "https://api.k6.io"),
+					resource.TestCheckResourceAttr("grafana_data_source.k6", "json_data.0.k6.0.token", "glc_token"),
+					resource.TestCheckResourceAttr("grafana_data_source.k6", "json_data_encoded", "{}"),
+					func(s *terraform.State) error {
+						if !dataSource.SecureJSONFields["token"] {
+							return fmt.Errorf("token not set")
+						}
+						if _, ok := dataSource.JSONData.(map[string]interface{})["token"]; ok {
+							return fmt.Errorf("token should only be stored in the secure JSON data")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_K6Project(t *testing.T) {
+	testutils.CheckCloudInstanceTestsEnabled(t)
+
+	var dataSource models.DataSource
+	dsName := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			// Without a token, the project can't be checked
+			{
+				Config: fmt.Sprintf(`
+				resource "grafana_data_source" "k6" {
+					type = "grafana-k6-datasource"
+					name = "%s"
+
+					json_data {
+						k6 {
+							stack_id   = 1234
+							project_id = 5678
+						}
+					}
+				}`, dsName),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.k6", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.k6", "json_data.0.k6.0.stack_id", "1234"),
+					resource.TestCheckResourceAttr("grafana_data_source.k6", "json_data.0.k6.0.project_id", "5678"),
+					resource.TestCheckResourceAttr("grafana_data_source.k6", "json_data_encoded", "{}"),
+					func(s *terraform.State) error {
+						if projectID := dataSource.JSONData.(map[string]interface{})["projectId"]; projectID != float64(5678) {
+							return fmt.Errorf("expected projectId to be 5678, got %v", projectID)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "grafana_data_source.k6",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// With a token, the project is looked up in the k6 API
+			{
+				Config: fmt.Sprintf(`
+				resource "grafana_data_source" "k6" {
+					type = "grafana-k6-datasource"
+					name = "%s"
+
+					json_data {
+						k6 {
+							stack_id     = 1234
+							project_id   = 91011
+							api_endpoint = "https://api.k6.io"
+							token        = "glc_invalid_token"
+						}
+					}
+				}`, dsName),
+				ExpectError: regexp.MustCompile("failed to check the k6 project 91011"),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_FrontendObservabilityCollector(t *testing.T) {
+	testutils.CheckCloudInstanceTestsEnabled(t)
+
+	var dataSource models.DataSource
+	dsName := acctest.RandString(10)
+
+	config := func(appKey string) string {
+		return fmt.Sprintf(`
+		resource "grafana_data_source" "faro" {
+			type = "grafana-kowalski-datasource"
+			name = "%s"
+
+			json_data {
+				frontend_observability {
+					stack_id           = 1234
+					api_endpoint       = "https://faro-api-prod-us-central-0.grafana.net/faro"
+					collector_endpoint = "https://faro-collector-prod-us-central-0.grafana.net/"
+					app_key            = "%s"
+				}
+			}
+		}`, dsName, appKey)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: config("abcdef123456"),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.faro", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.faro", "json_data.0.frontend_observability.0.app_key", "abcdef123456"),
+					resource.TestCheckResourceAttr("grafana_data_source.faro", "json_data.0.frontend_observability.0.collector_url", "https://faro-collector-prod-us-central-0.grafana.net/collect/abcdef123456"),
+					resource.TestCheckResourceAttr("grafana_data_source.faro", "json_data_encoded", "{}"),
+				),
+			},
+			{
+				ResourceName:      "grafana_data_source.faro",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: config("fedcba654321"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_data_source.faro", "json_data.0.frontend_observability.0.collector_url", "https://faro-collector-prod-us-central-0.grafana.net/collect/fedcba654321"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+				resource "grafana_data_source" "faro" {
+					type = "grafana-kowalski-datasource"
+					name = "%s"
+
+					json_data {
+						frontend_observability {
+							app_key = "abcdef123456"
+						}
+					}
+				}`, dsName),
+				ExpectError: regexp.MustCompile("`collector_endpoint` and `app_key` must be set together"),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_OnCall(t *testing.T) {
+	testutils.CheckCloudInstanceTestsEnabled(t)
+
+	var dataSource models.DataSource
+	var alertmanagerURL, webhookURL string
+	dsName := acctest.RandString(10)
+
+	config := func(name string) string {
+		return fmt.Sprintf(`
+		resource "grafana_data_source" "oncall" {
+			type = "grafana-oncall-datasource"
+			name = "%[1]s"
+
+			json_data {
+				oncall {
+					token = "glc_token"
+				}
+			}
+		}
+
+		resource "grafana_contact_point" "oncall" {
+			name = "%[2]s"
+			oncall {
+				url = grafana_data_source.oncall.json_data[0].oncall[0].alertmanager_integration_url
+			}
+		}`, name, dsName)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: config(dsName),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.oncall", &dataSource),
+					resource.TestMatchResourceAttr("grafana_data_source.oncall", "json_data.0.oncall.0.alertmanager_integration_url", regexp.MustCompile(`^https://.+`)),
+					resource.TestCheckResourceAttrSet("grafana_data_source.oncall", "json_data.0.oncall.0.webhook_integration_url"),
+					resource.TestCheckResourceAttrPair("grafana_contact_point.oncall", "oncall.0.url", "grafana_data_source.oncall", "json_data.0.oncall.0.alertmanager_integration_url"),
+					func(s *terraform.State) error {
+						attrs := s.RootModule().Resources["grafana_data_source.oncall"].Primary.Attributes
+						alertmanagerURL = attrs["json_data.0.oncall.0.alertmanager_integration_url"]
+						webhookURL = attrs["json_data.0.oncall.0.webhook_integration_url"]
+						return nil
+					},
+				),
+			},
+			// The URLs are generated on creation, updates must keep them so that the contact point keeps working
+			{
+				Config: config(dsName + "-updated"),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.oncall", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.oncall", "name", dsName+"-updated"),
+					resource.TestCheckResourceAttrPair("grafana_contact_point.oncall", "oncall.0.url", "grafana_data_source.oncall", "json_data.0.oncall.0.alertmanager_integration_url"),
+					func(s *terraform.State) error {
+						if err := checkDatasourceOutput(s, &dataSource, "grafana_data_source.oncall", "json_data.0.oncall.0.alertmanager_integration_url", "alertmanagerIntegrationUrl", alertmanagerURL); err != nil {
+							return err
+						}
+						return checkDatasourceOutput(s, &dataSource, "grafana_data_source.oncall", "json_data.0.oncall.0.webhook_integration_url", "webhookIntegrationUrl", webhookURL)
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_SyntheticMonitoring(t *testing.T) {
+	testutils.CheckCloudInstanceTestsEnabled(t)
+
+	var dataSource models.DataSource
+	dsName := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "grafana_data_source" "sm" {
+					type = "synthetic-monitoring-datasource"
+					name = "%s"
+
+					json_data {
+						synthetic_monitoring {
+							api_host     = "https://synthetic-monitoring-api.grafana.net"
+							access_token = "sm_token"
+							metrics {
+								datasource_uid  = "grafanacloud-prom"
+								datasource_name = "grafanacloud-prom"
+								hosted_id       = 1234
+							}
+							logs {
+								datasource_uid = "grafanacloud-logs"
+								hosted_id      = 5678
+							}
+						}
+					}
+				}`, dsName),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.sm", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.sm", "json_data.0.synthetic_monitoring.0.metrics.0.hosted_id", "1234"),
+					resource.TestCheckResourceAttr("grafana_data_source.sm", "json_data.0.synthetic_monitoring.0.logs.0.datasource_uid", "grafanacloud-logs"),
+					resource.TestCheckResourceAttr("grafana_data_source.sm", "json_data_encoded", "{}"),
+					func(s *terraform.State) error {
+						expected := map[string]interface{}{
+							"apiHost":     "https://synthetic-monitoring-api.grafana.net",
+							"initialized": true,
+							"metrics": map[string]interface{}{
+								"type":        "prometheus",
+								"uid":         "grafanacloud-prom",
+								"grafanaName": "grafanacloud-prom",
+								"hostedId":    float64(1234),
+							},
+							"logs": map[string]interface{}{
+								"type":     "loki",
+								"uid":      "grafanacloud-logs",
+								"hostedId": float64(5678),
+							},
+						}
+						if !reflect.DeepEqual(dataSource.JSONData, expected) {
+							return fmt.Errorf("bad json data: %#v. Expected: %+v", dataSource.JSONData, expected)
+						}
+						if !dataSource.SecureJSONFields["accessToken"] {
+							return fmt.Errorf("accessToken not set")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_MachineLearning(t *testing.T) {
+	testutils.CheckCloudInstanceTestsEnabled(t)
+
+	var dataSource models.DataSource
+	dsName := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "grafana_data_source" "ml" {
+					type = "grafana-ml-datasource"
+					name = "%s"
+
+					json_data {
+						machine_learning {
+							stack_id = 1234
+							token    = "glc_token"
+						}
+					}
+				}`, dsName),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.ml", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.ml", "json_data.0.machine_learning.0.stack_id", "1234"),
+					resource.TestCheckResourceAttr("grafana_data_source.ml", "json_data.0.machine_learning.0.token", "glc_token"),
+					resource.TestCheckResourceAttr("grafana_data_source.ml", "json_data_encoded", "{}"),
+					func(s *terraform.State) error {
+						if !dataSource.SecureJSONFields["token"] {
+							return fmt.Errorf("token not set")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_Incident(t *testing.T) {
+	testutils.CheckCloudInstanceTestsEnabled(t)
+
+	var dataSource models.DataSource
+	var webhookURL string
+	dsName := acctest.RandString(10)
+
+	config := func(name string) string {
+		return fmt.Sprintf(`
+		resource "grafana_data_source" "incident" {
+			type = "grafana-incident-datasource"
+			name = "%s"
+
+			json_data {
+				incident {
+					token = "glc_token"
+				}
+			}
+		}`, name)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             datasourceCheckExists.destroyed(&dataSource, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: config(dsName),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.incident", &dataSource),
+					resource.TestCheckResourceAttrSet("grafana_data_source.incident", "json_data.0.incident.0.webhook_url"),
+					func(s *terraform.State) error {
+						webhookURL = s.RootModule().Resources["grafana_data_source.incident"].Primary.Attributes["json_data.0.incident.0.webhook_url"]
+						return nil
+					},
+				),
+			},
+			// The URL is generated on creation, updates must keep it
+			{
+				Config: config(dsName + "-updated"),
+				Check: resource.ComposeTestCheckFunc(
+					datasourceCheckExists.exists("grafana_data_source.incident", &dataSource),
+					resource.TestCheckResourceAttr("grafana_data_source.incident", "name", dsName+"-updated"),
+					func(s *terraform.State) error {
+						return checkDatasourceOutput(s, &dataSource, "grafana_data_source.incident", "json_data.0.incident.0.webhook_url", "webhookUrl", webhookURL)
+					},
+				),
+			},
+		},
+	})
+}
+
+// checkDatasourceOutput checks that a URL generated by Grafana for a data source is still in its JSON data and in the state.
+func checkDatasourceOutput(s *terraform.State, dataSource *models.DataSource, resourceName, attr, gfKey, expected string) error {
+	if expected == "" {
+		return fmt.Errorf("%s wasn't set on creation", attr)
+	}
+	if got := s.RootModule().Resources[resourceName].Primary.Attributes[attr]; got != expected {
+		return fmt.Errorf("expected %s to be kept after the update, got %q instead of %q", attr, got, expected)
+	}
+	if got := dataSource.JSONData.(map[string]interface{})[gfKey]; got != expected {
+		return fmt.Errorf("expected %s to be kept in the JSON data after the update, got %v instead of %q", gfKey, got, expected)
+	}
+	return nil
+}
+
+// checkPluginInstalled skips the test if the given plugin is not installed on the Grafana instance under test.
+func checkPluginInstalled(t *testing.T, pluginID string) {
+	t.Helper()
+
+	// The plugin settings endpoint isn't part of the OpenAPI client
+	client := testutils.Provider.Meta().(*common.Client).GrafanaAPI
+	_, err := client.Transport.Submit(&runtime.ClientOperation{
+		ID:          "getPluginSettingsByID",
+		Method:      http.MethodGet,
+		PathPattern: "/plugins/" + url.PathEscape(pluginID) + "/settings",
+		Schemes:     []string{"http", "https"},
+		Params: runtime.ClientRequestWriterFunc(func(runtime.ClientRequest, strfmt.Registry) error {
+			return nil
+		}),
+		Reader: runtime.ClientResponseReaderFunc(func(response runtime.ClientResponse, _ runtime.Consumer) (interface{}, error) {
+			if response.Code() != http.StatusOK {
+				return nil, runtime.NewAPIError("getPluginSettingsByID", response.Message(), response.Code())
+			}
+			return nil, nil
+		}),
+	})
+	if err != nil {
+		t.Skipf("plugin %s is not installed: %v", pluginID, err)
+	}
+}
+
 func testAccDatasourceInOrganization(orgName string) string {
 	return fmt.Sprintf(`
 resource "grafana_organization" "test" {
@@ -0,0 +1,1788 @@
+package grafana
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+)
+
+type vertamediaClickHouseJSONData struct{}
+
+var _ datasourceJSONDataType = (*vertamediaClickHouseJSONData)(nil)
+var _ datasourceJSONDataValidator = (*vertamediaClickHouseJSONData)(nil)
+
+func (c vertamediaClickHouseJSONData) meta() datasourceJSONDataTypeMeta {
+	return datasourceJSONDataTypeMeta{
+		field:        "vertamedia_clickhouse",
+		pluginIDs:    []string{"vertamedia-clickhouse-datasource"},
+		desc:         "Options for the community (Altinity) ClickHouse plugin.",
+		secureFields: []string{"password"},
+	}
+}
+
+func (c vertamediaClickHouseJSONData) schema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"default_database": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The database used when a query doesn't specify one.",
+			},
+			"add_cors_header": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether to add the CORS flag to requests, to allow querying ClickHouse directly from the browser.",
+			},
+			"use_post": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether to send queries with the POST method instead of GET.",
+			},
+			"use_compression": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether to ask ClickHouse to compress its responses. Requires `compression_type`.",
+			},
+			"compression_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The compression used for responses when `use_compression` is enabled. One of `gzip`, `br`, `deflate` or `zstd`.",
+				ValidateFunc: validation.StringInSlice([]string{"gzip", "br", "deflate", "zstd"}, false),
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The basic auth password used to connect to ClickHouse. Requires `basic_auth_enabled`.",
+			},
+		},
+	}
+}
+
+func (c vertamediaClickHouseJSONData) validate(d *schema.ResourceDiff, raw map[string]interface{}) error {
+	if d.NewValueKnown("url") && d.Get("url").(string) == "" {
+		return errors.New("`url` is required for ClickHouse data sources")
+	}
+	if raw["use_compression"].(bool) && raw["compression_type"].(string) == "" {
+		return errors.New("`compression_type` is required when `use_compression` is enabled")
+	}
+	if !raw["use_compression"].(bool) && raw["compression_type"].(string) != "" {
+		return errors.New("`compression_type` can only be set when `use_compression` is enabled")
+	}
+	if raw["password"].(string) != "" && !d.Get("basic_auth_enabled").(bool) {
+		return errors.New("`password` requires `basic_auth_enabled` to be set")
+	}
+	return nil
+}
+
+func (c vertamediaClickHouseJSONData) pack(jsonData map[string]interface{}, state map[string]interface{}) map[string]interface{} {
+	tfSettings := map[string]interface{}{}
+	packJSONDataString(jsonData, tfSettings, "defaultDatabase", "default_database")
+	packJSONDataBool(jsonData, tfSettings, "addCorsHeader", "add_cors_header")
+	packJSONDataBool(jsonData, tfSettings, "usePOST", "use_post")
+	packJSONDataBool(jsonData, tfSettings, "useCompression", "use_compression")
+	packJSONDataString(jsonData, tfSettings, "compressionType", "compression_type")
+	packSecureFields(tfSettings, state, c.meta().secureFields)
+	return tfSettings
+}
+
+func (c vertamediaClickHouseJSONData) unpack(raw map[string]interface{}, jsonData map[string]interface{}, secureJSONData map[string]string) error {
+	unpackJSONDataString(raw, jsonData, "default_database", "defaultDatabase")
+	unpackJSONDataBool(raw, jsonData, "add_cors_header", "addCorsHeader")
+	unpackJSONDataBool(raw, jsonData, "use_post", "usePOST")
+	unpackJSONDataBool(raw, jsonData, "use_compression", "useCompression")
+	unpackJSONDataString(raw, jsonData, "compression_type", "compressionType")
+	unpackSecureJSONDataString(raw, secureJSONData, "password", "basicAuthPassword")
+	return nil
+}
+
+type prometheusJSONData struct{}
+
+var _ datasourceJSONDataType = (*prometheusJSONData)(nil)
+var _ datasourceJSONDataValidator = (*prometheusJSONData)(nil)
+
+func (p prometheusJSONData) meta() datasourceJSONDataTypeMeta {
+	return datasourceJSONDataTypeMeta{
+		field:     "prometheus",
+		pluginIDs: []string{"prometheus"},
+		desc:      "Options for Prometheus-compatible data sources (Prometheus, Mimir, Cortex, Thanos).",
+	}
+}
+
+func (p prometheusJSONData) schema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"http_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The HTTP method used to query the data source. One of `GET` or `POST`.",
+				ValidateFunc: validation.StringInSlice([]string{"GET", "POST"}, false),
+			},
+			"query_timeout": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "The timeout for queries, as a duration (e.g. `60s`).",
+				ValidateDiagFunc: common.ValidateDuration,
+			},
+			"manage_alerts": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether the alert and recording rules of the data source can be managed from Grafana's alerting UI.",
+			},
+			"alertmanager_uid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The UID of the `alertmanager` data source that receives the alerts of the data source's rules. Checked when `manage_alerts` is enabled.",
+			},
+			"oauth_pass_thru": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether to forward the user's upstream OAuth identity to the data source.",
+			},
+			"time_interval": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "The scrape interval of the data source, used as the lower limit of query steps (e.g. `15s`).",
+				ValidateDiagFunc: common.ValidateDuration,
+			},
+			"keep_cookies": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The names of the cookies to forward to the data source.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"disable_metrics_lookup": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether to disable the metrics lookup in the query editor. Useful for data sources with a very large number of metrics.",
+			},
+			"custom_query_parameters": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Parameters added to all queries, as a URL query string (e.g. `max_source_resolution=5m&timeout=10`).",
+				ValidateFunc: validateURLQueryString,
+			},
+			"default_editor": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The mode that the query editor opens in, e.g. in Explore. One of `builder` or `code`. Grafana's default is `builder`.",
+				ValidateFunc: validation.StringInSlice([]string{"builder", "code"}, false),
+			},
+			"cache_level": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "How long the query editor caches the metrics, labels and values it looks up. One of `None`, `Low`, `Medium` or `High`. Grafana's default is `Low`.",
+				ValidateFunc: validation.StringInSlice([]string{"None", "Low", "Medium", "High"}, false),
+			},
+			"azure_credentials": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Azure AD authentication, for Azure Monitor managed service for Prometheus.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"auth_type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The Azure authentication method. One of `clientsecret`, `msi`, `workloadidentity` or `currentuser`.",
+							ValidateFunc: validation.StringInSlice([]string{"clientsecret", "msi", "workloadidentity", "currentuser"}, false),
+						},
+						"cloud": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "AzureCloud",
+							Description:  "The Azure cloud the credentials belong to.",
+							ValidateFunc: validation.StringInSlice([]string{"AzureCloud", "AzureChinaCloud", "AzureUSGovernment"}, false),
+						},
+						"tenant_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The Azure AD tenant (directory) ID. Required with `clientsecret` authentication.",
+						},
+						"client_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The Azure AD application (client) ID. Required with `clientsecret` authentication.",
+						},
+						"client_secret": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "The Azure AD application client secret. Required with `clientsecret` authentication.",
+						},
+						"resource_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The audience of the requested token, e.g. `https://prometheus.monitor.azure.com`.",
+						},
+					},
+				},
+			},
+			"sigv4": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "AWS SigV4 authentication, for Amazon Managed Service for Prometheus.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"auth_type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The AWS authentication method. One of `default` (the AWS SDK's default credentials chain), `keys`, `credentials` (a profile of the shared credentials file) or `ec2_iam_role`.",
+							ValidateFunc: validation.StringInSlice([]string{"default", "keys", "credentials", "ec2_iam_role"}, false),
+						},
+						"region": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The AWS region of the workspace, e.g. `us-east-1`.",
+						},
+						"assume_role_arn": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The ARN of a role to assume.",
+						},
+						"external_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The external ID to use when assuming `assume_role_arn`.",
+						},
+						"profile": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The profile of the shared credentials file. Only used with `credentials` authentication.",
+						},
+						"access_key": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "The AWS access key ID. Required with `keys` authentication.",
+						},
+						"secret_key": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "The AWS secret access key. Required with `keys` authentication.",
+						},
+						"session_token": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "The AWS session token, for temporary credentials issued by STS. Only used with `keys` authentication. Like the other secrets, it's written to the secure JSON data and never read back.",
+						},
+					},
+				},
+			},
+			"ruler": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "A separate ruler endpoint, for Mimir and Cortex setups where alerting and recording rules aren't managed through the query URL.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"url": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The URL of the ruler API.",
+							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+						},
+						"basic_auth_username": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The basic auth username used to call the ruler.",
+						},
+						"basic_auth_password": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "The basic auth password used to call the ruler.",
+						},
+					},
+				},
+			},
+			"remote_write": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "A separate remote-write endpoint, for setups such as Grafana Cloud where metrics aren't written to the query URL. Grafana doesn't use it for queries, it's stored for the apps and integrations that send metrics to the data source.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"url": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The URL of the remote-write API, e.g. `https://prometheus-prod-01-eu-west-0.grafana.net/api/prom/push`.",
+							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+						},
+						"basic_auth_username": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The basic auth username used to write metrics.",
+						},
+						"basic_auth_password": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "The basic auth password used to write metrics.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (p prometheusJSONData) validate(d *schema.ResourceDiff, raw map[string]interface{}) error {
+	if creds, ok := typedJSONDataBlock(raw["azure_credentials"]); ok {
+		switch creds["auth_type"].(string) {
+		case "clientsecret":
+			for _, field := range []string{"tenant_id", "client_id", "client_secret"} {
+				if creds[field].(string) == "" {
+					return fmt.Errorf("azure_credentials: `%s` is required with `clientsecret` authentication", field)
+				}
+			}
+		case "msi", "workloadidentity":
+			if creds["client_secret"].(string) != "" {
+				return errors.New("azure_credentials: `client_secret` can only be used with `clientsecret` authentication")
+			}
+		case "currentuser":
+			if !raw["oauth_pass_thru"].(bool) {
+				return errors.New("azure_credentials: `currentuser` authentication requires `oauth_pass_thru` to be enabled")
+			}
+		}
+	}
+	if sigv4, ok := typedJSONDataBlock(raw["sigv4"]); ok {
+		if sigv4["auth_type"].(string) == "keys" {
+			for _, field := range []string{"access_key", "secret_key"} {
+				if sigv4[field].(string) == "" {
+					return fmt.Errorf("sigv4: `%s` is required with `keys` authentication", field)
+				}
+			}
+		} else {
+			for _, field := range []string{"access_key", "secret_key", "session_token"} {
+				if sigv4[field].(string) != "" {
+					return fmt.Errorf("sigv4: `%s` can only be used with `keys` authentication", field)
+				}
+			}
+		}
+		if sigv4["profile"].(string) != "" && sigv4["auth_type"].(string) != "credentials" {
+			return errors.New("sigv4: `profile` can only be used with `credentials` authentication")
+		}
+	}
+	for _, field := range []string{"ruler", "remote_write"} {
+		if endpoint, ok := typedJSONDataBlock(raw[field]); ok {
+			if endpoint["basic_auth_password"].(string) != "" && endpoint["basic_auth_username"].(string) == "" {
+				return fmt.Errorf("%s: `basic_auth_password` requires `basic_auth_username` to be set", field)
+			}
+		}
+	}
+	return nil
+}
+
+func (p prometheusJSONData) pack(jsonData map[string]interface{}, state map[string]interface{}) map[string]interface{} {
+	tfSettings := map[string]interface{}{}
+	packJSONDataString(jsonData, tfSettings, "httpMethod", "http_method")
+	packJSONDataString(jsonData, tfSettings, "queryTimeout", "query_timeout")
+	packJSONDataBool(jsonData, tfSettings, "oauthPassThru", "oauth_pass_thru")
+	packJSONDataString(jsonData, tfSettings, "timeInterval", "time_interval")
+	packJSONDataStringList(jsonData, tfSettings, "keepCookies", "keep_cookies")
+	packJSONDataBool(jsonData, tfSettings, "disableMetricsLookup", "disable_metrics_lookup")
+	packJSONDataString(jsonData, tfSettings, "customQueryParameters", "custom_query_parameters")
+	packJSONDataString(jsonData, tfSettings, "defaultEditor", "default_editor")
+	packJSONDataString(jsonData, tfSettings, "cacheLevel", "cache_level")
+	packJSONDataBool(jsonData, tfSettings, "manageAlerts", "manage_alerts")
+	packJSONDataString(jsonData, tfSettings, "alertmanagerUid", "alertmanager_uid")
+
+	if creds, ok := jsonData["azureCredentials"].(map[string]interface{}); ok {
+		tfCreds := map[string]interface{}{}
+		packJSONDataString(creds, tfCreds, "authType", "auth_type")
+		packJSONDataString(creds, tfCreds, "azureCloud", "cloud")
+		packJSONDataString(creds, tfCreds, "tenantId", "tenant_id")
+		packJSONDataString(creds, tfCreds, "clientId", "client_id")
+		// The token audience is stored at the root of the JSON data, but it's only relevant for Azure authentication.
+		packJSONDataString(jsonData, tfCreds, "azureEndpointResourceId", "resource_id")
+		if credsState, ok := typedJSONDataBlock(state["azure_credentials"]); ok {
+			packSecureFields(tfCreds, credsState, []string{"client_secret"})
+		}
+		tfSettings["azure_credentials"] = []interface{}{tfCreds}
+		delete(jsonData, "azureCredentials")
+	}
+
+	if sigv4Auth, _ := jsonData["sigV4Auth"].(bool); sigv4Auth {
+		tfSigV4 := map[string]interface{}{}
+		packJSONDataString(jsonData, tfSigV4, "sigV4AuthType", "auth_type")
+		packJSONDataString(jsonData, tfSigV4, "sigV4Region", "region")
+		packJSONDataString(jsonData, tfSigV4, "sigV4AssumeRoleArn", "assume_role_arn")
+		packJSONDataString(jsonData, tfSigV4, "sigV4ExternalId", "external_id")
+		packJSONDataString(jsonData, tfSigV4, "sigV4Profile", "profile")
+		if sigv4State, ok := typedJSONDataBlock(state["sigv4"]); ok {
+			packSecureFields(tfSigV4, sigv4State, []string{"access_key", "secret_key", "session_token"})
+		}
+		tfSettings["sigv4"] = []interface{}{tfSigV4}
+		delete(jsonData, "sigV4Auth")
+	}
+
+	if _, ok := jsonData["rulerUrl"]; ok {
+		tfRuler := map[string]interface{}{}
+		packJSONDataString(jsonData, tfRuler, "rulerUrl", "url")
+		packJSONDataString(jsonData, tfRuler, "rulerBasicAuthUser", "basic_auth_username")
+		if rulerState, ok := typedJSONDataBlock(state["ruler"]); ok {
+			packSecureFields(tfRuler, rulerState, []string{"basic_auth_password"})
+		}
+		tfSettings["ruler"] = []interface{}{tfRuler}
+	}
+
+	if _, ok := jsonData["remoteWriteUrl"]; ok {
+		tfRemoteWrite := map[string]interface{}{}
+		packJSONDataString(jsonData, tfRemoteWrite, "remoteWriteUrl", "url")
+		packJSONDataString(jsonData, tfRemoteWrite, "remoteWriteBasicAuthUser", "basic_auth_username")
+		if remoteWriteState, ok := typedJSONDataBlock(state["remote_write"]); ok {
+			packSecureFields(tfRemoteWrite, remoteWriteState, []string{"basic_auth_password"})
+		}
+		tfSettings["remote_write"] = []interface{}{tfRemoteWrite}
+	}
+	return tfSettings
+}
+
+func (p prometheusJSONData) unpack(raw map[string]interface{}, jsonData map[string]interface{}, secureJSONData map[string]string) error {
+	unpackJSONDataString(raw, jsonData, "http_method", "httpMethod")
+	unpackJSONDataString(raw, jsonData, "query_timeout", "queryTimeout")
+	unpackJSONDataBool(raw, jsonData, "oauth_pass_thru", "oauthPassThru")
+	unpackJSONDataString(raw, jsonData, "time_interval", "timeInterval")
+	unpackJSONDataStringList(raw, jsonData, "keep_cookies", "keepCookies")
+	unpackJSONDataBool(raw, jsonData, "disable_metrics_lookup", "disableMetricsLookup")
+	unpackJSONDataString(raw, jsonData, "custom_query_parameters", "customQueryParameters")
+	unpackJSONDataString(raw, jsonData, "default_editor", "defaultEditor")
+	unpackJSONDataString(raw, jsonData, "cache_level", "cacheLevel")
+	unpackJSONDataBool(raw, jsonData, "manage_alerts", "manageAlerts")
+	unpackJSONDataString(raw, jsonData, "alertmanager_uid", "alertmanagerUid")
+
+	if creds, ok := typedJSONDataBlock(raw["azure_credentials"]); ok {
+		gfCreds := map[string]interface{}{}
+		unpackJSONDataString(creds, gfCreds, "auth_type", "authType")
+		unpackJSONDataString(creds, gfCreds, "cloud", "azureCloud")
+		unpackJSONDataString(creds, gfCreds, "tenant_id", "tenantId")
+		unpackJSONDataString(creds, gfCreds, "client_id", "clientId")
+		jsonData["azureCredentials"] = gfCreds
+		unpackJSONDataString(creds, jsonData, "resource_id", "azureEndpointResourceId")
+		unpackSecureJSONDataString(creds, secureJSONData, "client_secret", "azureClientSecret")
+	}
+
+	if sigv4, ok := typedJSONDataBlock(raw["sigv4"]); ok {
+		jsonData["sigV4Auth"] = true
+		unpackJSONDataString(sigv4, jsonData, "auth_type", "sigV4AuthType")
+		unpackJSONDataString(sigv4, jsonData, "region", "sigV4Region")
+		unpackJSONDataString(sigv4, jsonData, "assume_role_arn", "sigV4AssumeRoleArn")
+		unpackJSONDataString(sigv4, jsonData, "external_id", "sigV4ExternalId")
+		unpackJSONDataString(sigv4, jsonData, "profile", "sigV4Profile")
+		unpackSecureJSONDataString(sigv4, secureJSONData, "access_key", "sigV4AccessKey")
+		unpackSecureJSONDataString(sigv4, secureJSONData, "secret_key", "sigV4SecretKey")
+		unpackSecureJSONDataString(sigv4, secureJSONData, "session_token", "sigV4SessionToken")
+	}
+
+	if ruler, ok := typedJSONDataBlock(raw["ruler"]); ok {
+		unpackJSONDataString(ruler, jsonData, "url", "rulerUrl")
+		unpackJSONDataString(ruler, jsonData, "basic_auth_username", "rulerBasicAuthUser")
+		unpackSecureJSONDataString(ruler, secureJSONData, "basic_auth_password", "rulerBasicAuthPassword")
+	}
+
+	if remoteWrite, ok := typedJSONDataBlock(raw["remote_write"]); ok {
+		unpackJSONDataString(remoteWrite, jsonData, "url", "remoteWriteUrl")
+		unpackJSONDataString(remoteWrite, jsonData, "basic_auth_username", "remoteWriteBasicAuthUser")
+		unpackSecureJSONDataString(remoteWrite, secureJSONData, "basic_auth_password", "remoteWriteBasicAuthPassword")
+	}
+	return nil
+}
+
+// grafanaCloudAppJSONData covers the data sources backing Grafana Cloud app plugins.
+// They only need to know which stack they belong to and a token to call the Cloud APIs with.
+// Some of them also generate URLs that other integrations need, those are exposed as computed outputs.
+type grafanaCloudAppJSONData struct {
+	field    string
+	pluginID string
+	app      string
+	// endpoint describes the API the app calls, for apps that need its URL. It's empty for the others.
+	endpoint string
+	// outputs maps computed attributes to the JSON data keys they're read from.
+	// Grafana only generates them on creation, so they're sent back from the state on updates.
+	outputs map[string]datasourceJSONDataOutput
+	// checkProject is set for apps whose data sources can be scoped to a project. It checks that the project exists.
+	checkProject func(apiEndpoint, token string, stackID, projectID int) error
+	// collector describes the endpoint that the app's instrumentation sends data to, for apps that collect data from clients. It's empty for the others.
+	collector string
+}
+
+type datasourceJSONDataOutput struct {
+	gfKey string
+	desc  string
+}
+
+var _ datasourceJSONDataType = (*grafanaCloudAppJSONData)(nil)
+
+func (a grafanaCloudAppJSONData) meta() datasourceJSONDataTypeMeta {
+	return datasourceJSONDataTypeMeta{
+		field:        a.field,
+		pluginIDs:    []string{a.pluginID},
+		desc:         fmt.Sprintf("Options for the data source backing the Grafana Cloud %s app.", a.app),
+		secureFields: []string{"token"},
+	}
+}
+
+func (a grafanaCloudAppJSONData) schema() *schema.Resource {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"stack_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The ID of the Grafana Cloud stack the app belongs to.",
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "A Grafana Cloud access policy token used to call the app's backend.",
+			},
+		},
+	}
+	if a.endpoint != "" {
+		r.Schema["api_endpoint"] = &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  fmt.Sprintf("The URL of the %s.", a.endpoint),
+			ValidateFunc: validation.IsURLWithHTTPS,
+		}
+	}
+	if a.checkProject != nil {
+		r.Schema["project_id"] = &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Description:  "The ID of the project the data source is scoped to. Only the project's data can be queried through the data source. When `token` is set, the project is checked to exist at plan time.",
+			ValidateFunc: validation.IntAtLeast(1),
+		}
+	}
+	if a.collector != "" {
+		r.Schema["collector_endpoint"] = &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  fmt.Sprintf("The URL of the %s.", a.collector),
+			ValidateFunc: validation.IsURLWithHTTPS,
+		}
+		r.Schema["app_key"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The key of the instrumented app that the data source is linked to. Must be set along with `collector_endpoint`.",
+		}
+		r.Schema["collector_url"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The URL that the app's instrumentation (e.g. the Faro Web SDK) sends data to, made of `collector_endpoint` and `app_key`.",
+		}
+	}
+	for tfKey, output := range a.outputs {
+		r.Schema[tfKey] = &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: output.desc,
+		}
+	}
+	return r
+}
+
+func (a grafanaCloudAppJSONData) validate(d *schema.ResourceDiff, raw map[string]interface{}) error {
+	key := "json_data.0." + a.field + ".0."
+	if a.collector != "" && d.NewValueKnown(key+"collector_endpoint") && d.NewValueKnown(key+"app_key") {
+		if (raw["collector_endpoint"].(string) == "") != (raw["app_key"].(string) == "") {
+			return errors.New("`collector_endpoint` and `app_key` must be set together")
+		}
+	}
+
+	projectID := raw["project_id"]
+	if a.checkProject == nil || projectID == nil || projectID.(int) == 0 {
+		return nil
+	}
+	// The project is only looked up when it changes, and when all the values needed to call the API are known
+	if d.Id() != "" && !d.HasChange(key+"project_id") && !d.HasChange(key+"token") {
+		return nil
+	}
+	if !d.NewValueKnown(key+"token") || !d.NewValueKnown(key+"api_endpoint") || !d.NewValueKnown(key+"stack_id") || raw["token"].(string) == "" {
+		return nil
+	}
+	apiEndpoint, _ := raw["api_endpoint"].(string)
+	return a.checkProject(apiEndpoint, raw["token"].(string), raw["stack_id"].(int), projectID.(int))
+}
+
+func (a grafanaCloudAppJSONData) pack(jsonData map[string]interface{}, state map[string]interface{}) map[string]interface{} {
+	tfSettings := map[string]interface{}{}
+	packJSONDataInt(jsonData, tfSettings, "stackId", "stack_id")
+	if a.endpoint != "" {
+		packJSONDataString(jsonData, tfSettings, "apiEndpoint", "api_endpoint")
+	}
+	if a.checkProject != nil {
+		packJSONDataInt(jsonData, tfSettings, "projectId", "project_id")
+	}
+	if a.collector != "" {
+		packJSONDataString(jsonData, tfSettings, "collectorEndpoint", "collector_endpoint")
+		packJSONDataString(jsonData, tfSettings, "appKey", "app_key")
+		tfSettings["collector_url"] = faroCollectorURL(tfSettings["collector_endpoint"], tfSettings["app_key"])
+	}
+	for tfKey, output := range a.outputs {
+		packJSONDataString(jsonData, tfSettings, output.gfKey, tfKey)
+	}
+	packSecureFields(tfSettings, state, a.meta().secureFields)
+	return tfSettings
+}
+
+func (a grafanaCloudAppJSONData) unpack(raw map[string]interface{}, jsonData map[string]interface{}, secureJSONData map[string]string) error {
+	unpackJSONDataInt(raw, jsonData, "stack_id", "stackId")
+	if a.endpoint != "" {
+		unpackJSONDataString(raw, jsonData, "api_endpoint", "apiEndpoint")
+	}
+	if a.checkProject != nil {
+		unpackJSONDataInt(raw, jsonData, "project_id", "projectId")
+	}
+	if a.collector != "" {
+		unpackJSONDataString(raw, jsonData, "collector_endpoint", "collectorEndpoint")
+		unpackJSONDataString(raw, jsonData, "app_key", "appKey")
+	}
+	for tfKey, output := range a.outputs {
+		unpackJSONDataString(raw, jsonData, tfKey, output.gfKey)
+	}
+	unpackSecureJSONDataString(raw, secureJSONData, "token", "token")
+	return nil
+}
+
+// faroCollectorURL returns the URL that the Faro Web SDK of an app sends data to, or an empty string if the app isn't linked to a collector.
+func faroCollectorURL(collectorEndpoint, appKey interface{}) string {
+	endpoint, _ := collectorEndpoint.(string)
+	key, _ := appKey.(string)
+	if endpoint == "" || key == "" {
+		return ""
+	}
+	return strings.TrimSuffix(endpoint, "/") + "/collect/" + key
+}
+
+// defaultK6APIEndpoint is the k6 Cloud API used by the k6 app when its data source doesn't set `api_endpoint`.
+const defaultK6APIEndpoint = "https://api.k6.io"
+
+var k6HTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// checkK6Project checks that a k6 project exists, using the stack and token of the k6 data source.
+func checkK6Project(apiEndpoint, token string, stackID, projectID int) error {
+	if apiEndpoint == "" {
+		apiEndpoint = defaultK6APIEndpoint
+	}
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(apiEndpoint, "/")+"/cloud/v6/projects/"+strconv.Itoa(projectID), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if stackID != 0 {
+		req.Header.Set("X-Stack-Id", strconv.Itoa(stackID))
+	}
+	resp, err := k6HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to check the k6 project %d: %w", projectID, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return fmt.Errorf("the k6 project %d does not exist", projectID)
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("failed to check the k6 project %d: the token was rejected (%s)", projectID, resp.Status)
+	default:
+		return fmt.Errorf("failed to check the k6 project %d: %s", projectID, resp.Status)
+	}
+}
+
+// grafanaCloudUsageEndpoint is the Prometheus API serving the usage and billing metrics of Grafana Cloud organizations.
+const grafanaCloudUsageEndpoint = "https://billing.grafana.net/api/prom"
+
+// grafanaCloudUsageJSONData covers the Prometheus data source querying the usage and billing metrics of a Grafana Cloud organization,
+// like the `grafanacloud-usage` data source provisioned in Cloud stacks.
+type grafanaCloudUsageJSONData struct{}
+
+var _ datasourceJSONDataType = (*grafanaCloudUsageJSONData)(nil)
+var _ datasourceJSONDataValidator = (*grafanaCloudUsageJSONData)(nil)
+
+func (u grafanaCloudUsageJSONData) meta() datasourceJSONDataTypeMeta {
+	return datasourceJSONDataTypeMeta{
+		field:     "grafana_cloud_usage",
+		pluginIDs: []string{"prometheus"},
+		desc: "Authentication of Prometheus data sources querying the usage and billing metrics of a Grafana Cloud organization, e.g. for cost dashboards. " +
+			"The data source's `url` is the usage endpoint (`" + grafanaCloudUsageEndpoint + "`), and requests use basic auth: `basic_auth_username` is the ID of the Grafana Cloud organization.",
+		secureFields: []string{"token"},
+	}
+}
+
+func (u grafanaCloudUsageJSONData) schema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "A Grafana Cloud access policy token allowed to read the organization's usage (`billing-metrics:read` scope).",
+			},
+		},
+	}
+}
+
+func (u grafanaCloudUsageJSONData) validate(d *schema.ResourceDiff, raw map[string]interface{}) error {
+	if raw["token"].(string) == "" {
+		return errors.New("`token` must be set")
+	}
+	if _, ok := d.GetOk("json_data.0.enterprise_metrics"); ok {
+		return errors.New("`grafana_cloud_usage` and `enterprise_metrics` both set the basic auth password, only one of them can be used")
+	}
+	if d.NewValueKnown("url") && d.Get("url").(string) == "" {
+		return fmt.Errorf("`url` must be set to the usage endpoint, e.g. %s", grafanaCloudUsageEndpoint)
+	}
+	if !d.Get("basic_auth_enabled").(bool) {
+		return errors.New("the token is sent with basic auth, `basic_auth_enabled` must be set")
+	}
+	if d.NewValueKnown("basic_auth_username") && d.Get("basic_auth_username").(string) == "" {
+		return errors.New("`basic_auth_username` must be set to the ID of the Grafana Cloud organization")
+	}
+	return nil
+}
+
+func (u grafanaCloudUsageJSONData) pack(jsonData map[string]interface{}, state map[string]interface{}) map[string]interface{} {
+	tfSettings := map[string]interface{}{}
+	packSecureFields(tfSettings, state, u.meta().secureFields)
+	return tfSettings
+}
+
+func (u grafanaCloudUsageJSONData) unpack(raw map[string]interface{}, jsonData map[string]interface{}, secureJSONData map[string]string) error {
+	unpackSecureJSONDataString(raw, secureJSONData, "token", "basicAuthPassword")
+	return nil
+}
+
+func validateURLQueryString(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if _, err := url.ParseQuery(v); err != nil {
+		return nil, []error{fmt.Errorf("%s is not a valid URL query string: %w", k, err)}
+	}
+	return nil, nil
+}
+
+type enterpriseMetricsJSONData struct{}
+
+var _ datasourceJSONDataType = (*enterpriseMetricsJSONData)(nil)
+var _ datasourceJSONDataValidator = (*enterpriseMetricsJSONData)(nil)
+
+func (e enterpriseMetricsJSONData) meta() datasourceJSONDataTypeMeta {
+	return datasourceJSONDataTypeMeta{
+		field:        "enterprise_metrics",
+		pluginIDs:    []string{"prometheus"},
+		desc:         "Authentication of Prometheus data sources querying Grafana Enterprise Metrics (GEM). GEM authenticates requests with basic auth: `basic_auth_username` is the tenant (or tenants, separated by `|`, with tenant federation) and the password is a GEM token.",
+		secureFields: []string{"access_token", "admin_token"},
+	}
+}
+
+func (e enterpriseMetricsJSONData) schema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"access_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The token of a GEM access policy allowed to read the metrics of the tenant, for tenant data sources. Can't be used along with `admin_token`.",
+			},
+			"admin_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "A GEM admin token, for admin data sources that can query any tenant. Can't be used along with `access_token`.",
+			},
+		},
+	}
+}
+
+func (e enterpriseMetricsJSONData) validate(d *schema.ResourceDiff, raw map[string]interface{}) error {
+	if (raw["access_token"].(string) == "") == (raw["admin_token"].(string) == "") {
+		return errors.New("exactly one of `access_token` or `admin_token` must be set")
+	}
+	if !d.Get("basic_auth_enabled").(bool) {
+		return errors.New("GEM tokens are sent with basic auth, `basic_auth_enabled` must be set")
+	}
+	if d.NewValueKnown("basic_auth_username") && d.Get("basic_auth_username").(string) == "" {
+		return errors.New("`basic_auth_username` must be set to the GEM tenant to query")
+	}
+	if _, ok := d.GetOk("tenant_id"); ok {
+		return errors.New("`tenant_id` can't be used with GEM tokens, the tenant is set in `basic_auth_username`")
+	}
+	return nil
+}
+
+func (e enterpriseMetricsJSONData) pack(jsonData map[string]interface{}, state map[string]interface{}) map[string]interface{} {
+	tfSettings := map[string]interface{}{}
+	packSecureFields(tfSettings, state, e.meta().secureFields)
+	return tfSettings
+}
+
+func (e enterpriseMetricsJSONData) unpack(raw map[string]interface{}, jsonData map[string]interface{}, secureJSONData map[string]string) error {
+	unpackSecureJSONDataString(raw, secureJSONData, "access_token", "basicAuthPassword")
+	unpackSecureJSONDataString(raw, secureJSONData, "admin_token", "basicAuthPassword")
+	return nil
+}
+
+type falconLogScaleJSONData struct{}
+
+var _ datasourceJSONDataType = (*falconLogScaleJSONData)(nil)
+
+func (l falconLogScaleJSONData) meta() datasourceJSONDataTypeMeta {
+	return datasourceJSONDataTypeMeta{
+		field:        "falcon_logscale",
+		pluginIDs:    []string{"grafana-falconlogscale-datasource"},
+		desc:         "Options for the CrowdStrike Falcon LogScale (formerly Humio) plugin.",
+		secureFields: []string{"token"},
+	}
+}
+
+func (l falconLogScaleJSONData) schema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"base_url": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The URL of the LogScale instance.",
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"default_repository": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The repository queried when a query doesn't specify one.",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[a-zA-Z0-9_.\-]+$`), "repository names can only contain letters, digits, dots, dashes and underscores"),
+			},
+			"default_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The default query editor mode. One of `LQL` or `Repositories`.",
+				ValidateFunc: validation.StringInSlice([]string{"LQL", "Repositories"}, false),
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The LogScale API token.",
+			},
+		},
+	}
+}
+
+func (l falconLogScaleJSONData) pack(jsonData map[string]interface{}, state map[string]interface{}) map[string]interface{} {
+	tfSettings := map[string]interface{}{}
+	packJSONDataString(jsonData, tfSettings, "baseURL", "base_url")
+	packJSONDataString(jsonData, tfSettings, "defaultRepository", "default_repository")
+	packJSONDataString(jsonData, tfSettings, "defaultMode", "default_mode")
+	packSecureFields(tfSettings, state, l.meta().secureFields)
+	return tfSettings
+}
+
+func (l falconLogScaleJSONData) unpack(raw map[string]interface{}, jsonData map[string]interface{}, secureJSONData map[string]string) error {
+	unpackJSONDataString(raw, jsonData, "base_url", "baseURL")
+	unpackJSONDataString(raw, jsonData, "default_repository", "defaultRepository")
+	unpackJSONDataString(raw, jsonData, "default_mode", "defaultMode")
+	unpackSecureJSONDataString(raw, secureJSONData, "token", "accessToken")
+	return nil
+}
+
+type lokiJSONData struct{}
+
+var _ datasourceJSONDataType = (*lokiJSONData)(nil)
+
+func (l lokiJSONData) meta() datasourceJSONDataTypeMeta {
+	return datasourceJSONDataTypeMeta{
+		field:     "loki",
+		pluginIDs: []string{"loki"},
+		desc:      "Options for Loki data sources. Other options can be set in `json_data_encoded`.",
+	}
+}
+
+func (l lokiJSONData) schema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"keep_cookies": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The names of the cookies to forward to the data source, e.g. the session cookie of an authenticating proxy.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"manage_alerts": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether the alert and recording rules of the data source can be managed from Grafana's alerting UI.",
+			},
+			"max_lines": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The default maximum number of log lines returned by queries, e.g. in Explore. Grafana's default is 1000. Loki rejects queries asking for more lines than its `max_entries_limit_per_query` (5000 by default).",
+				ValidateFunc: validation.IntBetween(1, 100000),
+			},
+			"derived_field": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Fields extracted from log lines, labels or structured metadata, shown as links in the log details.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the field, shown in the log details.",
+						},
+						"matcher_type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "regex",
+							Description:  "How the value of the field is found. With `regex`, `matcher_regex` is matched against the log line. With `label`, the value is taken from the label or structured metadata named `matcher_regex`, e.g. `trace_id` sent as structured metadata by OpenTelemetry.",
+							ValidateFunc: validation.StringInSlice([]string{"regex", "label"}, false),
+						},
+						"matcher_regex": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The regular expression whose first capture group is the value of the field, or the name of the label or structured metadata when `matcher_type` is `label`.",
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The URL of an external link, or the query of an internal link to `datasource_uid`. `${__value.raw}` is replaced by the value of the field.",
+						},
+						"url_display_label": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The text of the link. Defaults to the URL.",
+						},
+						"datasource_uid": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The UID of the data source an internal link opens, e.g. a Tempo data source to open traces. If not set, the link is an external link to `url`.",
+						},
+						"target_blank": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Whether external links open in a new tab.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (l lokiJSONData) validate(d *schema.ResourceDiff, raw map[string]interface{}) error {
+	for i, field := range raw["derived_field"].([]interface{}) {
+		field, ok := field.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if field["datasource_uid"].(string) != "" && field["target_blank"].(bool) {
+			return fmt.Errorf("derived_field.%d: `target_blank` can only be set on external links, without `datasource_uid`", i)
+		}
+	}
+	return nil
+}
+
+func (l lokiJSONData) pack(jsonData map[string]interface{}, state map[string]interface{}) map[string]interface{} {
+	tfSettings := map[string]interface{}{}
+	packJSONDataStringList(jsonData, tfSettings, "keepCookies", "keep_cookies")
+	packJSONDataBool(jsonData, tfSettings, "manageAlerts", "manage_alerts")
+	// Grafana's UI saves the max lines as a string
+	switch v := jsonData["maxLines"].(type) {
+	case string:
+		if maxLines, err := strconv.Atoi(v); err == nil {
+			tfSettings["max_lines"] = maxLines
+			delete(jsonData, "maxLines")
+		}
+	case float64:
+		tfSettings["max_lines"] = int(v)
+		delete(jsonData, "maxLines")
+	}
+	// Derived fields are only read into the block if they're managed with it, rather than with `json_data_encoded`
+	if fields, ok := jsonData["derivedFields"].([]interface{}); ok && len(state["derived_field"].([]interface{})) > 0 {
+		tfFields := make([]interface{}, 0, len(fields))
+		for _, field := range fields {
+			field, ok := field.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			tfField := map[string]interface{}{"matcher_type": "regex"}
+			packJSONDataString(field, tfField, "name", "name")
+			packJSONDataString(field, tfField, "matcherType", "matcher_type")
+			packJSONDataString(field, tfField, "matcherRegex", "matcher_regex")
+			packJSONDataString(field, tfField, "url", "url")
+			packJSONDataString(field, tfField, "urlDisplayLabel", "url_display_label")
+			packJSONDataString(field, tfField, "datasourceUid", "datasource_uid")
+			packJSONDataBool(field, tfField, "targetBlank", "target_blank")
+			tfFields = append(tfFields, tfField)
+		}
+		tfSettings["derived_field"] = tfFields
+		delete(jsonData, "derivedFields")
+	}
+	return tfSettings
+}
+
+func (l lokiJSONData) unpack(raw map[string]interface{}, jsonData map[string]interface{}, secureJSONData map[string]string) error {
+	unpackJSONDataStringList(raw, jsonData, "keep_cookies", "keepCookies")
+	unpackJSONDataBool(raw, jsonData, "manage_alerts", "manageAlerts")
+	if maxLines, _ := raw["max_lines"].(int); maxLines != 0 {
+		jsonData["maxLines"] = strconv.Itoa(maxLines)
+	}
+	if tfFields, _ := raw["derived_field"].([]interface{}); len(tfFields) > 0 {
+		fields := make([]interface{}, 0, len(tfFields))
+		for _, tfField := range tfFields {
+			tfField, ok := tfField.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			field := map[string]interface{}{}
+			unpackJSONDataString(tfField, field, "name", "name")
+			unpackJSONDataString(tfField, field, "matcher_type", "matcherType")
+			unpackJSONDataString(tfField, field, "matcher_regex", "matcherRegex")
+			unpackJSONDataString(tfField, field, "url", "url")
+			unpackJSONDataString(tfField, field, "url_display_label", "urlDisplayLabel")
+			unpackJSONDataString(tfField, field, "datasource_uid", "datasourceUid")
+			unpackJSONDataBool(tfField, field, "target_blank", "targetBlank")
+			fields = append(fields, field)
+		}
+		jsonData["derivedFields"] = fields
+	}
+	return nil
+}
+
+type mongoDBJSONData struct{}
+
+var _ datasourceJSONDataType = (*mongoDBJSONData)(nil)
+
+func (m mongoDBJSONData) meta() datasourceJSONDataTypeMeta {
+	return datasourceJSONDataTypeMeta{
+		field:        "mongodb",
+		pluginIDs:    []string{"grafana-mongodb-datasource"},
+		desc:         "Options for the MongoDB plugin.",
+		secureFields: []string{"connection_string", "password"},
+	}
+}
+
+func (m mongoDBJSONData) schema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"connection_string": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				Description:  "The MongoDB connection string, e.g. `mongodb+srv://cluster0.example.mongodb.net`. It's kept secret since it may contain credentials.",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^mongodb(\+srv)?://`), "the connection string must start with `mongodb://` or `mongodb+srv://`"),
+			},
+			"connection_options": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Connection options, as a URL query string (e.g. `authSource=admin&tls=true`).",
+				ValidateFunc: validateURLQueryString,
+			},
+			"default_database": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The database used when a query doesn't specify one.",
+			},
+			"username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The user to authenticate with, when credentials aren't part of the connection string.",
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The password of `username`.",
+			},
+		},
+	}
+}
+
+func (m mongoDBJSONData) pack(jsonData map[string]interface{}, state map[string]interface{}) map[string]interface{} {
+	tfSettings := map[string]interface{}{}
+	packJSONDataString(jsonData, tfSettings, "connectionOptions", "connection_options")
+	packJSONDataString(jsonData, tfSettings, "database", "default_database")
+	packJSONDataString(jsonData, tfSettings, "user", "username")
+	packSecureFields(tfSettings, state, m.meta().secureFields)
+	return tfSettings
+}
+
+func (m mongoDBJSONData) unpack(raw map[string]interface{}, jsonData map[string]interface{}, secureJSONData map[string]string) error {
+	unpackJSONDataString(raw, jsonData, "connection_options", "connectionOptions")
+	unpackJSONDataString(raw, jsonData, "default_database", "database")
+	unpackJSONDataString(raw, jsonData, "username", "user")
+	unpackSecureJSONDataString(raw, secureJSONData, "connection_string", "connection")
+	unpackSecureJSONDataString(raw, secureJSONData, "password", "password")
+	return nil
+}
+
+type mysqlJSONData struct{}
+
+var _ datasourceJSONDataType = (*mysqlJSONData)(nil)
+var _ datasourceJSONDataValidator = (*mysqlJSONData)(nil)
+
+func (m mysqlJSONData) meta() datasourceJSONDataTypeMeta {
+	return datasourceJSONDataTypeMeta{
+		field:        "mysql",
+		pluginIDs:    []string{"mysql"},
+		desc:         "Options for MySQL-compatible data sources (MySQL, MariaDB, Percona Server), which all use the `mysql` type.",
+		secureFields: []string{"password"},
+	}
+}
+
+func (m mysqlJSONData) schema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"timezone": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The time zone of the database session, as an offset (e.g. `+02:00`) or a time zone name (e.g. `Europe/Berlin`). The server's time zone is used if not set.",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^([+-]\d{2}:\d{2}|[A-Za-z_]+(/[A-Za-z0-9_+-]+)*)$`), "the time zone must be an offset (e.g. `+02:00`) or a time zone name (e.g. `Europe/Berlin`)"),
+			},
+			"allow_cleartext_passwords": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether to allow the cleartext authentication plugin, used e.g. by the PAM authentication of MariaDB and Percona Server.",
+			},
+			"max_open_conns": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The maximum number of open connections to the database. Grafana's default is used if not set.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_idle_conns": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The maximum number of idle connections. Can't be used along with `max_idle_conns_auto`.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_idle_conns_auto": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether the maximum number of idle connections follows `max_open_conns`.",
+			},
+			"conn_max_lifetime": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The maximum time, in seconds, that a connection is reused. Grafana's default is used if not set.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"tls_configuration_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "How the TLS certificates are given: `file-content` for certificates set in `secure_json_data_encoded` (`tlsCACert`, `tlsClientCert` and `tlsClientKey`), or `file-path` for files on the Grafana server.",
+				ValidateFunc: validation.StringInSlice([]string{"file-path", "file-content"}, false),
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The password of `username`.",
+			},
+		},
+	}
+}
+
+func (m mysqlJSONData) validate(d *schema.ResourceDiff, raw map[string]interface{}) error {
+	if raw["max_idle_conns"].(int) != 0 && raw["max_idle_conns_auto"].(bool) {
+		return errors.New("`max_idle_conns` can't be set when `max_idle_conns_auto` is enabled")
+	}
+	if maxOpen, maxIdle := raw["max_open_conns"].(int), raw["max_idle_conns"].(int); maxOpen != 0 && maxIdle > maxOpen {
+		return errors.New("`max_idle_conns` can't be greater than `max_open_conns`")
+	}
+	return nil
+}
+
+func (m mysqlJSONData) pack(jsonData map[string]interface{}, state map[string]interface{}) map[string]interface{} {
+	tfSettings := map[string]interface{}{}
+	packJSONDataString(jsonData, tfSettings, "timezone", "timezone")
+	packJSONDataBool(jsonData, tfSettings, "allowCleartextPasswords", "allow_cleartext_passwords")
+	packJSONDataInt(jsonData, tfSettings, "maxOpenConns", "max_open_conns")
+	packJSONDataInt(jsonData, tfSettings, "maxIdleConns", "max_idle_conns")
+	packJSONDataBool(jsonData, tfSettings, "maxIdleConnsAuto", "max_idle_conns_auto")
+	packJSONDataInt(jsonData, tfSettings, "connMaxLifetime", "conn_max_lifetime")
+	packJSONDataString(jsonData, tfSettings, "tlsConfigurationMethod", "tls_configuration_method")
+	packSecureFields(tfSettings, state, m.meta().secureFields)
+	return tfSettings
+}
+
+func (m mysqlJSONData) unpack(raw map[string]interface{}, jsonData map[string]interface{}, secureJSONData map[string]string) error {
+	unpackJSONDataString(raw, jsonData, "timezone", "timezone")
+	unpackJSONDataBool(raw, jsonData, "allow_cleartext_passwords", "allowCleartextPasswords")
+	unpackJSONDataInt(raw, jsonData, "max_open_conns", "maxOpenConns")
+	unpackJSONDataInt(raw, jsonData, "max_idle_conns", "maxIdleConns")
+	unpackJSONDataBool(raw, jsonData, "max_idle_conns_auto", "maxIdleConnsAuto")
+	unpackJSONDataInt(raw, jsonData, "conn_max_lifetime", "connMaxLifetime")
+	unpackJSONDataString(raw, jsonData, "tls_configuration_method", "tlsConfigurationMethod")
+	unpackSecureJSONDataString(raw, secureJSONData, "password", "password")
+	return nil
+}
+
+type alertmanagerJSONData struct{}
+
+var _ datasourceJSONDataType = (*alertmanagerJSONData)(nil)
+var _ datasourceJSONDataValidator = (*alertmanagerJSONData)(nil)
+
+func (a alertmanagerJSONData) meta() datasourceJSONDataTypeMeta {
+	return datasourceJSONDataTypeMeta{
+		field:     "alertmanager",
+		pluginIDs: []string{"alertmanager"},
+		desc:      "Options for external Alertmanager data sources (Prometheus Alertmanager, Mimir, Cortex).",
+	}
+}
+
+func (a alertmanagerJSONData) schema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"implementation": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "mimir",
+				Description:  "The Alertmanager implementation. One of `prometheus`, `mimir` or `cortex`.",
+				ValidateFunc: validation.StringInSlice([]string{"prometheus", "mimir", "cortex"}, false),
+			},
+			"handle_grafana_managed_alerts": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+				Description: "Whether Grafana sends its own (Grafana-managed) alerts to this Alertmanager. Only supported by the `prometheus` and `mimir` implementations. Grafana only sends alerts to external Alertmanagers when enabled in the alerting admin settings. " +
+					"With `mimir`, the alerts are sent to the tenant set in the data source's `tenant_id`, and this defaults to `true` when `tenant_id` is set.",
+			},
+		},
+	}
+}
+
+func (a alertmanagerJSONData) validate(d *schema.ResourceDiff, raw map[string]interface{}) error {
+	if d.NewValueKnown("url") && d.Get("url").(string) == "" {
+		return errors.New("`url` is required for Alertmanager data sources")
+	}
+	if raw["handle_grafana_managed_alerts"].(bool) && raw["implementation"].(string) == "cortex" {
+		return errors.New("`handle_grafana_managed_alerts` is only supported by the `prometheus` and `mimir` implementations")
+	}
+	if tenantID, ok := d.GetOk("tenant_id"); ok && tenantID.(string) != "" && raw["implementation"].(string) == "prometheus" {
+		return errors.New("`tenant_id` is only supported by the multi-tenant `mimir` and `cortex` implementations")
+	}
+	return nil
+}
+
+func (a alertmanagerJSONData) pack(jsonData map[string]interface{}, state map[string]interface{}) map[string]interface{} {
+	tfSettings := map[string]interface{}{}
+	packJSONDataString(jsonData, tfSettings, "implementation", "implementation")
+	packJSONDataBool(jsonData, tfSettings, "handleGrafanaManagedAlerts", "handle_grafana_managed_alerts")
+	return tfSettings
+}
+
+func (a alertmanagerJSONData) unpack(raw map[string]interface{}, jsonData map[string]interface{}, secureJSONData map[string]string) error {
+	unpackJSONDataString(raw, jsonData, "implementation", "implementation")
+	unpackJSONDataBool(raw, jsonData, "handle_grafana_managed_alerts", "handleGrafanaManagedAlerts")
+	return nil
+}
+
+// defaultAlertmanagerHandleGrafanaManagedAlerts sends Grafana-managed alerts to the tenant of Mimir Alertmanagers that set `tenant_id`,
+// unless `handle_grafana_managed_alerts` is set in the configuration.
+func defaultAlertmanagerHandleGrafanaManagedAlerts(d *schema.ResourceData, jsonData map[string]interface{}) {
+	if tenantID, ok := d.Get("tenant_id").(string); !ok || tenantID == "" || d.Get("type").(string) != "alertmanager" {
+		return
+	}
+	block, ok := typedJSONDataBlock(d.Get("json_data"))
+	if !ok {
+		return
+	}
+	raw, ok := typedJSONDataBlock(block["alertmanager"])
+	if !ok || raw["implementation"].(string) != "mimir" {
+		return
+	}
+
+	config := d.GetRawConfig()
+	for _, attr := range []string{"json_data", "alertmanager"} {
+		if config.IsNull() || !config.IsKnown() {
+			return
+		}
+		config = config.GetAttr(attr)
+		if config.IsNull() || !config.IsKnown() || config.LengthInt() == 0 {
+			return
+		}
+		config = config.Index(cty.NumberIntVal(0))
+	}
+	if config.IsNull() || !config.IsKnown() || !config.GetAttr("handle_grafana_managed_alerts").IsNull() {
+		return
+	}
+	jsonData["handleGrafanaManagedAlerts"] = true
+}
+
+type tempoJSONData struct{}
+
+var _ datasourceJSONDataType = (*tempoJSONData)(nil)
+var _ datasourceJSONDataValidator = (*tempoJSONData)(nil)
+
+func (t tempoJSONData) meta() datasourceJSONDataTypeMeta {
+	return datasourceJSONDataTypeMeta{
+		field:     "tempo",
+		pluginIDs: []string{"tempo"},
+		desc:      "Options for Tempo data sources.",
+	}
+}
+
+func (t tempoJSONData) schema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"traces_to_profiles": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Links the spans of traces to the profiles of a Pyroscope data source.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datasource_uid": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The UID of the `grafana-pyroscope-datasource` data source to link to.",
+						},
+						"profile_type_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The profile type shown for a span, e.g. `process_cpu:cpu:nanoseconds:cpu:nanoseconds`.",
+						},
+						"tags": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Description: "The span attributes used to query profiles, mapped to the label names to query them with. Leave a value blank to use the attribute name as label name.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"custom_query": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Whether to use `query` instead of the query generated from the tags.",
+						},
+						"query": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The custom profiles query. Only used when `custom_query` is enabled.",
+						},
+					},
+				},
+			},
+			"traces_to_logs": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Links the spans of traces to the logs of another data source, e.g. Loki.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datasource_uid": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The UID of the logs data source to link to.",
+						},
+						"tags":                  tempoTracesToTagsSchema("logs"),
+						"span_start_time_shift": tempoTracesToTimeShiftSchema("start"),
+						"span_end_time_shift":   tempoTracesToTimeShiftSchema("end"),
+						"filter_by_trace_id": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Whether to filter the logs by the trace ID.",
+						},
+						"filter_by_span_id": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Whether to filter the logs by the span ID.",
+						},
+						"custom_query": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Whether to use `query` instead of the query generated from the tags and filters.",
+						},
+						"query": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The custom logs query. Only used when `custom_query` is enabled.",
+						},
+					},
+				},
+			},
+			"traces_to_metrics": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Links the spans of traces to the metrics of a Prometheus data source, e.g. the span metrics of Tempo's metrics-generator.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datasource_uid": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "The UID of the `prometheus` data source to link to. Computed when `datasource_name` is set.",
+						},
+						"datasource_name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The name of the `prometheus` data source to link to, e.g. `grafanacloud-<stack>-prom` in Grafana Cloud. It's resolved to its UID when applying, and takes precedence over `datasource_uid`.",
+						},
+						"tags":                  tempoTracesToTagsSchema("metrics"),
+						"span_start_time_shift": tempoTracesToTimeShiftSchema("start"),
+						"span_end_time_shift":   tempoTracesToTimeShiftSchema("end"),
+						"queries": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The metrics queries linked from spans. In the queries, `$__tags` is replaced by the tags of the span.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "The name of the link.",
+									},
+									"query": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The PromQL query. Can't be empty.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"node_graph": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether to show the node graph of traces. It's built from the service graph metrics written by Tempo's metrics-generator.",
+			},
+			"service_map": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Links the service graph of the data source to the Prometheus data source its metrics are written to.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datasource_uid": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "The UID of the `prometheus` data source to link to. Computed when `datasource_name` is set.",
+						},
+						"datasource_name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The name of the `prometheus` data source to link to, e.g. `grafanacloud-<stack>-prom` in Grafana Cloud. It's resolved to its UID when applying, and takes precedence over `datasource_uid`.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (t tempoJSONData) validate(d *schema.ResourceDiff, raw map[string]interface{}) error {
+	if link, ok := typedJSONDataBlock(raw["traces_to_profiles"]); ok {
+		if link["custom_query"].(bool) && link["query"].(string) == "" {
+			return errors.New("traces_to_profiles: `query` is required when `custom_query` is enabled")
+		}
+	}
+	if link, ok := typedJSONDataBlock(raw["traces_to_logs"]); ok {
+		if link["custom_query"].(bool) && link["query"].(string) == "" {
+			return errors.New("traces_to_logs: `query` is required when `custom_query` is enabled")
+		}
+	}
+	for _, field := range []string{"service_map", "traces_to_metrics"} {
+		if link, ok := typedJSONDataBlock(raw[field]); ok {
+			if d.NewValueKnown("json_data") && link["datasource_uid"].(string) == "" && link["datasource_name"].(string) == "" {
+				return fmt.Errorf("%s: one of `datasource_uid` or `datasource_name` must be set", field)
+			}
+		}
+	}
+	if link, ok := typedJSONDataBlock(raw["traces_to_metrics"]); ok && d.NewValueKnown("json_data") {
+		for i, query := range link["queries"].([]interface{}) {
+			if query, ok := query.(map[string]interface{}); !ok || strings.TrimSpace(query["query"].(string)) == "" {
+				return fmt.Errorf("traces_to_metrics: the query of `queries.%d` can't be empty", i)
+			}
+		}
+	}
+	return nil
+}
+
+func (t tempoJSONData) pack(jsonData map[string]interface{}, state map[string]interface{}) map[string]interface{} {
+	tfSettings := map[string]interface{}{}
+	if link, ok := jsonData["tracesToProfiles"].(map[string]interface{}); ok {
+		tfLink := map[string]interface{}{}
+		packJSONDataString(link, tfLink, "datasourceUid", "datasource_uid")
+		packJSONDataString(link, tfLink, "profileTypeId", "profile_type_id")
+		packJSONDataBool(link, tfLink, "customQuery", "custom_query")
+		packJSONDataString(link, tfLink, "query", "query")
+		packTempoTracesToTags(link, tfLink)
+		tfSettings["traces_to_profiles"] = []interface{}{tfLink}
+		delete(jsonData, "tracesToProfiles")
+	}
+	if link, ok := jsonData["tracesToLogsV2"].(map[string]interface{}); ok {
+		tfLink := map[string]interface{}{}
+		packJSONDataString(link, tfLink, "datasourceUid", "datasource_uid")
+		packJSONDataString(link, tfLink, "spanStartTimeShift", "span_start_time_shift")
+		packJSONDataString(link, tfLink, "spanEndTimeShift", "span_end_time_shift")
+		packJSONDataBool(link, tfLink, "filterByTraceID", "filter_by_trace_id")
+		packJSONDataBool(link, tfLink, "filterBySpanID", "filter_by_span_id")
+		packJSONDataBool(link, tfLink, "customQuery", "custom_query")
+		packJSONDataString(link, tfLink, "query", "query")
+		packTempoTracesToTags(link, tfLink)
+		tfSettings["traces_to_logs"] = []interface{}{tfLink}
+		delete(jsonData, "tracesToLogsV2")
+	}
+	if link, ok := jsonData["tracesToMetrics"].(map[string]interface{}); ok {
+		tfLink := map[string]interface{}{}
+		packJSONDataString(link, tfLink, "datasourceUid", "datasource_uid")
+		packJSONDataString(link, tfLink, "spanStartTimeShift", "span_start_time_shift")
+		packJSONDataString(link, tfLink, "spanEndTimeShift", "span_end_time_shift")
+		packTempoTracesToTags(link, tfLink)
+		if stateLink, ok := typedJSONDataBlock(state["traces_to_metrics"]); ok {
+			tfLink["datasource_name"] = stateLink["datasource_name"]
+		}
+		if queries, ok := link["queries"].([]interface{}); ok {
+			tfQueries := make([]interface{}, 0, len(queries))
+			for _, query := range queries {
+				if query, ok := query.(map[string]interface{}); ok {
+					tfQuery := map[string]interface{}{}
+					packJSONDataString(query, tfQuery, "name", "name")
+					packJSONDataString(query, tfQuery, "query", "query")
+					tfQueries = append(tfQueries, tfQuery)
+				}
+			}
+			tfLink["queries"] = tfQueries
+		}
+		tfSettings["traces_to_metrics"] = []interface{}{tfLink}
+		delete(jsonData, "tracesToMetrics")
+	}
+	if nodeGraph, ok := jsonData["nodeGraph"].(map[string]interface{}); ok {
+		packJSONDataBool(nodeGraph, tfSettings, "enabled", "node_graph")
+		delete(jsonData, "nodeGraph")
+	}
+	if link, ok := jsonData["serviceMap"].(map[string]interface{}); ok {
+		tfLink := map[string]interface{}{}
+		packJSONDataString(link, tfLink, "datasourceUid", "datasource_uid")
+		// The name isn't stored, it's only used to find the UID
+		if stateLink, ok := typedJSONDataBlock(state["service_map"]); ok {
+			tfLink["datasource_name"] = stateLink["datasource_name"]
+		}
+		tfSettings["service_map"] = []interface{}{tfLink}
+		delete(jsonData, "serviceMap")
+	}
+	return tfSettings
+}
+
+func (t tempoJSONData) unpack(raw map[string]interface{}, jsonData map[string]interface{}, secureJSONData map[string]string) error {
+	if link, ok := typedJSONDataBlock(raw["traces_to_profiles"]); ok {
+		gfLink := map[string]interface{}{}
+		unpackJSONDataString(link, gfLink, "datasource_uid", "datasourceUid")
+		unpackJSONDataString(link, gfLink, "profile_type_id", "profileTypeId")
+		unpackJSONDataBool(link, gfLink, "custom_query", "customQuery")
+		unpackJSONDataString(link, gfLink, "query", "query")
+		unpackTempoTracesToTags(link, gfLink)
+		jsonData["tracesToProfiles"] = gfLink
+	}
+	if link, ok := typedJSONDataBlock(raw["traces_to_logs"]); ok {
+		gfLink := map[string]interface{}{}
+		unpackJSONDataString(link, gfLink, "datasource_uid", "datasourceUid")
+		unpackJSONDataString(link, gfLink, "span_start_time_shift", "spanStartTimeShift")
+		unpackJSONDataString(link, gfLink, "span_end_time_shift", "spanEndTimeShift")
+		unpackJSONDataBool(link, gfLink, "filter_by_trace_id", "filterByTraceID")
+		unpackJSONDataBool(link, gfLink, "filter_by_span_id", "filterBySpanID")
+		unpackJSONDataBool(link, gfLink, "custom_query", "customQuery")
+		unpackJSONDataString(link, gfLink, "query", "query")
+		unpackTempoTracesToTags(link, gfLink)
+		jsonData["tracesToLogsV2"] = gfLink
+	}
+	if link, ok := typedJSONDataBlock(raw["traces_to_metrics"]); ok {
+		gfLink := map[string]interface{}{}
+		// The UID of a data source set by name is set by resolveDatasourcePrometheusLinks
+		if link["datasource_name"].(string) == "" {
+			unpackJSONDataString(link, gfLink, "datasource_uid", "datasourceUid")
+		}
+		unpackJSONDataString(link, gfLink, "span_start_time_shift", "spanStartTimeShift")
+		unpackJSONDataString(link, gfLink, "span_end_time_shift", "spanEndTimeShift")
+		unpackTempoTracesToTags(link, gfLink)
+		tfQueries := link["queries"].([]interface{})
+		queries := make([]interface{}, 0, len(tfQueries))
+		for _, tfQuery := range tfQueries {
+			if tfQuery, ok := tfQuery.(map[string]interface{}); ok {
+				query := map[string]interface{}{}
+				unpackJSONDataString(tfQuery, query, "name", "name")
+				unpackJSONDataString(tfQuery, query, "query", "query")
+				queries = append(queries, query)
+			}
+		}
+		gfLink["queries"] = queries
+		jsonData["tracesToMetrics"] = gfLink
+	}
+	if raw["node_graph"].(bool) {
+		jsonData["nodeGraph"] = map[string]interface{}{"enabled": true}
+	}
+	// The UID of a data source set by name is set by resolveDatasourcePrometheusLinks
+	if link, ok := typedJSONDataBlock(raw["service_map"]); ok && link["datasource_name"].(string) == "" {
+		gfLink := map[string]interface{}{}
+		unpackJSONDataString(link, gfLink, "datasource_uid", "datasourceUid")
+		jsonData["serviceMap"] = gfLink
+	}
+	return nil
+}
+
+func tempoTracesToTagsSchema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Optional:    true,
+		Description: fmt.Sprintf("The span attributes used to query %s, mapped to the label names to query them with. Leave a value blank to use the attribute name as label name.", kind),
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+func tempoTracesToTimeShiftSchema(bound string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: fmt.Sprintf("Shifts the %s time of the span used in the linked query, e.g. `-1h` or `5m`.", bound),
+	}
+}
+
+// packTempoTracesToTags converts the `[{"key": ..., "value": ...}]` tags of a Tempo link into a map.
+func packTempoTracesToTags(link, tfLink map[string]interface{}) {
+	tags, ok := link["tags"].([]interface{})
+	if !ok {
+		return
+	}
+	tfTags := map[string]interface{}{}
+	for _, tag := range tags {
+		if tag, ok := tag.(map[string]interface{}); ok {
+			key, _ := tag["key"].(string)
+			value, _ := tag["value"].(string)
+			tfTags[key] = value
+		}
+	}
+	tfLink["tags"] = tfTags
+}
+
+// unpackTempoTracesToTags converts the tags map of a Tempo link into `[{"key": ..., "value": ...}]` objects, sorted by key.
+func unpackTempoTracesToTags(tfLink, link map[string]interface{}) {
+	tfTags := tfLink["tags"].(map[string]interface{})
+	keys := make([]string, 0, len(tfTags))
+	for key := range tfTags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	tags := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		tags = append(tags, map[string]interface{}{"key": key, "value": tfTags[key]})
+	}
+	link["tags"] = tags
+}
+
+// syntheticMonitoringJSONData covers the data source created by the Synthetic Monitoring app.
+// It links the app's API to the Prometheus and Loki data sources (and tenants) that the checks' results are written to.
+type syntheticMonitoringJSONData struct{}
+
+var _ datasourceJSONDataType = (*syntheticMonitoringJSONData)(nil)
+
+func (m syntheticMonitoringJSONData) meta() datasourceJSONDataTypeMeta {
+	return datasourceJSONDataTypeMeta{
+		field:        "synthetic_monitoring",
+		pluginIDs:    []string{"synthetic-monitoring-datasource"},
+		desc:         "Options for the data source of the Grafana Cloud Synthetic Monitoring app.",
+		secureFields: []string{"access_token"},
+	}
+}
+
+func (m syntheticMonitoringJSONData) schema() *schema.Resource {
+	linkedDatasource := func(kind string) *schema.Schema {
+		return &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: fmt.Sprintf("The %s data source that the results of the checks are written to.", kind),
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"datasource_uid": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: fmt.Sprintf("The UID of the %s data source.", kind),
+					},
+					"datasource_name": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: fmt.Sprintf("The name of the %s data source.", kind),
+					},
+					"hosted_id": {
+						Type:         schema.TypeInt,
+						Required:     true,
+						Description:  fmt.Sprintf("The ID of the Grafana Cloud %s instance (tenant) of the stack.", kind),
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+				},
+			},
+		}
+	}
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"api_host": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The URL of the Synthetic Monitoring API of the stack's region.",
+				ValidateFunc: validation.IsURLWithHTTPS,
+			},
+			"access_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The Synthetic Monitoring access token, e.g. the `sm_access_token` of a `grafana_synthetic_monitoring_installation`.",
+			},
+			"metrics": linkedDatasource("Prometheus"),
+			"logs":    linkedDatasource("Loki"),
+		},
+	}
+}
+
+func (m syntheticMonitoringJSONData) pack(jsonData map[string]interface{}, state map[string]interface{}) map[string]interface{} {
+	tfSettings := map[string]interface{}{}
+	packJSONDataString(jsonData, tfSettings, "apiHost", "api_host")
+	delete(jsonData, "initialized")
+	for _, tfKey := range []string{"metrics", "logs"} {
+		if linked, ok := jsonData[tfKey].(map[string]interface{}); ok {
+			tfLinked := map[string]interface{}{}
+			packJSONDataString(linked, tfLinked, "uid", "datasource_uid")
+			packJSONDataString(linked, tfLinked, "grafanaName", "datasource_name")
+			packJSONDataInt(linked, tfLinked, "hostedId", "hosted_id")
+			tfSettings[tfKey] = []interface{}{tfLinked}
+			delete(jsonData, tfKey)
+		}
+	}
+	packSecureFields(tfSettings, state, m.meta().secureFields)
+	return tfSettings
+}
+
+func (m syntheticMonitoringJSONData) unpack(raw map[string]interface{}, jsonData map[string]interface{}, secureJSONData map[string]string) error {
+	unpackJSONDataString(raw, jsonData, "api_host", "apiHost")
+	// The app only uses data sources that it has initialized
+	jsonData["initialized"] = true
+	for tfKey, dsType := range map[string]string{"metrics": "prometheus", "logs": "loki"} {
+		if linked, ok := typedJSONDataBlock(raw[tfKey]); ok {
+			gfLinked := map[string]interface{}{"type": dsType}
+			unpackJSONDataString(linked, gfLinked, "datasource_uid", "uid")
+			unpackJSONDataString(linked, gfLinked, "datasource_name", "grafanaName")
+			unpackJSONDataInt(linked, gfLinked, "hosted_id", "hostedId")
+			jsonData[tfKey] = gfLinked
+		}
+	}
+	unpackSecureJSONDataString(raw, secureJSONData, "access_token", "accessToken")
+	return nil
+}
@@ -3,9 +3,13 @@ package grafana
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"log"
 	"regexp"
 	"strconv"
+	"strings"
 
+	"github.com/go-openapi/runtime"
 	goapi "github.com/grafana/grafana-openapi-client-go/client"
 	"github.com/grafana/grafana-openapi-client-go/client/folders"
 	"github.com/grafana/grafana-openapi-client-go/client/search"
@@ -23,6 +27,8 @@ func resourceFolder() *common.Resource {
 	schema := &schema.Resource{
 
 		Description: `
+The folder's current permissions are read into ` + "`current_permissions`" + `. To manage them, import them into a ` + "`grafana_folder_permission`" + ` resource, with the same import ID as the folder.
+
 * [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/manage-dashboards/)
 * [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/folder/)
 `,
@@ -64,12 +70,48 @@ func resourceFolder() *common.Resource {
 			"parent_folder_uid": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 				Description: "The uid of the parent folder. " +
 					"If set, the folder will be nested. " +
 					"If not set, the folder will be created in the root folder. " +
+					"Changing it moves the folder, along with its contents. " +
 					"Note: This requires the nestedFolders feature flag to be enabled on your Grafana instance.",
 			},
+			"full_path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The slash-joined titles of the folder's parents and of the folder itself, starting from the root folder.",
+			},
+			"current_permissions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Description: "The folder's current permissions, as managed by `grafana_folder_permission`. " +
+					"Permissions inherited from parent folders or granted by custom and fixed roles aren't included. " +
+					"Empty if the user isn't allowed to read the folder's permissions.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the basic role the permission is granted to.",
+						},
+						"team_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the team the permission is granted to.",
+						},
+						"user_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the user or service account the permission is granted to.",
+						},
+						"permission": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "`View`, `Edit` or `Admin`.",
+						},
+					},
+				},
+			},
 		},
 	}
 
@@ -129,6 +171,18 @@ func UpdateFolder(ctx context.Context, d *schema.ResourceData, meta interface{})
 		return diag.FromErr(err)
 	}
 
+	// The folder may also have been moved outside of Terraform, move it back under the configured parent
+	if parentUID := d.Get("parent_folder_uid").(string); parentUID != folder.ParentUID {
+		if _, err := client.Folders.MoveFolder(folder.UID, &models.MoveFolderCommand{ParentUID: parentUID}); err != nil {
+			// Grafana versions without nested folders don't have the move endpoint
+			if common.IsNotFoundError(err) {
+				return diag.Errorf("failed to move folder %s under %q: %s. Moving folders requires nested folders (Grafana 10.3+ with the nestedFolders feature flag, or Grafana 11+). "+
+					"Otherwise, the folder must be replaced, e.g. with `terraform apply -replace`", folder.UID, parentUID, err)
+			}
+			return diag.Errorf("failed to move folder %s under %q: %s", folder.UID, parentUID, err)
+		}
+	}
+
 	return ReadFolder(ctx, d, meta)
 }
 
@@ -148,7 +202,55 @@ func ReadFolder(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 	d.Set("url", metaClient.GrafanaSubpath(folder.URL))
 	d.Set("parent_folder_uid", folder.ParentUID)
 
-	return nil
+	// The full path is informational, the folder is still read if a parent can't be
+	var diags diag.Diagnostics
+	fullPath, err := FolderFullPath(folder, func(uid string) (*models.Folder, error) {
+		return GetFolderByIDorUID(client.Folders, uid)
+	})
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("failed to compute the full path of folder %s", folder.UID),
+			Detail:   err.Error(),
+		})
+	} else {
+		d.Set("full_path", fullPath)
+	}
+
+	if err := readFolderCurrentPermissions(client, d, folder.UID); err != nil {
+		return append(diags, diag.Errorf("failed to read the permissions of folder %s: %s", folder.UID, err)...)
+	}
+
+	return diags
+}
+
+// readFolderCurrentPermissions sets the `current_permissions` attribute of a folder.
+// Users that can't read permissions can still manage folders, the attribute is then left empty.
+func readFolderCurrentPermissions(client *goapi.GrafanaHTTPAPI, d *schema.ResourceData, uid string) error {
+	resp, err := client.AccessControl.GetResourcePermissions(uid, foldersPermissionsType)
+	if err != nil {
+		if err, ok := err.(runtime.ClientResponseStatus); ok && (err.IsCode(403) || err.IsCode(404)) {
+			log.Printf("[WARN] can't read the permissions of folder %s: %s", uid, err)
+			d.Set("current_permissions", []interface{}{})
+			return nil
+		}
+		return err
+	}
+
+	permissions := []interface{}{}
+	for _, permission := range resp.Payload {
+		// Same filter as grafana_folder_permission, so that the permissions can be copied into it
+		if !permission.IsManaged || permission.IsInherited {
+			continue
+		}
+		permissions = append(permissions, map[string]interface{}{
+			"role":       permission.BuiltInRole,
+			"team_id":    strconv.FormatInt(permission.TeamID, 10),
+			"user_id":    strconv.FormatInt(permission.UserID, 10),
+			"permission": permission.Permission,
+		})
+	}
+	return d.Set("current_permissions", permissions)
 }
 
 func DeleteFolder(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -231,3 +333,29 @@ func GetFolderByIDorUID(client folders.ClientService, id string) (*models.Folder
 	}
 	return resp.GetPayload(), nil
 }
+
+// FolderFullPath returns the titles of the folder and of all its parents, from the root folder down, joined by slashes.
+// Grafana returns the parents of nested folders along with the folder. When they're missing, parents are looked up one by one with getFolder.
+func FolderFullPath(folder *models.Folder, getFolder func(uid string) (*models.Folder, error)) (string, error) {
+	seen := map[string]bool{folder.UID: true}
+	titles := []string{folder.Title}
+	for current := folder; current.ParentUID != ""; {
+		if len(current.Parents) > 0 {
+			for i := len(current.Parents) - 1; i >= 0; i-- {
+				titles = append([]string{current.Parents[i].Title}, titles...)
+			}
+			break
+		}
+		if seen[current.ParentUID] {
+			return "", fmt.Errorf("folder %s is its own ancestor", current.ParentUID)
+		}
+		parent, err := getFolder(current.ParentUID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get parent folder %s: %w", current.ParentUID, err)
+		}
+		seen[current.ParentUID] = true
+		titles = append([]string{parent.Title}, titles...)
+		current = parent
+	}
+	return strings.Join(titles, "/"), nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccFolderPermissionItem_basic(t *testing.T) {
@@ -93,6 +94,67 @@ func TestAccFolderPermissionItem_basic(t *testing.T) {
 	})
 }
 
+func TestAccFolderPermissionItem_nestedFolder(t *testing.T) {
+	testutils.CheckEnterpriseTestsEnabled(t, ">=10.3.0")
+
+	var (
+		parent     models.Folder
+		child      models.Folder
+		randomName = acctest.RandString(6)
+	)
+
+	config := fmt.Sprintf(`
+resource "grafana_folder" "parent" {
+	title = "%[1]s-parent"
+}
+
+resource "grafana_folder" "child" {
+	title             = "%[1]s-child"
+	parent_folder_uid = grafana_folder.parent.uid
+}
+
+resource "grafana_folder_permission_item" "parent_viewer" {
+	folder_uid = grafana_folder.parent.uid
+	role       = "Viewer"
+	permission = "View"
+}
+
+resource "grafana_folder_permission_item" "child_viewer" {
+	folder_uid = grafana_folder.child.uid
+	role       = "Viewer"
+	permission = "Edit"
+
+	depends_on = [grafana_folder_permission_item.parent_viewer]
+}`, randomName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					folderCheckExists.exists("grafana_folder.parent", &parent),
+					folderCheckExists.exists("grafana_folder.child", &child),
+					resource.TestCheckResourceAttr("grafana_folder_permission_item.child_viewer", "permission", "Edit"),
+				),
+			},
+			// The permission inherited from the parent folder is ignored
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				ResourceName:      "grafana_folder_permission_item.child_viewer",
+				ImportState:       true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) { return child.UID + ":role:Viewer", nil },
+				ImportStateVerify: true,
+				// The ID is imported without the org ID
+				ImportStateVerifyIgnore: []string{"id"},
+			},
+		},
+	})
+}
+
 func testAccFolderPermissionItemConfig(name string) string {
 	return fmt.Sprintf(`
 resource "grafana_folder" "testFolder" {
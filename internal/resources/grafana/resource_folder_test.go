@@ -139,12 +139,13 @@ resource grafana_folder child2 {
 					folderCheckExists.exists("grafana_folder.child1", &childFolder1),
 					resource.TestMatchResourceAttr("grafana_folder.child1", "id", defaultOrgIDRegexp),
 					resource.TestCheckResourceAttr("grafana_folder.child1", "title", "Nested Test: Child 1 "+name),
-					resource.TestCheckResourceAttrSet("grafana_folder.child1", "parent_folder_uid"),
+					resource.TestCheckResourceAttrPair("grafana_folder.child1", "parent_folder_uid", "grafana_folder.parent", "uid"),
 
 					folderCheckExists.exists("grafana_folder.child2", &childFolder2),
 					resource.TestMatchResourceAttr("grafana_folder.child2", "id", defaultOrgIDRegexp),
 					resource.TestCheckResourceAttr("grafana_folder.child2", "title", "Nested Test: Child 2 "+name),
 					resource.TestCheckResourceAttr("grafana_folder.child2", "parent_folder_uid", name+"-child1"),
+					resource.TestCheckResourceAttr("grafana_folder.child2", "full_path", fmt.Sprintf("Nested Test: Parent %[1]s/Nested Test: Child 1 %[1]s/Nested Test: Child 2 %[1]s", name)),
 				),
 			},
 			{
@@ -165,10 +166,242 @@ resource grafana_folder child2 {
 				ImportStateVerify:       true,
 				ImportStateVerifyIgnore: []string{"prevent_destroy_if_not_empty"},
 			},
+			// Changing the parent moves the folder, it isn't recreated
+			{
+				Config: fmt.Sprintf(`
+resource grafana_folder parent {
+	title = "Nested Test: Parent %[1]s"
+}
+
+resource grafana_folder child1 {
+	title = "Nested Test: Child 1 %[1]s"
+	uid = "%[1]s-child1"
+	parent_folder_uid = grafana_folder.parent.uid
+}
+
+resource grafana_folder child2 {
+	title = "Nested Test: Child 2 %[1]s"
+	parent_folder_uid = grafana_folder.parent.uid
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccFolderWasntRecreated("grafana_folder.child2", &childFolder2),
+					resource.TestCheckResourceAttrPair("grafana_folder.child2", "parent_folder_uid", "grafana_folder.parent", "uid"),
+					resource.TestCheckResourceAttr("grafana_folder.child2", "full_path", fmt.Sprintf("Nested Test: Parent %[1]s/Nested Test: Child 2 %[1]s", name)),
+				),
+			},
 		},
 	})
 }
 
+func TestAccFolder_movedOutsideTerraform(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=10.3.0")
+
+	var parentFolder1, parentFolder2, childFolder models.Folder
+	name := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	config := fmt.Sprintf(`
+resource grafana_folder parent1 {
+	title = "Move Test: Parent 1 %[1]s"
+}
+
+resource grafana_folder parent2 {
+	title = "Move Test: Parent 2 %[1]s"
+}
+
+resource grafana_folder child {
+	title = "Move Test: Child %[1]s"
+	parent_folder_uid = grafana_folder.parent1.uid
+}
+`, name)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			folderCheckExists.destroyed(&parentFolder1, nil),
+			folderCheckExists.destroyed(&parentFolder2, nil),
+			folderCheckExists.destroyed(&childFolder, nil),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					folderCheckExists.exists("grafana_folder.parent1", &parentFolder1),
+					folderCheckExists.exists("grafana_folder.parent2", &parentFolder2),
+					folderCheckExists.exists("grafana_folder.child", &childFolder),
+					resource.TestCheckResourceAttrPair("grafana_folder.child", "parent_folder_uid", "grafana_folder.parent1", "uid"),
+				),
+			},
+			// Move the child folder under the other parent, the next plan should move it back
+			{
+				Config: config,
+				Check: func(s *terraform.State) error {
+					_, err := grafanaTestClient().Folders.MoveFolder(childFolder.UID, &models.MoveFolderCommand{ParentUID: parentFolder2.UID})
+					return err
+				},
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccFolderWasntRecreated("grafana_folder.child", &childFolder),
+					resource.TestCheckResourceAttrPair("grafana_folder.child", "parent_folder_uid", "grafana_folder.parent1", "uid"),
+					func(s *terraform.State) error {
+						folder, err := grafana.GetFolderByIDorUID(grafanaTestClient().Folders, childFolder.UID)
+						if err != nil {
+							return err
+						}
+						if folder.ParentUID != parentFolder1.UID {
+							return fmt.Errorf("expected folder %s to be under %s, got %q", childFolder.UID, parentFolder1.UID, folder.ParentUID)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccFolder_currentPermissions(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=9.0.0")
+
+	var folder models.Folder
+	name := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	folderConfig := fmt.Sprintf(`
+resource grafana_folder test {
+	title = "Permissions Test %[1]s"
+}
+`, name)
+	// The default permissions of a new folder
+	permissionConfig := folderConfig + `
+resource grafana_folder_permission test {
+	folder_uid = grafana_folder.test.uid
+	permissions {
+		role       = "Viewer"
+		permission = "View"
+	}
+	permissions {
+		role       = "Editor"
+		permission = "Edit"
+	}
+}
+`
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             folderCheckExists.destroyed(&folder, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: folderConfig,
+				Check: resource.ComposeTestCheckFunc(
+					folderCheckExists.exists("grafana_folder.test", &folder),
+					resource.TestCheckTypeSetElemNestedAttrs("grafana_folder.test", "current_permissions.*", map[string]string{
+						"role":       "Viewer",
+						"team_id":    "0",
+						"user_id":    "0",
+						"permission": "View",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("grafana_folder.test", "current_permissions.*", map[string]string{
+						"role":       "Editor",
+						"permission": "Edit",
+					}),
+				),
+			},
+			{
+				ResourceName:            "grafana_folder.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"prevent_destroy_if_not_empty"},
+			},
+			// The permissions can be imported with the folder's ID
+			{
+				Config:       permissionConfig,
+				ResourceName: "grafana_folder_permission.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources["grafana_folder.test"].Primary.ID, nil
+				},
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 imported resource, got %d", len(states))
+					}
+					roles := map[string]string{}
+					attrs := states[0].Attributes
+					for k, v := range attrs {
+						if strings.HasPrefix(k, "permissions.") && strings.HasSuffix(k, ".role") && v != "" {
+							roles[v] = attrs[strings.TrimSuffix(k, ".role")+".permission"]
+						}
+					}
+					if roles["Viewer"] != "View" || roles["Editor"] != "Edit" {
+						return fmt.Errorf("expected the default role permissions to be imported, got %v", roles)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestFolderFullPath(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	folders := map[string]*models.Folder{
+		"root":   {UID: "root", Title: "Root"},
+		"middle": {UID: "middle", Title: "Middle", ParentUID: "root"},
+		"leaf":   {UID: "leaf", Title: "Leaf", ParentUID: "middle"},
+		"loop-a": {UID: "loop-a", Title: "Loop A", ParentUID: "loop-b"},
+		"loop-b": {UID: "loop-b", Title: "Loop B", ParentUID: "loop-a"},
+	}
+	lookups := 0
+	getFolder := func(uid string) (*models.Folder, error) {
+		lookups++
+		if folder, ok := folders[uid]; ok {
+			return folder, nil
+		}
+		return nil, fmt.Errorf("folder %s not found", uid)
+	}
+
+	for _, tc := range []struct {
+		name            string
+		folder          *models.Folder
+		expected        string
+		expectedError   string
+		expectedLookups int
+	}{
+		{name: "root folder", folder: folders["root"], expected: "Root"},
+		{name: "nested folder", folder: folders["leaf"], expected: "Root/Middle/Leaf", expectedLookups: 2},
+		{
+			name: "nested folder with parents",
+			folder: &models.Folder{
+				UID: "leaf", Title: "Leaf", ParentUID: "middle",
+				Parents: []*models.Folder{folders["root"], folders["middle"]},
+			},
+			expected: "Root/Middle/Leaf",
+		},
+		{name: "missing parent", folder: &models.Folder{UID: "orphan", Title: "Orphan", ParentUID: "missing"}, expectedError: "failed to get parent folder missing"},
+		{name: "parent cycle", folder: folders["loop-a"], expectedError: "folder loop-a is its own ancestor"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			lookups = 0
+			path, err := grafana.FolderFullPath(tc.folder, getFolder)
+			if tc.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+					t.Fatalf("expected error containing %q, got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if path != tc.expected {
+				t.Errorf("expected path %q, got %q", tc.expected, path)
+			}
+			if lookups != tc.expectedLookups {
+				t.Errorf("expected %d folder lookups, got %d", tc.expectedLookups, lookups)
+			}
+		})
+	}
+}
+
 func TestAccFolder_PreventDeletion(t *testing.T) {
 	testutils.CheckOSSTestsEnabled(t, ">=10.2.0") // Searching by folder UID was added in 10.2.0
 
@@ -127,7 +127,7 @@ func listLibraryPanels(ctx context.Context, client *goapi.GrafanaHTTPAPI, data *
 
 	var ids []string
 	for _, orgID := range orgIDs {
-		client = client.Clone().WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 
 		params := library_elements.NewGetLibraryElementsParams().WithKind(common.Ref(libraryPanelKind))
 		resp, err := client.LibraryElements.GetLibraryElements(params)
@@ -47,6 +47,9 @@ func resourceOrganization() *common.Resource {
 This resource represents an instance-scoped resource and uses Grafana's admin APIs.
 It does not work with API tokens or service accounts which are org-scoped.
 You must use basic auth.
+
+Grafana has no per-organization setting for the role of new members: users joining an organization get the role set by the
+` + "`auto_assign_org_role`" + ` option of the Grafana server, and service accounts get the role set when they're created.
 `,
 
 		CreateContext: CreateOrganization,
@@ -141,6 +144,46 @@ Note: users specified here must already exist in Grafana, unless 'create_users'
 set to true. This feature is only available in Grafana 10.2+.
 `,
 			},
+			"address": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "The postal address of the organization.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address1": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The first line of the address.",
+						},
+						"address2": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The second line of the address.",
+						},
+						"city": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The city of the address.",
+						},
+						"zip_code": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The zip or postal code of the address.",
+						},
+						"state": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The state or region of the address.",
+						},
+						"country": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The country of the address.",
+						},
+					},
+				},
+			},
 		},
 	}
 
@@ -187,6 +230,11 @@ func CreateOrganization(ctx context.Context, d *schema.ResourceData, meta interf
 		return diag.FromErr(err)
 	}
 	d.SetId(strconv.FormatInt(*resp.Payload.OrgID, 10))
+	if _, ok := d.GetOk("address"); ok {
+		if err := updateOrganizationAddress(client, *resp.Payload.OrgID, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	if err = UpdateUsers(d, meta); err != nil {
 		return diag.FromErr(err)
 	}
@@ -209,6 +257,7 @@ func ReadOrganization(ctx context.Context, d *schema.ResourceData, meta interfac
 	org := resp.Payload
 	d.Set("org_id", org.ID)
 	d.Set("name", org.Name)
+	d.Set("address", flattenOrganizationAddress(org.Address))
 	if err := ReadUsers(d, meta); err != nil {
 		return diag.FromErr(err)
 	}
@@ -227,6 +276,11 @@ func UpdateOrganization(ctx context.Context, d *schema.ResourceData, meta interf
 			return diag.FromErr(err)
 		}
 	}
+	if d.HasChange("address") {
+		if err := updateOrganizationAddress(client, orgID, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	if err := UpdateUsers(d, meta); err != nil {
 		return diag.FromErr(err)
 	}
@@ -234,6 +288,37 @@ func UpdateOrganization(ctx context.Context, d *schema.ResourceData, meta interf
 	return nil
 }
 
+func updateOrganizationAddress(client *goapi.GrafanaHTTPAPI, orgID int64, d *schema.ResourceData) error {
+	form := models.UpdateOrgAddressForm{}
+	if v, ok := d.GetOk("address.0"); ok {
+		address := v.(map[string]interface{})
+		form.Address1 = address["address1"].(string)
+		form.Address2 = address["address2"].(string)
+		form.City = address["city"].(string)
+		form.Zipcode = address["zip_code"].(string)
+		form.State = address["state"].(string)
+		form.Country = address["country"].(string)
+	}
+	_, err := client.Orgs.UpdateOrgAddress(orgID, &form)
+	return err
+}
+
+func flattenOrganizationAddress(address *models.Address) []interface{} {
+	if address == nil || *address == (models.Address{}) {
+		return nil
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"address1": address.Address1,
+			"address2": address.Address2,
+			"city":     address.City,
+			"zip_code": address.ZipCode,
+			"state":    address.State,
+			"country":  address.Country,
+		},
+	}
+}
+
 func DeleteOrganization(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, err := OAPIGlobalClient(meta)
 	if err != nil {
@@ -2,9 +2,12 @@ package grafana
 
 import (
 	"context"
+	"fmt"
 	"strconv"
+	"strings"
 
 	goapi "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/search"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -37,9 +40,16 @@ func resourceOrganizationPreferences() *common.Resource {
 				ValidateFunc: validation.StringInSlice([]string{"light", "dark", "system", ""}, false),
 			},
 			"home_dashboard_uid": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "The Organization home dashboard UID. This is only available in Grafana 9.0+.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The Organization home dashboard UID. This is only available in Grafana 9.0+.",
+				ConflictsWith: []string{"home_dashboard_title"},
+			},
+			"home_dashboard_title": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The title of the Organization home dashboard. It's resolved to a dashboard UID when applying, and must match exactly one dashboard. This is only available in Grafana 9.0+.",
+				ConflictsWith: []string{"home_dashboard_uid"},
 			},
 			"timezone": {
 				Type:         schema.TypeString,
@@ -74,9 +84,14 @@ func listOrganizationPreferences(ctx context.Context, client *goapi.GrafanaHTTPA
 func CreateOrganizationPreferences(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, orgID := OAPIClientFromNewOrgResource(meta, d)
 
-	_, err := client.OrgPreferences.UpdateOrgPreferences(&models.UpdatePrefsCmd{
+	homeDashboardUID, err := homeDashboardUIDFromTitle(client, d.Get("home_dashboard_uid").(string), d.Get("home_dashboard_title").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = client.OrgPreferences.UpdateOrgPreferences(&models.UpdatePrefsCmd{
 		Theme:            d.Get("theme").(string),
-		HomeDashboardUID: d.Get("home_dashboard_uid").(string),
+		HomeDashboardUID: homeDashboardUID,
 		Timezone:         d.Get("timezone").(string),
 		WeekStart:        d.Get("week_start").(string),
 	})
@@ -101,7 +116,15 @@ func ReadOrganizationPreferences(ctx context.Context, d *schema.ResourceData, me
 
 	d.Set("org_id", d.Id())
 	d.Set("theme", prefs.Theme)
-	d.Set("home_dashboard_uid", prefs.HomeDashboardUID)
+	if d.Get("home_dashboard_title").(string) != "" {
+		title, err := homeDashboardTitle(client, prefs.HomeDashboardUID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("home_dashboard_title", title)
+	} else {
+		d.Set("home_dashboard_uid", prefs.HomeDashboardUID)
+	}
 	d.Set("timezone", prefs.Timezone)
 	d.Set("week_start", prefs.WeekStart)
 
@@ -122,3 +145,63 @@ func DeleteOrganizationPreferences(ctx context.Context, d *schema.ResourceData,
 
 	return nil
 }
+
+// homeDashboardUIDFromTitle returns the UID of the home dashboard, which is either set directly or resolved from its title.
+func homeDashboardUIDFromTitle(client *goapi.GrafanaHTTPAPI, uid, title string) (string, error) {
+	if title == "" {
+		return uid, nil
+	}
+	resp, err := client.Search.Search(search.NewSearchParams().WithType(common.Ref("dash-db")).WithQuery(&title))
+	if err != nil {
+		return "", fmt.Errorf("failed to search for the home dashboard %q: %w", title, err)
+	}
+	return ResolveDashboardUIDByTitle(title, resp.Payload)
+}
+
+// ResolveDashboardUIDByTitle returns the UID of the dashboard with the given title, among search results.
+// Searching by title also matches partial titles, so only exact matches are considered. There must be exactly one.
+func ResolveDashboardUIDByTitle(title string, hits []*models.Hit) (string, error) {
+	var matches []*models.Hit
+	for _, hit := range hits {
+		if hit.Title == title {
+			matches = append(matches, hit)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no dashboard found with title %q", title)
+	case 1:
+		return matches[0].UID, nil
+	}
+
+	found := make([]string, len(matches))
+	for i, hit := range matches {
+		folder := hit.FolderTitle
+		if folder == "" {
+			folder = "General"
+		}
+		found[i] = fmt.Sprintf("%s (in folder %s)", hit.UID, folder)
+	}
+	return "", fmt.Errorf("%d dashboards found with title %q, use the dashboard UID instead: %s", len(matches), title, strings.Join(found, ", "))
+}
+
+// homeDashboardTitle returns the title of the current home dashboard, to detect when it's changed outside of Terraform.
+func homeDashboardTitle(client *goapi.GrafanaHTTPAPI, uid string) (string, error) {
+	if uid == "" {
+		return "", nil
+	}
+	resp, err := client.Dashboards.GetDashboardByUID(uid)
+	if err != nil {
+		if common.IsNotFoundError(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	model, ok := resp.Payload.Dashboard.(map[string]interface{})
+	if !ok {
+		return "", nil
+	}
+	title, _ := model["title"].(string)
+	return title, nil
+}
@@ -3,11 +3,13 @@ package grafana_test
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/resources/grafana"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -137,6 +139,96 @@ func TestAccResourceOrganizationPreferences(t *testing.T) {
 	})
 }
 
+func TestAccResourceOrganizationPreferences_homeDashboardTitle(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=9.0.0")
+
+	var org models.OrgDetailsDTO
+	name := acctest.RandString(10)
+
+	config := func(homeDashboard string) string {
+		return fmt.Sprintf(`
+resource "grafana_organization" "test" {
+	name = "%[1]s"
+}
+
+resource "grafana_dashboard" "first" {
+	org_id = grafana_organization.test.id
+	config_json = jsonencode({
+	  title = "%[1]s first"
+	  uid   = "%[1]s-first"
+	})
+}
+
+resource "grafana_dashboard" "second" {
+	org_id = grafana_organization.test.id
+	config_json = jsonencode({
+	  title = "%[1]s second"
+	  uid   = "%[1]s-second"
+	})
+}
+
+resource "grafana_organization_preferences" "test" {
+  org_id               = grafana_organization.test.id
+  home_dashboard_title = "%[1]s %[2]s"
+  depends_on           = [grafana_dashboard.first, grafana_dashboard.second]
+}
+`, name, homeDashboard)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             orgCheckExists.destroyed(&org, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: config("first"),
+				Check: resource.ComposeTestCheckFunc(
+					orgCheckExists.exists("grafana_organization.test", &org),
+					testAccCheckOrganizationPreferences(&org, models.Preferences{HomeDashboardUID: name + "-first"}),
+					resource.TestCheckResourceAttr("grafana_organization_preferences.test", "home_dashboard_title", name+" first"),
+					resource.TestCheckResourceAttr("grafana_organization_preferences.test", "home_dashboard_uid", ""),
+				),
+			},
+			{
+				Config: config("second"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOrganizationPreferences(&org, models.Preferences{HomeDashboardUID: name + "-second"}),
+					resource.TestCheckResourceAttr("grafana_organization_preferences.test", "home_dashboard_title", name+" second"),
+				),
+			},
+			{
+				Config:      config("third"),
+				ExpectError: regexp.MustCompile(fmt.Sprintf(`no dashboard found with title "%s third"`, name)),
+			},
+		},
+	})
+}
+
+func TestResolveDashboardUIDByTitle(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	hits := []*models.Hit{
+		{UID: "prod", Title: "Production"},
+		{UID: "prod-old", Title: "Production (old)"},
+		{UID: "staging-1", Title: "Staging", FolderTitle: "Team A"},
+		{UID: "staging-2", Title: "Staging"},
+	}
+
+	uid, err := grafana.ResolveDashboardUIDByTitle("Production", hits)
+	if err != nil || uid != "prod" {
+		t.Errorf("expected the prod dashboard, got %q (error: %v)", uid, err)
+	}
+
+	if _, err := grafana.ResolveDashboardUIDByTitle("Prod", hits); err == nil || err.Error() != `no dashboard found with title "Prod"` {
+		t.Errorf("expected a not found error for a partial title, got %v", err)
+	}
+
+	_, err = grafana.ResolveDashboardUIDByTitle("Staging", hits)
+	expected := `2 dashboards found with title "Staging", use the dashboard UID instead: staging-1 (in folder Team A), staging-2 (in folder General)`
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected error %q, got %v", expected, err)
+	}
+}
+
 func testAccCheckOrganizationPreferences(org *models.OrgDetailsDTO, expectedPrefs models.Preferences) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		client := grafanaTestClient().WithOrgID(org.ID)
@@ -156,6 +248,9 @@ func testAccCheckOrganizationPreferences(org *models.OrgDetailsDTO, expectedPref
 		if gotPrefs.WeekStart != expectedPrefs.WeekStart {
 			errs = append(errs, fmt.Sprintf("expected organization preferences week start '%s'; got '%s'", expectedPrefs.WeekStart, gotPrefs.WeekStart))
 		}
+		if expectedPrefs.HomeDashboardUID != "" && gotPrefs.HomeDashboardUID != expectedPrefs.HomeDashboardUID {
+			errs = append(errs, fmt.Sprintf("expected organization preferences home dashboard '%s'; got '%s'", expectedPrefs.HomeDashboardUID, gotPrefs.HomeDashboardUID))
+		}
 
 		if len(errs) > 0 {
 			return errors.New(strings.Join(errs, "\n"))
@@ -7,6 +7,7 @@ import (
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -197,6 +198,55 @@ func TestAccOrganization_roleNoneUser(t *testing.T) {
 	})
 }
 
+func TestAccOrganization_address(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var org models.OrgDetailsDTO
+	orgName := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             orgCheckExists.destroyed(&org, &org),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrganizationConfig_address(orgName, "Montreal"),
+				Check: resource.ComposeTestCheckFunc(
+					orgCheckExists.exists("grafana_organization.test", &org),
+					resource.TestCheckResourceAttr("grafana_organization.test", "address.#", "1"),
+					resource.TestCheckResourceAttr("grafana_organization.test", "address.0.address1", "123 Main St"),
+					resource.TestCheckResourceAttr("grafana_organization.test", "address.0.city", "Montreal"),
+					resource.TestCheckResourceAttr("grafana_organization.test", "address.0.country", "Canada"),
+					func(s *terraform.State) error {
+						if org.Address == nil || org.Address.City != "Montreal" {
+							return fmt.Errorf("expected the organization city to be Montreal, got %+v", org.Address)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccOrganizationConfig_address(orgName, "Toronto"),
+				Check: resource.ComposeTestCheckFunc(
+					orgCheckExists.exists("grafana_organization.test", &org),
+					resource.TestCheckResourceAttr("grafana_organization.test", "address.0.city", "Toronto"),
+					func(s *terraform.State) error {
+						if org.Address == nil || org.Address.City != "Toronto" {
+							return fmt.Errorf("expected the organization city to be Toronto, got %+v", org.Address)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:            "grafana_organization.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"admins", "admin_user", "create_users"},
+			},
+		},
+	})
+}
+
 func TestAccOrganization_createManyUsers_longtest(t *testing.T) {
 	if testing.Short() { // Also named "longtest" to allow targeting with -run=.*longtest
 		t.Skip("skipping test in short mode")
@@ -326,6 +376,20 @@ func TestAccOrganization_externalUser(t *testing.T) {
 	})
 }
 
+func testAccOrganizationConfig_address(name, city string) string {
+	return fmt.Sprintf(`
+resource "grafana_organization" "test" {
+	name = "%s"
+	address {
+		address1 = "123 Main St"
+		city     = "%s"
+		zip_code = "H0H 0H0"
+		country  = "Canada"
+	}
+}
+`, name, city)
+}
+
 const testAccOrganizationConfig_basic = `
 resource "grafana_organization" "test" {
     name = "terraform-acc-test"
@@ -12,6 +12,7 @@ import (
 	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePlaylist() *common.Resource {
@@ -51,20 +52,24 @@ func resourcePlaylist() *common.Resource {
 							Computed: true,
 						},
 						"order": {
-							Type:     schema.TypeInt,
-							Required: true,
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "The position of the item in the playlist. Items are shown in ascending order.",
 						},
 						"title": {
 							Type:     schema.TypeString,
 							Required: true,
 						},
 						"type": {
-							Type:     schema.TypeString,
-							Optional: true,
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "The type of the item. With `dashboard_by_uid`, `value` is the UID of a dashboard. With `dashboard_by_tag`, `value` is a tag and the playlist shows all of the dashboards with this tag, including those tagged later. `dashboard_by_id` is deprecated in favor of `dashboard_by_uid`.",
+							ValidateFunc: validation.StringInSlice([]string{"dashboard_by_uid", "dashboard_by_tag", "dashboard_by_id"}, false),
 						},
 						"value": {
-							Type:     schema.TypeString,
-							Optional: true,
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The dashboard UID, tag or ID of the item, depending on its `type`.",
 						},
 					},
 				},
@@ -88,7 +93,7 @@ func listPlaylists(ctx context.Context, client *goapi.GrafanaHTTPAPI, data *List
 
 	var ids []string
 	for _, orgID := range orgIDs {
-		client = client.Clone().WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 
 		resp, err := client.Playlists.SearchPlaylists(playlists.NewSearchPlaylistsParams())
 		if err != nil {
@@ -126,6 +126,67 @@ func TestAccPlaylist_update(t *testing.T) {
 	})
 }
 
+func TestAccPlaylist_dashboardByTag(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=9.0.0")
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	var playlist models.Playlist
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             playlistCheckExists.destroyed(&playlist, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "grafana_dashboard" "test" {
+	config_json = jsonencode({
+		title = %[1]q
+		uid   = %[1]q
+	})
+}
+
+resource "grafana_playlist" "test" {
+	name     = %[1]q
+	interval = "5m"
+
+	item {
+		order = 1
+		title = "Production dashboards"
+		type  = "dashboard_by_tag"
+		value = "production"
+	}
+
+	item {
+		order = 2
+		title = "Overview"
+		type  = "dashboard_by_uid"
+		value = grafana_dashboard.test.uid
+	}
+}`, rName),
+				Check: resource.ComposeTestCheckFunc(
+					playlistCheckExists.exists(paylistResource, &playlist),
+					resource.TestCheckResourceAttr(paylistResource, "item.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(paylistResource, "item.*", map[string]string{
+						"order": "1",
+						"type":  "dashboard_by_tag",
+						"value": "production",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(paylistResource, "item.*", map[string]string{
+						"order": "2",
+						"type":  "dashboard_by_uid",
+						"value": rName,
+					}),
+				),
+			},
+			{
+				ResourceName:      paylistResource,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func TestAccPlaylist_disappears(t *testing.T) {
 	testutils.CheckOSSTestsEnabled(t)
 
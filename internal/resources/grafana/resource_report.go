@@ -10,6 +10,7 @@ import (
 	"time"
 	_ "time/tzdata"
 
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/strfmt"
 	goapi "github.com/grafana/grafana-openapi-client-go/client"
 	"github.com/grafana/grafana-openapi-client-go/models"
@@ -124,6 +125,12 @@ func resourceReport() *common.Resource {
 				Default:      reportOrientationLandscape,
 				ValidateFunc: validation.StringInSlice(reportOrientations, false),
 			},
+			"pdf_show_template_variables": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to show the values of the dashboard's template variables in the PDF.",
+			},
 			"formats": {
 				Type:        schema.TypeSet,
 				Optional:    true,
@@ -260,7 +267,7 @@ func listReports(ctx context.Context, client *goapi.GrafanaHTTPAPI, data *Lister
 
 	var ids []string
 	for _, orgID := range orgIDs {
-		client = client.Clone().WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 
 		resp, err := client.Reports.GetReports()
 		if err != nil && common.IsNotFoundError(err) {
@@ -286,7 +293,7 @@ func CreateReport(ctx context.Context, d *schema.ResourceData, meta interface{})
 		return diag.FromErr(err)
 	}
 
-	res, err := client.Reports.CreateReport(&report)
+	res, err := client.Reports.CreateReport(&report, withReportPDFOptions(d))
 	if err != nil {
 		data, _ := json.Marshal(report)
 		return diag.Errorf("error creating the following report:\n%s\n%v", string(data), err)
@@ -303,7 +310,8 @@ func ReadReport(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 		return diag.FromErr(err)
 	}
 
-	r, err := client.Reports.GetReport(id)
+	var pdfOptions reportPDFOptions
+	r, err := client.Reports.GetReport(id, withResponseBody(&pdfOptions))
 	if err, shouldReturn := common.CheckReadError("report", d, err); shouldReturn {
 		return err
 	}
@@ -317,6 +325,7 @@ func ReadReport(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 	d.Set("include_table_csv", r.Payload.EnableCSV)
 	d.Set("layout", r.Payload.Options.Layout)
 	d.Set("orientation", r.Payload.Options.Orientation)
+	d.Set("pdf_show_template_variables", pdfOptions.Options.ShowTemplateVariables)
 	d.Set("org_id", strconv.FormatInt(r.Payload.OrgID, 10))
 
 	if _, ok := d.GetOk("formats"); ok {
@@ -381,7 +390,7 @@ func UpdateReport(ctx context.Context, d *schema.ResourceData, meta interface{})
 		return diag.FromErr(err)
 	}
 
-	if _, err := client.Reports.UpdateReport(id, &report); err != nil {
+	if _, err := client.Reports.UpdateReport(id, &report, withReportPDFOptions(d)); err != nil {
 		data, _ := json.Marshal(report)
 		return diag.Errorf("error updating the following report:\n%s\n%v", string(data), err)
 	}
@@ -400,6 +409,25 @@ func DeleteReport(ctx context.Context, d *schema.ResourceData, meta interface{})
 	return diag
 }
 
+// reportPDFOptions are the options of a report that are missing from the OpenAPI client's models.
+type reportPDFOptions struct {
+	Options struct {
+		ShowTemplateVariables bool `json:"pdfShowTemplateVariables"`
+	} `json:"options"`
+}
+
+// withReportPDFOptions sends the options of the resource that are missing from the OpenAPI client's models.
+func withReportPDFOptions(d *schema.ResourceData) func(*runtime.ClientOperation) {
+	return withRequestBodyFields(func(body map[string]interface{}) {
+		options, ok := body["options"].(map[string]interface{})
+		if !ok {
+			options = map[string]interface{}{}
+			body["options"] = options
+		}
+		options["pdfShowTemplateVariables"] = d.Get("pdf_show_template_variables").(bool)
+	})
+}
+
 func schemaToReport(d *schema.ResourceData) (models.CreateOrUpdateReportConfig, error) {
 	frequency := d.Get("schedule.0.frequency").(string)
 	timezone := d.Get("schedule.0.timezone").(string)
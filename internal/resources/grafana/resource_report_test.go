@@ -151,6 +151,56 @@ func TestAccResourceReport_basic(t *testing.T) {
 	})
 }
 
+func TestAccResourceReport_PDFOptions(t *testing.T) {
+	testutils.CheckEnterpriseTestsEnabled(t, ">=10.3.0")
+
+	var report models.Report
+	name := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             reportCheckExists.destroyed(&report, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "grafana_dashboard" "test" {
+	config_json = jsonencode({
+		title = "%[1]s"
+		uid   = "%[1]s"
+	})
+}
+
+resource "grafana_report" "test" {
+	name       = "%[1]s"
+	recipients = ["some@email.com"]
+	schedule {
+		frequency = "hourly"
+	}
+	dashboards {
+		uid = grafana_dashboard.test.uid
+	}
+
+	orientation                 = "landscape"
+	layout                      = "grid"
+	pdf_show_template_variables = true
+}`, name),
+				Check: resource.ComposeTestCheckFunc(
+					reportCheckExists.exists("grafana_report.test", &report),
+					resource.TestCheckResourceAttr("grafana_report.test", "orientation", "landscape"),
+					resource.TestCheckResourceAttr("grafana_report.test", "layout", "grid"),
+					resource.TestCheckResourceAttr("grafana_report.test", "pdf_show_template_variables", "true"),
+					func(s *terraform.State) error {
+						if report.Options == nil || report.Options.Orientation != "landscape" || report.Options.Layout != "grid" {
+							return fmt.Errorf("unexpected report options: %+v", report.Options)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 func TestAccResourceReport_InOrg(t *testing.T) {
 	testutils.CheckEnterpriseTestsEnabled(t, ">=9.0.0")
 
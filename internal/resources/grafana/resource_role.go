@@ -119,7 +119,7 @@ func CreateRole(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 	client, orgID := OAPIClientFromNewOrgResource(meta, d)
 	if d.Get("global").(bool) {
 		orgID = 0
-		client = client.WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 	}
 
 	var version int
@@ -172,7 +172,7 @@ func ReadRole(ctx context.Context, d *schema.ResourceData, meta interface{}) dia
 	client, _, uid := OAPIClientFromExistingOrgResource(meta, d.Id())
 	if d.Get("global").(bool) {
 		var orgID int64 = 0
-		client = client.WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 	}
 	return readRoleFromUID(client, uid, d)
 }
@@ -236,7 +236,7 @@ func UpdateRole(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 	client, _, uid := OAPIClientFromExistingOrgResource(meta, d.Id())
 	if d.Get("global").(bool) {
 		var orgID int64 = 0
-		client = client.WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 	}
 
 	if d.HasChange("version") || d.HasChange("name") || d.HasChange("description") || d.HasChange("permissions") ||
@@ -269,7 +269,7 @@ func DeleteRole(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 	global := d.Get("global").(bool)
 	if global {
 		var orgID int64 = 0
-		client = client.WithOrgID(orgID)
+		client = common.GrafanaAPIWithOrgID(client, orgID)
 	}
 	_, err := client.AccessControl.DeleteRole(access_control.NewDeleteRoleParams().WithRoleUID(uid).WithGlobal(&global), nil)
 	diag, _ := common.CheckReadError("role", d, err)
@@ -2,14 +2,20 @@ package grafana
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/go-openapi/runtime"
+	goapi "github.com/grafana/grafana-openapi-client-go/client"
 	"github.com/grafana/grafana-openapi-client-go/client/service_accounts"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceServiceAccountToken() *common.Resource {
@@ -17,12 +23,18 @@ func resourceServiceAccountToken() *common.Resource {
 		Description: `
 **Note:** This resource is available only with Grafana 9.1+.
 
+If the token is deleted outside of Terraform, it's recreated on the next apply.
+
+Tokens can be rotated without downtime with ` + "`rotate_after_days`" + ` or ` + "`rotation_trigger`" + `: a new token is created, and the previous one is kept until the next apply, so that its users can switch to the new ` + "`key`" + ` in the meantime.
+
 * [Official documentation](https://grafana.com/docs/grafana/latest/administration/service-accounts/)
 * [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/serviceaccount/#service-account-api)`,
 
 		CreateContext: serviceAccountTokenCreate,
 		ReadContext:   serviceAccountTokenRead,
+		UpdateContext: serviceAccountTokenUpdate,
 		DeleteContext: serviceAccountTokenDelete,
+		CustomizeDiff: serviceAccountTokenCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -43,21 +55,42 @@ func resourceServiceAccountToken() *common.Resource {
 				ForceNew:    true,
 				Description: "The key expiration in seconds. It is optional. If it is a positive number an expiration date for the key is set. If it is null, zero or is omitted completely (unless `api_key_max_seconds_to_live` configuration option is set) the key will never expire.",
 			},
+			"rotate_after_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Rotate the token when it's older than this number of days, on the next apply.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"rotation_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Any value, e.g. a date. Changing it rotates the token.",
+			},
 			"key": {
 				Type:        schema.TypeString,
 				Computed:    true,
 				Sensitive:   true,
-				Description: "The key of the service account token.",
+				Description: "The key of the service account token. It changes when the token is rotated.",
+			},
+			"created": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The creation date of the service account token, in RFC3339 format.",
+			},
+			"previous_token_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the token replaced by the last rotation. It's deleted on the next apply.",
 			},
 			"expiration": {
 				Type:        schema.TypeString,
 				Computed:    true,
-				Description: "The expiration date of the service account token.",
+				Description: "The expiration date of the service account token, in RFC3339 format. Empty if the token never expires.",
 			},
 			"has_expired": {
 				Type:        schema.TypeBool,
 				Computed:    true,
-				Description: "The status of the service account token.",
+				Description: "Whether the service account token has expired.",
 			},
 		},
 	}
@@ -72,13 +105,22 @@ func resourceServiceAccountToken() *common.Resource {
 
 func serviceAccountTokenCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	orgID, serviceAccountIDStr := SplitOrgResourceID(d.Get("service_account_id").(string))
-	c := m.(*common.Client).GrafanaAPI.Clone().WithOrgID(orgID)
+	c := common.GrafanaAPIWithOrgID(m.(*common.Client).GrafanaAPI, orgID)
 	serviceAccountID, err := strconv.ParseInt(serviceAccountIDStr, 10, 64)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	name := d.Get("name").(string)
+	if err := createServiceAccountToken(c, serviceAccountID, d.Get("name").(string), d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Fill the true resource's state by performing a read
+	return serviceAccountTokenRead(ctx, d, m)
+}
+
+// createServiceAccountToken creates a token with the given name, and sets it as the token of the resource.
+func createServiceAccountToken(c *goapi.GrafanaHTTPAPI, serviceAccountID int64, name string, d *schema.ResourceData) error {
 	ttl := d.Get("seconds_to_live").(int)
 
 	request := models.AddServiceAccountTokenCommand{
@@ -88,23 +130,77 @@ func serviceAccountTokenCreate(ctx context.Context, d *schema.ResourceData, m in
 	params := service_accounts.NewCreateTokenParams().WithServiceAccountID(serviceAccountID).WithBody(&request)
 	response, err := c.ServiceAccounts.CreateToken(params)
 	if err != nil {
-		return diag.FromErr(err)
+		return err
 	}
 	token := response.Payload
 
 	d.SetId(strconv.FormatInt(token.ID, 10))
-	err = d.Set("key", token.Key)
+	if err := d.Set("key", token.Key); err != nil {
+		return err
+	}
+	// Older Grafana versions don't return the expiration and creation date when listing tokens
+	now := time.Now()
+	if err := d.Set("created", now.UTC().Format(time.RFC3339)); err != nil {
+		return err
+	}
+	if expiration := ServiceAccountTokenExpiration(now, int64(ttl)); expiration != "" {
+		return d.Set("expiration", expiration)
+	}
+	return nil
+}
+
+func serviceAccountTokenCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	rotate := d.HasChange("rotation_trigger") || ServiceAccountTokenNeedsRotation(d.Get("created").(string), d.Get("rotate_after_days").(int), time.Now())
+	if rotate {
+		for _, key := range []string{"key", "created", "expiration", "has_expired", "previous_token_id"} {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	// The token replaced by the last rotation is deleted
+	if d.Get("previous_token_id").(string) != "" {
+		return d.SetNew("previous_token_id", "")
+	}
+	return nil
+}
+
+func serviceAccountTokenUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	orgID, serviceAccountIDStr := SplitOrgResourceID(d.Get("service_account_id").(string))
+	c := common.GrafanaAPIWithOrgID(m.(*common.Client).GrafanaAPI, orgID)
+	serviceAccountID, err := strconv.ParseInt(serviceAccountIDStr, 10, 64)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	// Fill the true resource's state by performing a read
+	previousTokenID, _ := d.GetChange("previous_token_id")
+	if err := deleteServiceAccountToken(c, serviceAccountID, previousTokenID.(string)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("previous_token_id", "")
+
+	// The planned creation date is unknown when the token is rotated, the current one is in the state
+	created, _ := d.GetChange("created")
+	if d.HasChange("rotation_trigger") || ServiceAccountTokenNeedsRotation(created.(string), d.Get("rotate_after_days").(int), time.Now()) {
+		// Token names are unique, the new token is named after the time it's created at
+		oldTokenID := d.Id()
+		name := fmt.Sprintf("%s-%d", d.Get("name").(string), time.Now().Unix())
+		if err := createServiceAccountToken(c, serviceAccountID, name, d); err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("previous_token_id", oldTokenID)
+	}
+
 	return serviceAccountTokenRead(ctx, d, m)
 }
 
 func serviceAccountTokenRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	orgID, serviceAccountIDStr := SplitOrgResourceID(d.Get("service_account_id").(string))
-	c := m.(*common.Client).GrafanaAPI.Clone().WithOrgID(orgID)
+	c := common.GrafanaAPIWithOrgID(m.(*common.Client).GrafanaAPI, orgID)
 	serviceAccountID, err := strconv.ParseInt(serviceAccountIDStr, 10, 64)
 	if err != nil {
 		return diag.FromErr(err)
@@ -122,23 +218,35 @@ func serviceAccountTokenRead(ctx context.Context, d *schema.ResourceData, m inte
 	for _, key := range response.Payload {
 		if id == key.ID {
 			d.SetId(strconv.FormatInt(key.ID, 10))
-			err = d.Set("name", key.Name)
-			if err != nil {
-				return diag.FromErr(err)
+			// Rotated tokens are named after the configured name, with a suffix
+			if name := d.Get("name").(string); !strings.HasPrefix(key.Name, name+"-") {
+				err = d.Set("name", key.Name)
+				if err != nil {
+					return diag.FromErr(err)
+				}
 			}
+			if !key.Created.IsZero() {
+				err = d.Set("created", time.Time(key.Created).UTC().Format(time.RFC3339))
+				if err != nil {
+					return diag.FromErr(err)
+				}
+			}
+			expiration := d.Get("expiration").(string)
 			if !key.Expiration.IsZero() {
-				err = d.Set("expiration", key.Expiration.String())
+				expiration = time.Time(key.Expiration).UTC().Format(time.RFC3339)
+				err = d.Set("expiration", expiration)
 				if err != nil {
 					return diag.FromErr(err)
 				}
 			}
-			err = d.Set("has_expired", key.HasExpired)
+			err = d.Set("has_expired", key.HasExpired || ServiceAccountTokenHasExpired(expiration, time.Now()))
 
 			return diag.FromErr(err)
 		}
 	}
 
-	log.Printf("[WARN] removing service account token%d from state because it no longer exists in grafana", id)
+	// The token was deleted (revoked) outside of Terraform. Removing it from the state makes the next plan recreate it.
+	log.Printf("[WARN] removing service account token %d from state because it no longer exists in grafana", id)
 	d.SetId("")
 
 	return nil
@@ -146,12 +254,16 @@ func serviceAccountTokenRead(ctx context.Context, d *schema.ResourceData, m inte
 
 func serviceAccountTokenDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	orgID, serviceAccountIDStr := SplitOrgResourceID(d.Get("service_account_id").(string))
-	c := m.(*common.Client).GrafanaAPI.Clone().WithOrgID(orgID)
+	c := common.GrafanaAPIWithOrgID(m.(*common.Client).GrafanaAPI, orgID)
 	serviceAccountID, err := strconv.ParseInt(serviceAccountIDStr, 10, 64)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	if err := deleteServiceAccountToken(c, serviceAccountID, d.Get("previous_token_id").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	id, err := strconv.ParseInt(d.Id(), 10, 32)
 	if err != nil {
 		return diag.FromErr(err)
@@ -161,3 +273,54 @@ func serviceAccountTokenDelete(ctx context.Context, d *schema.ResourceData, m in
 
 	return diag.FromErr(err)
 }
+
+// deleteServiceAccountToken deletes the token replaced by a rotation, if there's one and it still exists.
+func deleteServiceAccountToken(c *goapi.GrafanaHTTPAPI, serviceAccountID int64, tokenID string) error {
+	if tokenID == "" {
+		return nil
+	}
+	id, err := strconv.ParseInt(tokenID, 10, 64)
+	if err != nil {
+		return err
+	}
+	_, err = c.ServiceAccounts.DeleteToken(id, serviceAccountID)
+	if err, ok := err.(runtime.ClientResponseStatus); ok && err.IsCode(404) {
+		return nil
+	}
+	return err
+}
+
+// ServiceAccountTokenNeedsRotation returns whether a token created at the given RFC3339 date is older than the given number of days.
+// Tokens are never rotated by age if rotateAfterDays isn't positive, or if their creation date is unknown.
+func ServiceAccountTokenNeedsRotation(created string, rotateAfterDays int, now time.Time) bool {
+	if rotateAfterDays <= 0 || created == "" {
+		return false
+	}
+	createdAt, err := time.Parse(time.RFC3339, created)
+	if err != nil {
+		return false
+	}
+	return !now.Before(createdAt.Add(time.Duration(rotateAfterDays) * 24 * time.Hour))
+}
+
+// ServiceAccountTokenExpiration returns the expiration date, in RFC3339 format, of a token created at the given time.
+// Tokens without a positive seconds_to_live never expire, an empty string is returned for those.
+func ServiceAccountTokenExpiration(created time.Time, secondsToLive int64) string {
+	if secondsToLive <= 0 {
+		return ""
+	}
+	return created.Add(time.Duration(secondsToLive) * time.Second).UTC().Format(time.RFC3339)
+}
+
+// ServiceAccountTokenHasExpired returns whether the given RFC3339 expiration date is past.
+// An empty or invalid expiration means that the token never expires.
+func ServiceAccountTokenHasExpired(expiration string, now time.Time) bool {
+	if expiration == "" {
+		return false
+	}
+	expiresAt, err := time.Parse(time.RFC3339, expiration)
+	if err != nil {
+		return false
+	}
+	return !now.Before(expiresAt)
+}
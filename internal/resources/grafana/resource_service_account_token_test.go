@@ -2,9 +2,12 @@ package grafana_test
 
 import (
 	"fmt"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/resources/grafana"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -41,6 +44,7 @@ func TestAccServiceAccountToken_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("grafana_service_account.test", "role", "Viewer"),
 					resource.TestCheckResourceAttr("grafana_service_account_token.test", "name", name+"-updated"),
 					resource.TestCheckResourceAttrSet("grafana_service_account_token.test", "expiration"),
+					resource.TestCheckResourceAttr("grafana_service_account_token.test", "has_expired", "false"),
 				),
 			},
 			// Check that the token is deleted when the resource is destroyed
@@ -110,6 +114,191 @@ func TestAccServiceAccountToken_inOrg(t *testing.T) {
 	})
 }
 
+func TestAccServiceAccountToken_revokedOutsideTerraform(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=9.1.0")
+
+	name := acctest.RandString(10)
+	var sa models.ServiceAccountDTO
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             serviceAccountCheckExists.destroyed(&sa, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceAccountTokenConfig(name, "Editor", 300, false),
+				Check: resource.ComposeTestCheckFunc(
+					serviceAccountCheckExists.exists("grafana_service_account.test", &sa),
+					checkServiceAccountTokens(&sa, []string{name}),
+				),
+			},
+			// Revoke the token, the next plan should recreate it
+			{
+				Config: testAccServiceAccountTokenConfig(name, "Editor", 300, false),
+				Check: func(s *terraform.State) error {
+					rs := s.RootModule().Resources["grafana_service_account_token.test"]
+					tokenID, err := strconv.ParseInt(rs.Primary.ID, 10, 64)
+					if err != nil {
+						return err
+					}
+					_, err = grafanaTestClient().WithOrgID(sa.OrgID).ServiceAccounts.DeleteToken(tokenID, sa.ID)
+					return err
+				},
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccServiceAccountTokenConfig(name, "Editor", 300, false),
+				Check: resource.ComposeTestCheckFunc(
+					checkServiceAccountTokens(&sa, []string{name}),
+					resource.TestCheckResourceAttrSet("grafana_service_account_token.test", "key"),
+				),
+			},
+		},
+	})
+}
+
+func TestServiceAccountTokenExpiration(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	created := time.Date(2024, 2, 28, 23, 30, 0, 0, time.FixedZone("UTC+2", 2*60*60))
+	for _, tc := range []struct {
+		secondsToLive int64
+		expected      string
+	}{
+		{0, ""},
+		{-1, ""},
+		{60, "2024-02-28T21:31:00Z"},
+		{86400, "2024-02-29T21:30:00Z"},
+	} {
+		if got := grafana.ServiceAccountTokenExpiration(created, tc.secondsToLive); got != tc.expected {
+			t.Errorf("expected expiration %q for %d seconds, got %q", tc.expected, tc.secondsToLive, got)
+		}
+	}
+
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	for expiration, expected := range map[string]bool{
+		"":                     false,
+		"invalid":              false,
+		"2024-02-29T21:30:00Z": true,
+		"2024-03-01T00:00:00Z": true,
+		"2024-03-01T00:00:01Z": false,
+	} {
+		if got := grafana.ServiceAccountTokenHasExpired(expiration, now); got != expected {
+			t.Errorf("expected has_expired=%t for %q, got %t", expected, expiration, got)
+		}
+	}
+}
+
+func TestAccServiceAccountToken_rotation(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">=9.1.0")
+
+	name := acctest.RandString(10)
+	var sa models.ServiceAccountDTO
+	var firstKey, secondKey string
+
+	config := func(trigger string) string {
+		return fmt.Sprintf(`
+resource "grafana_service_account" "test" {
+	name = "%[1]s"
+	role = "Viewer"
+}
+
+resource "grafana_service_account_token" "test" {
+	name               = "%[1]s"
+	service_account_id = grafana_service_account.test.id
+	rotation_trigger   = "%[2]s"
+}
+`, name, trigger)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             serviceAccountCheckExists.destroyed(&sa, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: config("2024-01-01"),
+				Check: resource.ComposeTestCheckFunc(
+					serviceAccountCheckExists.exists("grafana_service_account.test", &sa),
+					checkServiceAccountTokens(&sa, []string{name}),
+					resource.TestCheckResourceAttrSet("grafana_service_account_token.test", "created"),
+					resource.TestCheckResourceAttr("grafana_service_account_token.test", "previous_token_id", ""),
+					resource.TestCheckResourceAttrWith("grafana_service_account_token.test", "key", func(value string) error {
+						firstKey = value
+						return nil
+					}),
+				),
+			},
+			// Changing the trigger creates a new token, the previous one is kept until the next apply
+			{
+				Config: config("2024-02-01"),
+				Check: resource.ComposeTestCheckFunc(
+					checkServiceAccountTokenCount(&sa, 2),
+					resource.TestCheckResourceAttrSet("grafana_service_account_token.test", "previous_token_id"),
+					resource.TestCheckResourceAttr("grafana_service_account_token.test", "name", name),
+					resource.TestCheckResourceAttrWith("grafana_service_account_token.test", "key", func(value string) error {
+						if value == firstKey {
+							return fmt.Errorf("expected the key to change when rotating the token")
+						}
+						secondKey = value
+						return nil
+					}),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("2024-02-01"),
+				Check: resource.ComposeTestCheckFunc(
+					checkServiceAccountTokenCount(&sa, 1),
+					resource.TestCheckResourceAttr("grafana_service_account_token.test", "previous_token_id", ""),
+					resource.TestCheckResourceAttrWith("grafana_service_account_token.test", "key", func(value string) error {
+						if value != secondKey {
+							return fmt.Errorf("expected the key to stay the same when deleting the previous token")
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestServiceAccountTokenNeedsRotation(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		created         string
+		rotateAfterDays int
+		expected        bool
+	}{
+		{"2024-01-01T00:00:00Z", 0, false},
+		{"", 30, false},
+		{"invalid", 30, false},
+		{"2024-02-20T12:00:00Z", 30, false},
+		{"2024-01-31T12:00:01Z", 30, false},
+		{"2024-01-31T12:00:00Z", 30, true},
+		{"2024-01-01T00:00:00Z", 30, true},
+		{"2024-02-29T14:00:00+02:00", 1, true},
+	} {
+		if got := grafana.ServiceAccountTokenNeedsRotation(tc.created, tc.rotateAfterDays, now); got != tc.expected {
+			t.Errorf("expected rotation=%t for a token created at %q with rotate_after_days=%d, got %t", tc.expected, tc.created, tc.rotateAfterDays, got)
+		}
+	}
+}
+
+func checkServiceAccountTokenCount(sa *models.ServiceAccountDTO, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := grafanaTestClient().WithOrgID(sa.OrgID)
+		resp, err := client.ServiceAccounts.ListTokens(sa.ID)
+		if err != nil {
+			return err
+		}
+		if len(resp.Payload) != expected {
+			return fmt.Errorf("expected %d tokens, got %d", expected, len(resp.Payload))
+		}
+		return nil
+	}
+}
+
 func checkServiceAccountTokens(sa *models.ServiceAccountDTO, expectNames []string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		client := grafanaTestClient().WithOrgID(sa.OrgID)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"strconv"
 
 	goapi "github.com/grafana/grafana-openapi-client-go/client"
@@ -64,6 +65,11 @@ func resourceTeam() *common.Resource {
 				Optional:    true,
 				Description: "An email address for the team.",
 			},
+			"avatar_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the team's avatar. Grafana generates it from the team's `email`, through Gravatar.",
+			},
 			"members": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -81,6 +87,16 @@ to the team. Note: users specified here must already exist in Grafana.
 					return false
 				},
 			},
+			"ignore_members": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"members"},
+				Description: `
+Set to true to leave the members of the team alone, e.g. when they're managed with ` + "`grafana_team_member`" + `.
+Otherwise, the team's members are the ones set in ` + "`members`" + `, and other members are removed.
+`,
+			},
 			"ignore_externally_synced_members": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -109,6 +125,11 @@ Team Sync can be provisioned using [grafana_team_external_group resource](https:
 							Description: "The UID of the dashboard to display when a team member logs in.",
 							Default:     "",
 						},
+						"home_dashboard_title": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The title of the dashboard to display when a team member logs in. It's resolved to a dashboard UID when applying, and must match exactly one dashboard. Can't be used with `home_dashboard_uid`.",
+						},
 						"timezone": {
 							Type:         schema.TypeString,
 							Optional:     true,
@@ -171,23 +192,41 @@ func CreateTeam(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 
 	d.SetId(MakeOrgResourceID(orgID, teamID))
 	d.Set("team_id", teamID)
-	if err = UpdateMembers(client, d); err != nil {
-		return diag.FromErr(err)
-	}
 
+	// The team is deleted if it can't be fully configured, so that it isn't left half-configured outside of Terraform's state
 	if err := updateTeamPreferences(client, teamID, d); err != nil {
-		return err
+		return rollbackTeamCreation(client, teamID, d, err)
+	}
+
+	if !d.Get("ignore_members").(bool) {
+		if err = UpdateMembers(client, d); err != nil {
+			return rollbackTeamCreation(client, teamID, d, diag.FromErr(err))
+		}
 	}
 
 	if _, ok := d.GetOk("team_sync"); ok {
 		if err := manageTeamExternalGroup(client, teamID, d, "team_sync.0.groups"); err != nil {
-			return diag.FromErr(err)
+			return rollbackTeamCreation(client, teamID, d, diag.FromErr(err))
 		}
 	}
 
 	return ReadTeam(ctx, d, meta)
 }
 
+// rollbackTeamCreation deletes a team that failed to be configured after its creation.
+// If the team can't be deleted, its ID is kept: Terraform then marks it as tainted and replaces it on the next apply.
+func rollbackTeamCreation(client *goapi.GrafanaHTTPAPI, teamID int64, d *schema.ResourceData, diags diag.Diagnostics) diag.Diagnostics {
+	if _, err := client.Teams.DeleteTeamByID(strconv.FormatInt(teamID, 10)); err != nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("failed to delete team %d after its configuration failed", teamID),
+			Detail:   err.Error(),
+		})
+	}
+	d.SetId("")
+	return diags
+}
+
 func ReadTeam(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, _, idStr := OAPIClientFromExistingOrgResource(meta, d.Id())
 	teamID, _ := strconv.ParseInt(idStr, 10, 64)
@@ -206,9 +245,8 @@ func readTeamFromID(client *goapi.GrafanaHTTPAPI, teamID int64, d *schema.Resour
 	d.Set("team_id", teamID)
 	d.Set("name", team.Name)
 	d.Set("org_id", strconv.FormatInt(team.OrgID, 10))
-	if team.Email != "" {
-		d.Set("email", team.Email)
-	}
+	d.Set("email", team.Email)
+	d.Set("avatar_url", team.AvatarURL)
 
 	resp, err := client.Teams.GetTeamPreferences(teamIDStr)
 	if err != nil {
@@ -235,16 +273,27 @@ func readTeamFromID(client *goapi.GrafanaHTTPAPI, teamID int64, d *schema.Resour
 	}
 
 	if preferences.Theme+preferences.Timezone+preferences.HomeDashboardUID+preferences.WeekStart != "" {
-		d.Set("preferences", []map[string]interface{}{
-			{
-				"theme":              preferences.Theme,
-				"home_dashboard_uid": preferences.HomeDashboardUID,
-				"timezone":           preferences.Timezone,
-				"week_start":         preferences.WeekStart,
-			},
-		})
+		tfPreferences := map[string]interface{}{
+			"theme":              preferences.Theme,
+			"home_dashboard_uid": preferences.HomeDashboardUID,
+			"timezone":           preferences.Timezone,
+			"week_start":         preferences.WeekStart,
+		}
+		if d.Get("preferences.0.home_dashboard_title").(string) != "" {
+			title, err := homeDashboardTitle(client, preferences.HomeDashboardUID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			tfPreferences["home_dashboard_uid"] = ""
+			tfPreferences["home_dashboard_title"] = title
+		}
+		d.Set("preferences", []map[string]interface{}{tfPreferences})
 	}
 
+	if d.Get("ignore_members").(bool) {
+		d.Set("members", nil)
+		return nil
+	}
 	return readTeamMembers(client, d)
 }
 
@@ -262,8 +311,10 @@ func UpdateTeam(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 			return diag.FromErr(err)
 		}
 	}
-	if err := UpdateMembers(client, d); err != nil {
-		return diag.FromErr(err)
+	if !d.Get("ignore_members").(bool) {
+		if err := UpdateMembers(client, d); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	if err := updateTeamPreferences(client, teamID, d); err != nil {
@@ -287,14 +338,23 @@ func DeleteTeam(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 }
 
 func updateTeamPreferences(client *goapi.GrafanaHTTPAPI, teamID int64, d *schema.ResourceData) diag.Diagnostics {
-	if d.IsNewResource() || d.HasChanges("preferences.0.theme", "preferences.0.home_dashboard_uid", "preferences.0.timezone", "preferences.0.week_start") {
+	if d.IsNewResource() || d.HasChanges("preferences.0.theme", "preferences.0.home_dashboard_uid", "preferences.0.home_dashboard_title", "preferences.0.timezone", "preferences.0.week_start") {
+		title := d.Get("preferences.0.home_dashboard_title").(string)
+		uid := d.Get("preferences.0.home_dashboard_uid").(string)
+		if title != "" && uid != "" {
+			return diag.Errorf("only one of preferences.0.home_dashboard_uid and preferences.0.home_dashboard_title can be set")
+		}
+		homeDashboardUID, err := homeDashboardUIDFromTitle(client, uid, title)
+		if err != nil {
+			return diag.FromErr(err)
+		}
 		body := models.UpdatePrefsCmd{
 			Theme:            d.Get("preferences.0.theme").(string),
-			HomeDashboardUID: d.Get("preferences.0.home_dashboard_uid").(string),
+			HomeDashboardUID: homeDashboardUID,
 			Timezone:         d.Get("preferences.0.timezone").(string),
 			WeekStart:        d.Get("preferences.0.week_start").(string),
 		}
-		_, err := client.Teams.UpdateTeamPreferences(strconv.FormatInt(teamID, 10), &body)
+		_, err = client.Teams.UpdateTeamPreferences(strconv.FormatInt(teamID, 10), &body)
 		return diag.FromErr(err)
 	}
 
@@ -302,13 +362,27 @@ func updateTeamPreferences(client *goapi.GrafanaHTTPAPI, teamID int64, d *schema
 }
 
 func readTeamMembers(client *goapi.GrafanaHTTPAPI, d *schema.ResourceData) diag.Diagnostics {
-	resp, err := client.Teams.GetTeamMembers(strconv.Itoa(d.Get("team_id").(int)))
+	members, err := currentTeamMembers(client, d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	teamMembers := resp.GetPayload()
 	memberSlice := []string{}
-	for _, teamMember := range teamMembers {
+	for email := range members {
+		memberSlice = append(memberSlice, email)
+	}
+	d.Set("members", memberSlice)
+
+	return nil
+}
+
+// currentTeamMembers returns the members of the team that are managed by the `members` attribute, by email.
+func currentTeamMembers(client *goapi.GrafanaHTTPAPI, d *schema.ResourceData) (map[string]TeamMember, error) {
+	resp, err := client.Teams.GetTeamMembers(strconv.Itoa(d.Get("team_id").(int)))
+	if err != nil {
+		return nil, err
+	}
+	members := make(map[string]TeamMember)
+	for _, teamMember := range resp.GetPayload() {
 		// Admin is added automatically to the team when the team is created.
 		// We can't interact with it, so we skip it from Terraform management.
 		if teamMember.Email == "admin@localhost" {
@@ -320,75 +394,72 @@ func readTeamMembers(client *goapi.GrafanaHTTPAPI, d *schema.ResourceData) diag.
 		if (!hasKey || ignoreExternallySynced.(bool)) && len(teamMember.Labels) > 0 {
 			continue
 		}
-		memberSlice = append(memberSlice, teamMember.Email)
+		members[teamMember.Email] = TeamMember{teamMember.UserID, teamMember.Email}
 	}
-	d.Set("members", memberSlice)
-
-	return nil
+	return members, nil
 }
 
+// UpdateMembers only adds and removes the members that differ between the team in Grafana and the configuration,
+// so that large teams aren't updated member by member on every change.
 func UpdateMembers(client *goapi.GrafanaHTTPAPI, d *schema.ResourceData) error {
-	stateMembers, configMembers, err := collectMembers(d)
+	currentMembers, err := currentTeamMembers(client, d)
 	if err != nil {
 		return err
 	}
-	// compile the list of differences between current state and config
-	changes := memberChanges(stateMembers, configMembers)
-	// retrieves the corresponding user IDs based on the email provided
+	configMembers := make(map[string]TeamMember)
+	for _, u := range d.Get("members").(*schema.Set).List() {
+		configMembers[u.(string)] = TeamMember{0, u.(string)}
+	}
+	// compile the list of differences between the team in Grafana and config
+	changes := MemberChanges(currentMembers, configMembers)
+	// retrieves the user IDs of the members to add, based on the email provided
 	changes, err = addMemberIdsToChanges(client, changes)
 	if err != nil {
 		return err
 	}
-	// now we can make the corresponding updates so current state matches config
+	// now we can make the corresponding updates so the team matches config
 	return applyMemberChanges(client, int64(d.Get("team_id").(int)), changes)
 }
 
-func collectMembers(d *schema.ResourceData) (map[string]TeamMember, map[string]TeamMember, error) {
-	stateMembers, configMembers := make(map[string]TeamMember), make(map[string]TeamMember)
-
-	// Get the lists of team members read in from Grafana state (old) and configured (new)
-	state, config := d.GetChange("members")
-	for _, u := range state.(*schema.Set).List() {
-		login := u.(string)
-		// Sanity check that a member isn't specified twice within a team
-		if _, ok := stateMembers[login]; ok {
-			return nil, nil, fmt.Errorf("error: Team Member '%s' cannot be specified multiple times", login)
-		}
-		stateMembers[login] = TeamMember{0, login}
-	}
-	for _, u := range config.(*schema.Set).List() {
-		login := u.(string)
-		// Sanity check that a member isn't specified twice within a team
-		if _, ok := configMembers[login]; ok {
-			return nil, nil, fmt.Errorf("error: Team Member '%s' cannot be specified multiple times", login)
-		}
-		configMembers[login] = TeamMember{0, login}
-	}
-
-	return stateMembers, configMembers, nil
-}
-
-func memberChanges(stateMembers, configMembers map[string]TeamMember) []MemberChange {
+// MemberChanges returns the members to add and to remove for a team to go from its current members to the configured ones.
+// Members are added first, then removed, in the order of their emails.
+func MemberChanges(currentMembers, configMembers map[string]TeamMember) []MemberChange {
 	var changes []MemberChange
-	for _, user := range configMembers {
-		_, ok := stateMembers[user.Email]
-		if !ok {
-			// Member doesn't exist in Grafana's state for the team, should be added.
-			changes = append(changes, MemberChange{AddMember, user})
-			continue
+	for _, email := range sortedMemberEmails(configMembers) {
+		if _, ok := currentMembers[email]; !ok {
+			// Member isn't in the team in Grafana, should be added.
+			changes = append(changes, MemberChange{AddMember, configMembers[email]})
 		}
 	}
-	for _, user := range stateMembers {
-		if _, ok := configMembers[user.Email]; !ok {
-			// Member exists in Grafana's state for the team, but isn't
+	for _, email := range sortedMemberEmails(currentMembers) {
+		if _, ok := configMembers[email]; !ok {
+			// Member is in the team in Grafana, but isn't
 			// present in the team configuration, should be removed.
-			changes = append(changes, MemberChange{RemoveMember, user})
+			changes = append(changes, MemberChange{RemoveMember, currentMembers[email]})
 		}
 	}
 	return changes
 }
 
+func sortedMemberEmails(members map[string]TeamMember) []string {
+	emails := make([]string, 0, len(members))
+	for email := range members {
+		emails = append(emails, email)
+	}
+	sort.Strings(emails)
+	return emails
+}
+
+// addMemberIdsToChanges sets the user IDs of the members that don't have one yet, i.e. the ones to add.
 func addMemberIdsToChanges(client *goapi.GrafanaHTTPAPI, changes []MemberChange) ([]MemberChange, error) {
+	missingIDs := false
+	for _, change := range changes {
+		missingIDs = missingIDs || change.Member.ID == 0
+	}
+	if !missingIDs {
+		return changes, nil
+	}
+
 	gUserMap := make(map[string]int64)
 
 	resp, err := client.Org.GetOrgUsersForCurrentOrg()
@@ -402,6 +473,10 @@ func addMemberIdsToChanges(client *goapi.GrafanaHTTPAPI, changes []MemberChange)
 	var output []MemberChange
 
 	for _, change := range changes {
+		if change.Member.ID != 0 {
+			output = append(output, change)
+			continue
+		}
 		id, ok := gUserMap[change.Member.Email]
 		if !ok {
 			if change.Type == AddMember {
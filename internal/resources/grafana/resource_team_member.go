@@ -0,0 +1,150 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// teamAdminPermission is the permission of the team members that can administer the team.
+const teamAdminPermission = 4
+
+var resourceTeamMemberID = common.NewResourceID(
+	common.OptionalIntIDField("orgID"),
+	common.IntIDField("teamID"),
+	common.IntIDField("userID"),
+)
+
+func resourceTeamMember() *common.Resource {
+	schema := &schema.Resource{
+		Description: `
+Manages a single member of a team. Equivalent to an item of the ` + "`members`" + ` attribute of the ` + "`grafana_team`" + ` resource, use one or the other to manage a team's members.
+The team must set ` + "`ignore_members`" + `, otherwise updating it removes the members added with this resource.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/administration/team-management/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/team/#add-team-member)
+`,
+
+		CreateContext: CreateTeamMember,
+		ReadContext:   ReadTeamMember,
+		DeleteContext: DeleteTeamMember,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"team_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the team.",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					_, old = SplitOrgResourceID(old)
+					_, new = SplitOrgResourceID(new)
+					return old == new
+				},
+			},
+			"user_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the user to add to the team.",
+			},
+			"permission": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The role of the user in the team, `Member` or `Admin`. Team admins are managed with `grafana_team_permission`.",
+			},
+		},
+	}
+
+	return common.NewLegacySDKResource(
+		common.CategoryGrafanaOSS,
+		"grafana_team_member",
+		resourceTeamMemberID,
+		schema,
+	)
+}
+
+// SplitTeamMemberID parses the ID of a `grafana_team_member` resource, `teamID:userID` or `orgID:teamID:userID`.
+// The org ID is 0 if it isn't part of the ID.
+func SplitTeamMemberID(id string) (orgID, teamID, userID int64, err error) {
+	parts, err := resourceTeamMemberID.Split(id)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	if len(parts) == 3 {
+		orgID = parts[0].(int64)
+		parts = parts[1:]
+	}
+	return orgID, parts[0].(int64), parts[1].(int64), nil
+}
+
+func CreateTeamMember(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	orgID, teamIDStr := SplitOrgResourceID(d.Get("team_id").(string))
+	teamID, err := strconv.ParseInt(teamIDStr, 10, 64)
+	if err != nil {
+		return diag.Errorf("invalid team ID %q: %s", teamIDStr, err)
+	}
+	userID := int64(d.Get("user_id").(int))
+	client, orgID, _ := OAPIClientFromExistingOrgResource(meta, MakeOrgResourceID(orgID, teamID))
+
+	if _, err := client.Teams.AddTeamMember(teamIDStr, &models.AddTeamMemberCommand{UserID: userID}); err != nil {
+		return diag.Errorf("error adding user %d to team %d: %s", userID, teamID, err)
+	}
+
+	d.SetId(resourceTeamMemberID.Make(orgID, teamID, userID))
+	return ReadTeamMember(ctx, d, meta)
+}
+
+func ReadTeamMember(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	orgID, teamID, userID, err := SplitTeamMemberID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	client, orgID, _ := OAPIClientFromExistingOrgResource(meta, MakeOrgResourceID(orgID, teamID))
+
+	resp, err := client.Teams.GetTeamMembers(strconv.FormatInt(teamID, 10))
+	if err, shouldReturn := common.CheckReadError("team", d, err); shouldReturn {
+		return err
+	}
+
+	for _, member := range resp.GetPayload() {
+		if member.UserID != userID {
+			continue
+		}
+		permission := "Member"
+		if member.Permission == teamAdminPermission {
+			permission = "Admin"
+		}
+		d.SetId(resourceTeamMemberID.Make(orgID, teamID, userID))
+		d.Set("team_id", MakeOrgResourceID(orgID, teamID))
+		d.Set("user_id", userID)
+		d.Set("permission", permission)
+		return nil
+	}
+
+	log.Printf("[WARN] removing team member %s from state because the user is no longer a member of the team", d.Id())
+	d.SetId("")
+	return nil
+}
+
+func DeleteTeamMember(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	orgID, teamID, userID, err := SplitTeamMemberID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	client, _, _ := OAPIClientFromExistingOrgResource(meta, MakeOrgResourceID(orgID, teamID))
+
+	_, err = client.Teams.RemoveTeamMember(userID, strconv.FormatInt(teamID, 10))
+	if err != nil && !common.IsNotFoundError(err) {
+		return diag.FromErr(fmt.Errorf("error removing user %d from team %d: %w", userID, teamID, err))
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package grafana_test
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/resources/grafana"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccTeamMember_basic(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var team models.TeamDTO
+	name := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             teamCheckExists.destroyed(&team, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTeamMemberConfig(name, name),
+				Check: resource.ComposeTestCheckFunc(
+					teamCheckExists.exists("grafana_team.test", &team),
+					resource.TestMatchResourceAttr("grafana_team_member.test", "id", regexp.MustCompile(`^1:\d+:\d+$`)),
+					resource.TestCheckResourceAttrPair("grafana_team_member.test", "team_id", "grafana_team.test", "id"),
+					resource.TestCheckResourceAttrPair("grafana_team_member.test", "user_id", "grafana_user.test", "user_id"),
+					resource.TestCheckResourceAttr("grafana_team_member.test", "permission", "Member"),
+				),
+			},
+			// The team ignores its members, updating it must keep the member
+			{
+				Config: testAccTeamMemberConfig(name, name+"-renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					teamCheckExists.exists("grafana_team.test", &team),
+					resource.TestCheckResourceAttr("grafana_team.test", "name", name+"-renamed"),
+					func(s *terraform.State) error {
+						resp, err := grafanaTestClient().Teams.GetTeamMembers(strconv.FormatInt(team.ID, 10))
+						if err != nil {
+							return err
+						}
+						for _, member := range resp.Payload {
+							if member.Login == name {
+								return nil
+							}
+						}
+						return fmt.Errorf("expected %s to still be a member of the team", name)
+					},
+				),
+			},
+			{
+				ResourceName:      "grafana_team_member.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// The org ID is optional in the imported ID
+			{
+				ResourceName:      "grafana_team_member.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["grafana_team_member.test"]
+					return fmt.Sprintf("%d:%s", team.ID, rs.Primary.Attributes["user_id"]), nil
+				},
+			},
+		},
+	})
+}
+
+func TestSplitTeamMemberID(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	for _, tc := range []struct {
+		id          string
+		orgID       int64
+		teamID      int64
+		userID      int64
+		expectedErr bool
+	}{
+		{id: "2:3", teamID: 2, userID: 3},
+		{id: "1:2:3", orgID: 1, teamID: 2, userID: 3},
+		{id: "2", expectedErr: true},
+		{id: "1:2:3:4", expectedErr: true},
+		{id: "team:3", expectedErr: true},
+	} {
+		t.Run(tc.id, func(t *testing.T) {
+			orgID, teamID, userID, err := grafana.SplitTeamMemberID(tc.id)
+			if tc.expectedErr != (err != nil) {
+				t.Fatalf("expected error: %t, got %v", tc.expectedErr, err)
+			}
+			if orgID != tc.orgID || teamID != tc.teamID || userID != tc.userID {
+				t.Errorf("expected %d:%d:%d, got %d:%d:%d", tc.orgID, tc.teamID, tc.userID, orgID, teamID, userID)
+			}
+		})
+	}
+}
+
+func testAccTeamMemberConfig(name, teamName string) string {
+	return fmt.Sprintf(`
+resource "grafana_team" "test" {
+	name           = "%[2]s"
+	ignore_members = true
+}
+
+resource "grafana_user" "test" {
+	email    = "%[1]s@example.com"
+	login    = "%[1]s"
+	password = "my-password"
+}
+
+resource "grafana_team_member" "test" {
+	team_id = grafana_team.test.id
+	user_id = grafana_user.test.user_id
+}
+`, name, teamName)
+}
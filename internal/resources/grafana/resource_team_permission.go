@@ -0,0 +1,78 @@
+package grafana
+
+import (
+	"strconv"
+
+	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceTeamPermission() *common.Resource {
+	crudHelper := &resourcePermissionsHelper{
+		resourceType:        teamsPermissionsType,
+		unmanagedPermission: "Member",
+		getResource:         resourceTeamPermissionGet,
+	}
+
+	schema := &schema.Resource{
+		Description: `
+Manages the entire set of permissions for a team, i.e. who can administer it. Permissions that aren't specified when applying this resource will be removed.
+
+Grafana stores team memberships as ` + "`Member`" + ` permissions of the team. They aren't managed by this resource, use ` + "`grafana_team`" + `'s ` + "`members`" + ` or ` + "`grafana_team_member`" + ` instead.
+Granting ` + "`Admin`" + ` makes the user a member of the team. Removing the grant only takes the ` + "`Admin`" + ` permission away, the user stays a member of the team.
+
+**Note:** This resource is available only with Grafana Enterprise 9.1+.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/administration/team-management/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/developers/http_api/access_control/)`,
+
+		CreateContext: crudHelper.updatePermissions,
+		ReadContext:   crudHelper.readPermissions,
+		UpdateContext: crudHelper.updatePermissions,
+		DeleteContext: crudHelper.deletePermissions,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"team_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the team.",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					_, old = SplitOrgResourceID(old)
+					_, new = SplitOrgResourceID(new)
+					return old == new
+				},
+			},
+		},
+	}
+	crudHelper.addCommonSchemaAttributes(schema.Schema)
+
+	return common.NewLegacySDKResource(
+		common.CategoryGrafanaEnterprise,
+		"grafana_team_permission",
+		orgResourceIDInt("teamID"),
+		schema,
+	)
+}
+
+func resourceTeamPermissionGet(d *schema.ResourceData, meta interface{}) (string, error) {
+	client, _ := OAPIClientFromNewOrgResource(meta, d)
+	_, id := SplitOrgResourceID(d.Get("team_id").(string))
+	if d.Id() != "" {
+		client, _, id = OAPIClientFromExistingOrgResource(meta, d.Id())
+	}
+	teamID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return "", err
+	}
+	team, err := getTeamByID(client, teamID)
+	if err != nil {
+		return "", err
+	}
+	id = strconv.FormatInt(team.ID, 10)
+	d.Set("team_id", id)
+	return id, nil
+}
@@ -0,0 +1,86 @@
+package grafana_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
+)
+
+func TestAccTeamPermission_basic(t *testing.T) {
+	testutils.CheckEnterpriseTestsEnabled(t, ">=9.1.0")
+
+	var team models.TeamDTO
+	name := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             teamCheckExists.destroyed(&team, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: testTeamPermissionConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					teamPermissionsCheckExists.exists("grafana_team_permission.test", &team),
+					resource.TestCheckResourceAttr("grafana_team_permission.test", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("grafana_team_permission.test", "permissions.0.permission", "Admin"),
+					resource.TestCheckResourceAttrPair("grafana_team_permission.test", "permissions.0.user_id", "grafana_user.test", "id"),
+				),
+			},
+			{
+				ImportState:       true,
+				ResourceName:      "grafana_team_permission.test",
+				ImportStateVerify: true,
+			},
+			// Test destroy. The admin stays a member of the team, as configured in grafana_team
+			{
+				Config: testutils.WithoutResource(t, testTeamPermissionConfig(name), "grafana_team_permission.test"),
+				Check: resource.ComposeTestCheckFunc(
+					teamCheckExists.exists("grafana_team.test", &team),
+					teamPermissionsCheckExists.destroyed(&team, nil),
+					resource.TestCheckResourceAttr("grafana_team.test", "members.#", "1"),
+					func(s *terraform.State) error {
+						resp, err := grafanaTestClient().Teams.GetTeamMembers(strconv.FormatInt(team.ID, 10))
+						if err != nil {
+							return err
+						}
+						for _, member := range resp.Payload {
+							if member.Login == name+"@test.com" {
+								return nil
+							}
+						}
+						return fmt.Errorf("expected %s to still be a member of the team", name)
+					},
+				),
+			},
+		},
+	})
+}
+
+func testTeamPermissionConfig(name string) string {
+	return fmt.Sprintf(`
+resource "grafana_team" "test" {
+	name    = "%[1]s"
+	members = [grafana_user.test.email]
+}
+
+resource "grafana_user" "test" {
+	email    = "%[1]s@test.com"
+	login    = "%[1]s@test.com"
+	password = "password"
+}
+
+resource "grafana_team_permission" "test" {
+	team_id = grafana_team.test.id
+	permissions {
+		user_id    = grafana_user.test.id
+		permission = "Admin"
+	}
+}
+`, name)
+}
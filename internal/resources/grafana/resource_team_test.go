@@ -2,6 +2,7 @@ package grafana_test
 
 import (
 	"fmt"
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
@@ -9,9 +10,11 @@ import (
 
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
+	"github.com/grafana/terraform-provider-grafana/v3/internal/resources/grafana"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccTeam_basic(t *testing.T) {
@@ -55,6 +58,57 @@ func TestAccTeam_basic(t *testing.T) {
 	})
 }
 
+func TestAccTeam_email(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t)
+
+	var team models.TeamDTO
+	teamName := acctest.RandString(5)
+
+	config := func(email string) string {
+		return fmt.Sprintf(`
+resource "grafana_team" "test" {
+	name  = "%s"
+	email = "%s"
+}`, teamName, email)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             teamCheckExists.destroyed(&team, nil),
+		Steps: []resource.TestStep{
+			{
+				Config: config(teamName + "@example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					teamCheckExists.exists("grafana_team.test", &team),
+					resource.TestCheckResourceAttr("grafana_team.test", "email", teamName+"@example.com"),
+					resource.TestCheckResourceAttrSet("grafana_team.test", "avatar_url"),
+				),
+			},
+			{
+				Config: config(teamName + "-updated@example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					teamCheckExists.exists("grafana_team.test", &team),
+					resource.TestCheckResourceAttr("grafana_team.test", "name", teamName),
+					resource.TestCheckResourceAttr("grafana_team.test", "email", teamName+"-updated@example.com"),
+					func(s *terraform.State) error {
+						if team.Email != teamName+"-updated@example.com" {
+							return fmt.Errorf("expected the team email to be updated in Grafana, got %q", team.Email)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: config(""),
+				Check: resource.ComposeTestCheckFunc(
+					teamCheckExists.exists("grafana_team.test", &team),
+					resource.TestCheckResourceAttr("grafana_team.test", "email", ""),
+				),
+			},
+		},
+	})
+}
+
 func TestAccTeam_preferences(t *testing.T) {
 	testutils.CheckOSSTestsEnabled(t, ">= 9.0.0") // Dashboard UID is only available in Grafana 9.0.0+
 
@@ -347,6 +401,35 @@ func TestAccTeam_OrgScopedOnAPIKey(t *testing.T) {
 	})
 }
 
+func TestAccTeam_RollbackOnFailedCreation(t *testing.T) {
+	testutils.CheckOSSTestsEnabled(t, ">= 9.0.0")
+
+	var team models.TeamDTO
+	teamName := acctest.RandString(5)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testutils.ProtoV5ProviderFactories,
+		CheckDestroy:             teamCheckExists.destroyed(&team, nil),
+		Steps: []resource.TestStep{
+			// The preferences are set, then adding the member fails
+			{
+				Config:      testAccTeamDefinition(teamName, []string{`"does-not-exist-` + teamName + `@example.com"`}, true, nil),
+				ExpectError: regexp.MustCompile("User does not exist in Grafana"),
+			},
+			// The team was deleted, so a team with the same name can be created
+			{
+				Config: testAccTeamDefinition(teamName, nil, true, nil),
+				Check: resource.ComposeTestCheckFunc(
+					teamCheckExists.exists("grafana_team.test", &team),
+					resource.TestCheckResourceAttr("grafana_team.test", "name", teamName),
+					resource.TestCheckResourceAttr("grafana_team.test", "members.#", "0"),
+					resource.TestCheckResourceAttr("grafana_team.test", "preferences.0.theme", "dark"),
+				),
+			},
+		},
+	})
+}
+
 func testAccTeamDefinition(name string, teamMembers []string, withPreferences bool, externalGroups []string) string {
 	withPreferencesBlock := ""
 	if withPreferences {
@@ -421,3 +504,62 @@ resource "grafana_team" "test" {
 	members = [ ]
 }`, orgName)
 }
+
+func TestMemberChanges(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	members := func(emails ...string) map[string]grafana.TeamMember {
+		m := map[string]grafana.TeamMember{}
+		for i, email := range emails {
+			m[email] = grafana.TeamMember{ID: int64(i + 1), Email: email}
+		}
+		return m
+	}
+
+	for _, tc := range []struct {
+		name     string
+		current  map[string]grafana.TeamMember
+		config   map[string]grafana.TeamMember
+		expected []grafana.MemberChange
+	}{
+		{
+			name:    "no changes",
+			current: members("a@example.com", "b@example.com"),
+			config:  members("b@example.com", "a@example.com"),
+		},
+		{
+			name:    "add one member to a large team",
+			current: members("a@example.com", "b@example.com", "c@example.com"),
+			config:  members("a@example.com", "b@example.com", "c@example.com", "d@example.com"),
+			expected: []grafana.MemberChange{
+				{Type: grafana.AddMember, Member: grafana.TeamMember{ID: 4, Email: "d@example.com"}},
+			},
+		},
+		{
+			name:    "add and remove members",
+			current: members("a@example.com", "c@example.com", "b@example.com"),
+			config:  members("b@example.com", "e@example.com", "d@example.com"),
+			expected: []grafana.MemberChange{
+				{Type: grafana.AddMember, Member: grafana.TeamMember{ID: 3, Email: "d@example.com"}},
+				{Type: grafana.AddMember, Member: grafana.TeamMember{ID: 2, Email: "e@example.com"}},
+				{Type: grafana.RemoveMember, Member: grafana.TeamMember{ID: 1, Email: "a@example.com"}},
+				{Type: grafana.RemoveMember, Member: grafana.TeamMember{ID: 2, Email: "c@example.com"}},
+			},
+		},
+		{
+			name:    "remove all members",
+			current: members("a@example.com", "b@example.com"),
+			config:  members(),
+			expected: []grafana.MemberChange{
+				{Type: grafana.RemoveMember, Member: grafana.TeamMember{ID: 1, Email: "a@example.com"}},
+				{Type: grafana.RemoveMember, Member: grafana.TeamMember{ID: 2, Email: "b@example.com"}},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if changes := grafana.MemberChanges(tc.current, tc.config); !reflect.DeepEqual(changes, tc.expected) {
+				t.Errorf("expected changes %+v, got %+v", tc.expected, changes)
+			}
+		})
+	}
+}
@@ -88,9 +88,13 @@ var DataSources = addValidationToDataSources(
 	datasourceDashboard(),
 	datasourceDashboards(),
 	datasourceDatasource(),
+	datasourceDatasources(),
+	datasourceDatasourcesHealth(),
 	datasourceFolder(),
 	datasourceFolders(),
+	datasourceLegacyAlerts(),
 	datasourceLibraryPanel(),
+	datasourceRuleGroups(),
 	datasourceUser(),
 	datasourceUsers(),
 	datasourceRole(),
@@ -109,6 +113,7 @@ var Resources = addValidationToResources(
 	resourceAnnotation(),
 	resourceContactPoint(),
 	resourceDashboard(),
+	resourceDashboardSnapshot(),
 	resourcePublicDashboard(),
 	resourceDashboardPermission(),
 	resourceDataSource(),
@@ -129,6 +134,8 @@ var Resources = addValidationToResources(
 	resourceRuleGroup(),
 	resourceTeam(),
 	resourceTeamExternalGroup(),
+	resourceTeamMember(),
+	resourceTeamPermission(),
 	resourceServiceAccountToken(),
 	resourceServiceAccount(),
 	resourceServiceAccountPermission(),
@@ -174,14 +174,13 @@ func resourceProbeRead(ctx context.Context, d *schema.ResourceData, c *smapi.Cli
 	d.Set("region", prb.Region)
 	d.Set("public", prb.Public)
 
-	if len(prb.Labels) > 0 {
-		// Convert []sm.Label into a map before set.
-		labels := make(map[string]string, len(prb.Labels))
-		for _, l := range prb.Labels {
-			labels[l.Name] = l.Value
-		}
-		d.Set("labels", labels)
+	// Convert []sm.Label into a map before set.
+	// It's always set, so that labels added or removed outside of Terraform are reconciled on the next apply.
+	labels := make(map[string]string, len(prb.Labels))
+	for _, l := range prb.Labels {
+		labels[l.Name] = l.Value
 	}
+	d.Set("labels", labels)
 
 	if prb.Capabilities != nil {
 		d.Set("disable_scripted_checks", prb.Capabilities.DisableScriptedChecks)
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"testing"
 
+	sm "github.com/grafana/synthetic-monitoring-agent/pkg/pb/synthetic_monitoring"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -54,6 +55,47 @@ func TestAccResourceProbe(t *testing.T) {
 					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_probe.main", "disable_scripted_checks", "true"),
 				),
 			},
+			// Change the labels outside of Terraform, the next plan should reconcile them
+			{
+				Config: testutils.TestAccExampleWithReplace(t, "resources/grafana_synthetic_monitoring_probe/resource_update.tf", map[string]string{
+					"Mauna Loa": randomName + " Updated",
+				}),
+				Check: func(s *terraform.State) error {
+					client := testutils.Provider.Meta().(*common.Client).SMAPI
+					id, err := strconv.ParseInt(s.RootModule().Resources["grafana_synthetic_monitoring_probe.main"].Primary.ID, 10, 64)
+					if err != nil {
+						return err
+					}
+					probe, err := client.GetProbe(context.Background(), id)
+					if err != nil {
+						return err
+					}
+					probe.Labels = []sm.Label{{Name: "added", Value: "outside"}}
+					_, err = client.UpdateProbe(context.Background(), *probe)
+					return err
+				},
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testutils.TestAccExampleWithReplace(t, "resources/grafana_synthetic_monitoring_probe/resource_update.tf", map[string]string{
+					"Mauna Loa": randomName + " Updated",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_probe.main", "labels.%", "1"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_probe.main", "labels.type", "volcano"),
+				),
+			},
+			// Remove all labels
+			{
+				Config: testutils.TestAccExampleWithReplace(t, "resources/grafana_synthetic_monitoring_probe/resource_update.tf", map[string]string{
+					"Mauna Loa": randomName + " Updated",
+					"labels = {\n    type = \"volcano\"\n  }": "",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_probe.main", "labels.%", "0"),
+					resource.TestCheckResourceAttrSet("grafana_synthetic_monitoring_probe.main", "auth_token"),
+				),
+			},
 		},
 	})
 }
@@ -28,7 +28,9 @@ import (
 
 func CreateClients(providerConfig ProviderConfig) (*common.Client, error) {
 	var err error
-	c := &common.Client{}
+	c := &common.Client{
+		RateLimiter: common.NewRateLimiter(float64(providerConfig.APIRateLimit.ValueInt64())),
+	}
 	if !providerConfig.Auth.IsNull() && !providerConfig.URL.IsNull() {
 		if err = createGrafanaAPIClient(c, providerConfig); err != nil {
 			return nil, err
@@ -46,11 +48,11 @@ func CreateClients(providerConfig ProviderConfig) (*common.Client, error) {
 		}
 	}
 	if !providerConfig.SMAccessToken.IsNull() {
-		c.SMAPI = SMAPI.NewClient(providerConfig.SMURL.ValueString(), providerConfig.SMAccessToken.ValueString(), getRetryClient(providerConfig))
+		c.SMAPI = SMAPI.NewClient(providerConfig.SMURL.ValueString(), providerConfig.SMAccessToken.ValueString(), getRetryClient(c, providerConfig))
 	}
 	if !providerConfig.OncallAccessToken.IsNull() {
 		var onCallClient *onCallAPI.Client
-		onCallClient, err = createOnCallClient(providerConfig)
+		onCallClient, err = createOnCallClient(c, providerConfig)
 		if err != nil {
 			return nil, err
 		}
@@ -104,7 +106,10 @@ func createGrafanaAPIClient(client *common.Client, providerConfig ProviderConfig
 	if cfg.HTTPHeaders, err = getHTTPHeadersMap(providerConfig); err != nil {
 		return err
 	}
+	// The client replaces the retrying transport of the OpenAPI client. It's part of the config, so it's kept when the client is scoped to an org.
+	cfg.Client = getGrafanaAPIRetryClient(providerConfig, tlsClientConfig, cfg.HTTPHeaders)
 	client.GrafanaAPI = goapi.NewHTTPClientWithConfig(strfmt.Default, &cfg)
+	client.GrafanaAPI.SetTransport(common.RateLimitClientTransport(client.GrafanaAPI.Transport, client.RateLimiter))
 	client.GrafanaAPIConfig = &cfg
 
 	return nil
@@ -114,7 +119,7 @@ func createMLClient(client *common.Client, providerConfig ProviderConfig) error
 	mlcfg := mlapi.Config{
 		BasicAuth:   client.GrafanaAPIConfig.BasicAuth,
 		BearerToken: client.GrafanaAPIConfig.APIKey,
-		Client:      getRetryClient(providerConfig),
+		Client:      getGrafanaPluginRetryClient(client, providerConfig),
 		NumRetries:  client.GrafanaAPIConfig.NumRetries,
 	}
 	mlURL := client.GrafanaAPIURL
@@ -133,7 +138,7 @@ func createSLOClient(client *common.Client, providerConfig ProviderConfig) error
 	sloConfig.Scheme = client.GrafanaAPIURLParsed.Scheme
 	sloConfig.DefaultHeader["Authorization"] = "Bearer " + providerConfig.Auth.ValueString()
 	sloConfig.DefaultHeader["Grafana-Terraform-Provider"] = "true"
-	sloConfig.HTTPClient = getRetryClient(providerConfig)
+	sloConfig.HTTPClient = getGrafanaPluginRetryClient(client, providerConfig)
 	client.SLOClient = slo.NewAPIClient(sloConfig)
 	return nil
 }
@@ -146,7 +151,7 @@ func createCloudClient(client *common.Client, providerConfig ProviderConfig) err
 	}
 	openAPIConfig.Host = parsedURL.Host
 	openAPIConfig.Scheme = "https"
-	openAPIConfig.HTTPClient = getRetryClient(providerConfig)
+	openAPIConfig.HTTPClient = getRetryClient(client, providerConfig)
 	openAPIConfig.DefaultHeader["Authorization"] = "Bearer " + providerConfig.CloudAccessPolicyToken.ValueString()
 	httpHeaders, err := getHTTPHeadersMap(providerConfig)
 	if err != nil {
@@ -160,7 +165,7 @@ func createCloudClient(client *common.Client, providerConfig ProviderConfig) err
 	return nil
 }
 
-func createOnCallClient(providerConfig ProviderConfig) (*onCallAPI.Client, error) {
+func createOnCallClient(client *common.Client, providerConfig ProviderConfig) (*onCallAPI.Client, error) {
 	tlsClientConfig, err := parseTLSconfig(providerConfig)
 	if err != nil {
 		return nil, err
@@ -173,9 +178,9 @@ func createOnCallClient(providerConfig ProviderConfig) (*onCallAPI.Client, error
 
 	retryClient := retryablehttp.NewClient()
 	retryClient.HTTPClient = &http.Client{
-		Transport: &http.Transport{
+		Transport: common.RateLimitRoundTripper(&http.Transport{
 			TLSClientConfig: tlsClientConfig,
-		},
+		}, client.RateLimiter),
 	}
 	onCallClient.Client = retryClient
 
@@ -289,12 +294,34 @@ func setToStringArray(set []attr.Value) []string {
 	return result
 }
 
-func getRetryClient(providerConfig ProviderConfig) *http.Client {
-	retryClient := retryablehttp.NewClient()
-	retryClient.RetryMax = int(providerConfig.Retries.ValueInt64())
-	if wait := providerConfig.RetryWait.ValueInt64(); wait > 0 {
-		retryClient.RetryWaitMin = time.Second * time.Duration(wait)
-		retryClient.RetryWaitMax = time.Second * time.Duration(wait)
-	}
+func newRetryClient(providerConfig ProviderConfig) *retryablehttp.Client {
+	wait := time.Second * time.Duration(providerConfig.RetryWait.ValueInt64())
+	return common.NewRetryClient(int(providerConfig.Retries.ValueInt64()), wait, setToStringArray(providerConfig.RetryStatusCodes.Elements())...)
+}
+
+func getRetryClient(client *common.Client, providerConfig ProviderConfig) *http.Client {
+	retryClient := newRetryClient(providerConfig)
+	// Each attempt counts towards the rate limit
+	retryClient.HTTPClient.Transport = common.RateLimitRoundTripper(retryClient.HTTPClient.Transport, client.RateLimiter)
 	return retryClient.StandardClient()
 }
+
+// getGrafanaAPIRetryClient is getRetryClient for the Grafana API client, with the TLS config and the `http_headers` of the provider.
+// The operations of that client are already rate limited by its transport, so the attempts aren't limited again.
+func getGrafanaAPIRetryClient(providerConfig ProviderConfig, tlsClientConfig *tls.Config, headers map[string]string) *http.Client {
+	retryClient := newRetryClient(providerConfig)
+	if transport, ok := retryClient.HTTPClient.Transport.(*http.Transport); ok {
+		transport.TLSClientConfig = tlsClientConfig
+	}
+	httpClient := retryClient.StandardClient()
+	httpClient.Transport = common.HeadersRoundTripper(httpClient.Transport, headers)
+	return httpClient
+}
+
+// getGrafanaPluginRetryClient is getRetryClient for the APIs of Grafana plugins (ML, SLO).
+// They're served by the Grafana server, so they also get the `http_headers` of the provider, e.g. for a gateway in front of Grafana.
+func getGrafanaPluginRetryClient(client *common.Client, providerConfig ProviderConfig) *http.Client {
+	httpClient := getRetryClient(client, providerConfig)
+	httpClient.Transport = common.HeadersRoundTripper(httpClient.Transport, client.GrafanaAPIConfig.HTTPHeaders)
+	return httpClient
+}
@@ -23,6 +23,7 @@ type ProviderConfig struct {
 	Retries          types.Int64  `tfsdk:"retries"`
 	RetryStatusCodes types.Set    `tfsdk:"retry_status_codes"`
 	RetryWait        types.Int64  `tfsdk:"retry_wait"`
+	APIRateLimit     types.Int64  `tfsdk:"api_rate_limit"`
 
 	TLSKey             types.String `tfsdk:"tls_key"`
 	TLSCert            types.String `tfsdk:"tls_cert"`
@@ -66,6 +67,9 @@ func (c *ProviderConfig) SetDefaults() error {
 	if c.RetryWait, err = envDefaultFuncInt64(c.RetryWait, "GRAFANA_RETRY_WAIT", 0); err != nil {
 		return fmt.Errorf("failed to parse GRAFANA_RETRY_WAIT: %w", err)
 	}
+	if c.APIRateLimit, err = envDefaultFuncInt64(c.APIRateLimit, "GRAFANA_API_RATE_LIMIT", 0); err != nil {
+		return fmt.Errorf("failed to parse GRAFANA_API_RATE_LIMIT: %w", err)
+	}
 	if c.InsecureSkipVerify, err = envDefaultFuncBool(c.InsecureSkipVerify, "GRAFANA_INSECURE_SKIP_VERIFY", false); err != nil {
 		return fmt.Errorf("failed to parse GRAFANA_INSECURE_SKIP_VERIFY: %w", err)
 	}
@@ -124,7 +128,7 @@ func (p *frameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 			"http_headers": schema.MapAttribute{
 				Optional:            true,
 				Sensitive:           true,
-				MarkdownDescription: "Optional. HTTP headers mapping keys to values used for accessing the Grafana and Grafana Cloud APIs. May alternatively be set via the `GRAFANA_HTTP_HEADERS` environment variable in JSON format.",
+				MarkdownDescription: "Optional. HTTP headers mapping keys to values used for accessing the Grafana and Grafana Cloud APIs, including the APIs of the Machine Learning and SLO plugins. May alternatively be set via the `GRAFANA_HTTP_HEADERS` environment variable in JSON format.",
 				ElementType:         types.StringType,
 			},
 			"retries": schema.Int64Attribute{
@@ -133,12 +137,16 @@ func (p *frameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 			},
 			"retry_status_codes": schema.SetAttribute{
 				Optional:            true,
-				MarkdownDescription: "The status codes to retry on for Grafana API and Grafana Cloud API calls. Use `x` as a digit wildcard. Defaults to 429 and 5xx. May alternatively be set via the `GRAFANA_RETRY_STATUS_CODES` environment variable.",
+				MarkdownDescription: "The status codes to retry on for Grafana API and Grafana Cloud API calls. Use `x` as a digit wildcard. Defaults to 429, 502, 503 and 504. Only idempotent requests (GET, HEAD, OPTIONS, PUT, DELETE) are retried. May alternatively be set via the `GRAFANA_RETRY_STATUS_CODES` environment variable.",
 				ElementType:         types.StringType,
 			},
 			"retry_wait": schema.Int64Attribute{
 				Optional:            true,
-				MarkdownDescription: "The amount of time in seconds to wait between retries for Grafana API and Grafana Cloud API calls. May alternatively be set via the `GRAFANA_RETRY_WAIT` environment variable.",
+				MarkdownDescription: "The amount of time in seconds to wait before the first retry of Grafana API and Grafana Cloud API calls. The wait doubles (with jitter) for each following retry, and the `Retry-After` header of 429 responses is honored. May alternatively be set via the `GRAFANA_RETRY_WAIT` environment variable.",
+			},
+			"api_rate_limit": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The maximum number of requests per second sent by the provider, to all the Grafana, Grafana Cloud, Synthetic Monitoring, SLO, Machine Learning and OnCall APIs combined. Useful to stay under the API rate limits of Grafana Cloud when managing many resources. Defaults to 0 (unlimited). May alternatively be set via the `GRAFANA_API_RATE_LIMIT` environment variable.",
 			},
 			"tls_key": schema.StringAttribute{
 				Optional:            true,
@@ -47,7 +47,7 @@ func Provider(version string) *schema.Provider {
 				Optional:    true,
 				Sensitive:   true,
 				Elem:        &schema.Schema{Type: schema.TypeString},
-				Description: "Optional. HTTP headers mapping keys to values used for accessing the Grafana and Grafana Cloud APIs. May alternatively be set via the `GRAFANA_HTTP_HEADERS` environment variable in JSON format.",
+				Description: "Optional. HTTP headers mapping keys to values used for accessing the Grafana and Grafana Cloud APIs, including the APIs of the Machine Learning and SLO plugins. May alternatively be set via the `GRAFANA_HTTP_HEADERS` environment variable in JSON format.",
 			},
 			"retries": {
 				Type:        schema.TypeInt,
@@ -57,13 +57,18 @@ func Provider(version string) *schema.Provider {
 			"retry_status_codes": {
 				Type:        schema.TypeSet,
 				Optional:    true,
-				Description: "The status codes to retry on for Grafana API and Grafana Cloud API calls. Use `x` as a digit wildcard. Defaults to 429 and 5xx. May alternatively be set via the `GRAFANA_RETRY_STATUS_CODES` environment variable.",
+				Description: "The status codes to retry on for Grafana API and Grafana Cloud API calls. Use `x` as a digit wildcard. Defaults to 429, 502, 503 and 504. Only idempotent requests (GET, HEAD, OPTIONS, PUT, DELETE) are retried. May alternatively be set via the `GRAFANA_RETRY_STATUS_CODES` environment variable.",
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
 			"retry_wait": {
 				Type:        schema.TypeInt,
 				Optional:    true,
-				Description: "The amount of time in seconds to wait between retries for Grafana API and Grafana Cloud API calls. May alternatively be set via the `GRAFANA_RETRY_WAIT` environment variable.",
+				Description: "The amount of time in seconds to wait before the first retry of Grafana API and Grafana Cloud API calls. The wait doubles (with jitter) for each following retry, and the `Retry-After` header of 429 responses is honored. May alternatively be set via the `GRAFANA_RETRY_WAIT` environment variable.",
+			},
+			"api_rate_limit": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The maximum number of requests per second sent by the provider, to all the Grafana, Grafana Cloud, Synthetic Monitoring, SLO, Machine Learning and OnCall APIs combined. Useful to stay under the API rate limits of Grafana Cloud when managing many resources. Defaults to 0 (unlimited). May alternatively be set via the `GRAFANA_API_RATE_LIMIT` environment variable.",
 			},
 			"tls_key": {
 				Type:        schema.TypeString,
@@ -179,6 +184,7 @@ func configure(version string, p *schema.Provider) func(context.Context, *schema
 			Retries:                int64ValueOrNull(d, "retries"),
 			RetryStatusCodes:       statusCodes,
 			RetryWait:              types.Int64Value(int64(d.Get("retry_wait").(int))),
+			APIRateLimit:           int64ValueOrNull(d, "api_rate_limit"),
 			UserAgent:              types.StringValue(p.UserAgent("terraform-provider-grafana", version)),
 		}
 		if err := cfg.SetDefaults(); err != nil {
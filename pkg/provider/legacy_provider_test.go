@@ -2,14 +2,18 @@ package provider_test
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/common"
 	"github.com/grafana/terraform-provider-grafana/v3/internal/testutils"
 	"github.com/grafana/terraform-provider-grafana/v3/pkg/provider"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -175,3 +179,47 @@ func TestProviderConfigure(t *testing.T) {
 		})
 	}
 }
+
+func TestGrafanaAPIRetries(t *testing.T) {
+	testutils.IsUnitTest(t)
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls%3 != 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1, "name": "Main Org."}`)) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	client, err := provider.CreateClients(provider.ProviderConfig{
+		URL:     types.StringValue(server.URL),
+		Auth:    types.StringValue("admin:admin"),
+		Retries: types.Int64Value(2),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.GrafanaAPI.Org.GetCurrentOrg(); err != nil {
+		t.Fatalf("expected the request to be retried until it succeeds, got %s", err)
+	}
+	// The clients scoped to an org keep retrying
+	if _, err := client.GrafanaAPI.Clone().WithOrgID(2).Org.GetCurrentOrg(); err != nil {
+		t.Fatalf("expected the request of the org client to be retried until it succeeds, got %s", err)
+	}
+	if calls != 6 {
+		t.Errorf("expected 6 calls, got %d", calls)
+	}
+
+	// Non-idempotent requests aren't retried
+	if _, err := client.GrafanaAPI.Orgs.CreateOrg(&models.CreateOrgCommand{Name: "test"}); err == nil {
+		t.Fatal("expected the request to fail without being retried")
+	}
+	if calls != 7 {
+		t.Errorf("expected 7 calls, got %d", calls)
+	}
+}